@@ -1,8 +1,11 @@
 package main
 
 import (
+	"time"
+
 	"github.com/WHITE13452/toDoList/internal/cli"
 	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/WHITE13452/toDoList/internal/quickadd"
 	"github.com/spf13/cobra"
 )
 
@@ -10,41 +13,149 @@ var (
 	taskDescription string
 	taskCategory    string
 	taskPriority    int
+	taskDue         string
+	taskSnooze      string
+	addRaw          bool
+	addDryRun       bool
+	taskProject     string
+	taskEstimate    string
 )
 
 var addCmd = &cobra.Command{
 	Use:   "add [title]",
 	Short: "添加新任务",
-	Long:  "添加一个新的待办事项。标题为必填参数，描述、分类和优先级为可选。",
-	Args:  cobra.ExactArgs(1),
+	Long: `添加一个新的待办事项。标题为必填参数，描述、分类和优先级为可选。
+
+标题支持快速添加语法（使用 --raw 关闭）：
+  !3 或 !!!      优先级
+  #work          分类 (work/study/life/other)，其他 # 开头的词保留在标题中
+  +release       标签，不能包含逗号
+  @launch        项目
+  due:fri        截止时间，多个词用引号或下划线，例如 due:next_friday
+  snooze:3d      延后时间
+  \#1            按原样保留在标题中
+
+  todo add "Prepare demo !3 #work +release due:fri"
+  todo add "写周报 #工作 !!! due:周五下午"
+
+命令行参数优先于标题中的语法。--due 和 --snooze 支持自然语言时间，例如：
+  todo add "写周报" --due friday
+  todo add "准备演示" --due "明天下午三点"
+  todo add "续费域名" --due 2026-11-01 --snooze "next monday"`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		title := args[0]
 
+		// 未指定的分类和优先级使用配置中的默认值，快速添加语法优先于配置
+		if !cmd.Flags().Changed("category") {
+			taskCategory = string(defaultCategory())
+		}
+		if !cmd.Flags().Changed("priority") {
+			taskPriority = int(defaultPriority())
+		}
+
+		// 解析快速添加语法
+		var parsed *quickadd.Result
+		if !addRaw {
+			result, err := quickadd.Parse(title, time.Now())
+			if err != nil {
+				cli.Error("error.quick_add", err)
+				return
+			}
+			parsed = result
+			title = parsed.Title
+			if parsed.Category != "" && !cmd.Flags().Changed("category") {
+				taskCategory = string(parsed.Category)
+			}
+			if parsed.Priority != 0 && !cmd.Flags().Changed("priority") {
+				taskPriority = int(parsed.Priority)
+			}
+			if parsed.Project != "" && !cmd.Flags().Changed("project") {
+				taskProject = parsed.Project
+			}
+		}
+
 		// 验证分类
 		category := models.TaskCategory(taskCategory)
 		if category != models.CategoryWork && category != models.CategoryStudy &&
 			category != models.CategoryLife && category != models.CategoryOther {
-			cli.PrintError("无效的分类，必须是 work, study, life 或 other")
+			cli.Error("error.invalid_category")
 			return
 		}
 
 		// 验证优先级
 		priority := models.Priority(taskPriority)
 		if priority < models.PriorityLow || priority > models.PriorityUrgent {
-			cli.PrintError("无效的优先级，必须是 1-4")
+			cli.Error("error.invalid_priority")
 			return
 		}
 
+		// 验证项目
+		var project *models.Project
+		if taskProject != "" {
+			var err error
+			if project, err = resolveProject(taskProject); err != nil {
+				cli.PrintError("%v", err)
+				return
+			}
+			if project.Archived {
+				cli.Error("error.project_archived", project.Name)
+				return
+			}
+		}
+
 		// 创建任务
 		task := models.NewTask(title, taskDescription, category, priority)
+		if project != nil {
+			task.ProjectID = project.ID
+		}
+		if parsed != nil {
+			task.Tags = parsed.Tags
+			task.DueAt = parsed.DueAt
+			task.DeferUntil = parsed.DeferUntil
+		}
+
+		if taskDue != "" {
+			due, err := parseTime(taskDue, dueDefaultClock)
+			if err != nil {
+				cli.PrintError("%v", err)
+				return
+			}
+			task.DueAt = &due
+		}
+
+		if taskSnooze != "" {
+			until, err := parseTimeOrDuration(taskSnooze, deferDefaultClock)
+			if err != nil {
+				cli.PrintError("%v", err)
+				return
+			}
+			task.Defer(&until)
+		}
+
+		if taskEstimate != "" {
+			estimate, err := parseEstimate(taskEstimate)
+			if err != nil {
+				cli.PrintError("%v", err)
+				return
+			}
+			task.Estimate = estimate
+		}
+
+		if parsed != nil && parsed.Title != args[0] {
+			cli.PrintParsedTask(task)
+		}
+		if addDryRun {
+			return
+		}
 
 		// 保存任务
 		if err := store.AddTask(task); err != nil {
-			cli.PrintError("添加任务失败: %v", err)
+			cli.Error("error.add_task", err)
 			return
 		}
 
-		cli.PrintSuccess("任务已添加 (ID: %d)", task.ID)
+		cli.Success("task.added", task.ID)
 		cli.PrintTask(task, false)
 	},
 }
@@ -53,6 +164,12 @@ func init() {
 	rootCmd.AddCommand(addCmd)
 
 	addCmd.Flags().StringVarP(&taskDescription, "description", "d", "", "任务描述")
-	addCmd.Flags().StringVarP(&taskCategory, "category", "c", "other", "任务分类 (work/study/life/other)")
-	addCmd.Flags().IntVarP(&taskPriority, "priority", "p", 2, "优先级 (1:低 2:中 3:高 4:紧急)")
+	addCmd.Flags().StringVarP(&taskCategory, "category", "c", "other", "任务分类 (work/study/life/other)，默认读取配置中的 defaults.category")
+	addCmd.Flags().IntVarP(&taskPriority, "priority", "p", 2, "优先级 (1:低 2:中 3:高 4:紧急)，默认读取配置中的 defaults.priority")
+	addCmd.Flags().StringVar(&taskDue, "due", "", "截止时间 (例如 tomorrow 5pm、下周五、2026-11-01)")
+	addCmd.Flags().StringVar(&taskSnooze, "snooze", "", "延后到指定时间再显示 (例如 3d、next monday)")
+	addCmd.Flags().StringVarP(&taskProject, "project", "P", "", "所属项目 (名称或 ID)")
+	addCmd.Flags().StringVarP(&taskEstimate, "estimate", "e", "", "工作量估算，时长或故事点 (例如 2h、1h30m、3pt)")
+	addCmd.Flags().BoolVar(&addRaw, "raw", false, "不解析标题中的快速添加语法")
+	addCmd.Flags().BoolVarP(&addDryRun, "dry-run", "n", false, "只显示解析结果，不保存任务")
 }
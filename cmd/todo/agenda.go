@@ -0,0 +1,72 @@
+package main
+
+import (
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/WHITE13452/toDoList/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	agendaDays    int
+	agendaProject string
+	agendaAll     bool
+	agendaJSON    bool
+)
+
+var agendaCmd = &cobra.Command{
+	Use:   "agenda",
+	Short: "查看议程：已逾期、今天和接下来几天到期的任务",
+	Long: `按天列出即将到期的未完成任务，已逾期的任务排在最前面。
+
+  todo agenda              # 今天和接下来 7 天
+  todo agenda -n 14 -P launch
+  todo agenda --json       # 输出 JSON，供脚本使用`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if agendaDays < 0 {
+			cli.Error("agenda.negative_days")
+			return
+		}
+
+		projectID, err := resolveProjectID(agendaProject)
+		if err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+
+		now := time.Now()
+		end := models.AgendaEnd(now, agendaDays)
+		tasks, err := store.GetAllTasks(storage.TaskFilter{
+			Status:          models.StatusPending,
+			SortBy:          "due_at",
+			ProjectID:       projectID,
+			IncludeDeferred: agendaAll,
+			DueBefore:       &end,
+		})
+		if err != nil {
+			cli.Error("error.list_tasks", err)
+			return
+		}
+
+		agenda := models.NewAgenda(tasks, now, agendaDays)
+		if outputJSON(agendaJSON) {
+			if err := cli.PrintJSON(agenda); err != nil {
+				cli.Error("error.json", err)
+			}
+			return
+		}
+		cli.PrintAgenda(agenda)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(agendaCmd)
+
+	agendaCmd.Flags().IntVarP(&agendaDays, "days", "n", 7, "显示今天之后的天数")
+	agendaCmd.Flags().StringVarP(&agendaProject, "project", "P", "", "只显示该项目下的任务 (名称或 ID)")
+	agendaCmd.Flags().BoolVarP(&agendaAll, "all", "a", false, "包含延后中的任务")
+	agendaCmd.Flags().BoolVar(&agendaJSON, "json", false, "以 JSON 格式输出")
+}
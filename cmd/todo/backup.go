@@ -0,0 +1,79 @@
+package main
+
+import (
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupOutput string
+	backupDir    string
+	backupKeep   int
+	backupList   bool
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "备份数据库",
+	Long: `使用 SQLite 在线备份 API 备份数据库，备份期间不影响其他命令读写。
+
+默认备份到数据库所在目录下的 backups 目录，文件名带时间戳，只保留最新的 --keep 个手动备份。
+升级 schema、恢复备份和 'todo doctor --fix' 之前会自动备份，这些自动备份不参与轮换。
+
+  todo backup                      # 备份并只保留最新 10 个
+  todo backup --keep 30
+  todo backup -o ~/todo-backup.db  # 备份到指定文件
+  todo backup --list               # 查看已有的备份`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		dir := backupDir
+		if dir == "" {
+			dir = storage.BackupDir(store.Path())
+		}
+
+		if backupList {
+			backups, err := storage.ListBackups(dir, store.Path())
+			if err != nil {
+				cli.PrintError("%v", err)
+				return
+			}
+			cli.PrintBackups(backups)
+			return
+		}
+
+		dest := backupOutput
+		if dest == "" {
+			dest = storage.BackupPath(dir, store.Path(), time.Now(), "")
+		}
+		if err := store.Backup(dest); err != nil {
+			cli.Error("backup.failed", err)
+			return
+		}
+		cli.Success("backup.done", dest)
+
+		// 指定了输出文件时不轮换
+		if backupOutput != "" {
+			return
+		}
+		removed, err := storage.RotateBackups(dir, store.Path(), backupKeep)
+		if err != nil {
+			cli.Error("backup.prune_failed", err)
+			return
+		}
+		if len(removed) > 0 {
+			cli.Info("backup.pruned", len(removed), backupKeep)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(backupCmd)
+
+	backupCmd.Flags().StringVarP(&backupOutput, "output", "o", "", "备份到指定文件，不参与轮换")
+	backupCmd.Flags().StringVar(&backupDir, "dir", "", "备份目录，默认为数据库所在目录下的 backups")
+	backupCmd.Flags().IntVar(&backupKeep, "keep", 10, "保留最新的手动备份数量")
+	backupCmd.Flags().BoolVarP(&backupList, "list", "l", false, "列出已有的备份")
+}
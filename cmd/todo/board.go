@@ -0,0 +1,43 @@
+package main
+
+import (
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	boardProject string
+	boardAll     bool
+)
+
+var boardCmd = &cobra.Command{
+	Use:   "board",
+	Short: "以看板形式显示任务",
+	Long:  "按工作流状态分列显示任务。延后中的任务默认隐藏，使用 --all 显示。终端宽度读取 COLUMNS 环境变量。",
+	Run: func(cmd *cobra.Command, args []string) {
+		projectID, err := resolveProjectID(boardProject)
+		if err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+
+		tasks, err := store.GetAllTasks(storage.TaskFilter{
+			ProjectID:       projectID,
+			IncludeDeferred: boardAll,
+		})
+		if err != nil {
+			cli.Error("error.list_tasks", err)
+			return
+		}
+
+		cli.PrintBoard(store.Workflow(), tasks)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(boardCmd)
+
+	boardCmd.Flags().StringVarP(&boardProject, "project", "P", "", "只显示该项目下的任务 (名称或 ID)")
+	boardCmd.Flags().BoolVarP(&boardAll, "all", "a", false, "包含延后中的任务")
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/WHITE13452/toDoList/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	calendarMonth   string
+	calendarProject string
+	calendarJSON    bool
+)
+
+var calendarCmd = &cobra.Command{
+	Use:   "calendar",
+	Short: "以月历形式查看到期任务",
+	Long: `显示一个月的日历，每天标出到期的任务数：
+  !3  有逾期未完成的任务
+  •2  还有未完成的任务
+  ✓1  全部已完成
+
+  todo calendar                    # 本月
+  todo calendar --month 2026-11
+  todo calendar --month "next month" --json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		month := time.Now()
+		if calendarMonth != "" {
+			if t, err := time.ParseInLocation("2006-01", calendarMonth, time.Local); err == nil {
+				month = t
+			} else if t, err := parseTime(calendarMonth, 0); err == nil {
+				month = t
+			} else {
+				cli.Error("calendar.invalid_month", calendarMonth)
+				return
+			}
+		}
+
+		projectID, err := resolveProjectID(calendarProject)
+		if err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+
+		start, end := models.MonthRange(month)
+		tasks, err := store.GetAllTasks(storage.TaskFilter{
+			ProjectID:       projectID,
+			IncludeDeferred: true,
+			DueAfter:        &start,
+			DueBefore:       &end,
+		})
+		if err != nil {
+			cli.Error("error.list_tasks", err)
+			return
+		}
+
+		calendar := models.NewCalendar(tasks, month, time.Now())
+		if outputJSON(calendarJSON) {
+			if err := cli.PrintJSON(calendar); err != nil {
+				cli.Error("error.json", err)
+			}
+			return
+		}
+		cli.PrintCalendar(calendar)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(calendarCmd)
+
+	calendarCmd.Flags().StringVarP(&calendarMonth, "month", "m", "", "要显示的月份，默认本月")
+	calendarCmd.Flags().StringVarP(&calendarProject, "project", "P", "", "只显示该项目下的任务 (名称或 ID)")
+	calendarCmd.Flags().BoolVar(&calendarJSON, "json", false, "以 JSON 格式输出")
+}
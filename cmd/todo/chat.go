@@ -6,9 +6,12 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/WHITE13452/toDoList/internal/agent"
 	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/WHITE13452/toDoList/internal/quickadd"
 	"github.com/WHITE13452/toDoList/internal/tools"
 	"github.com/spf13/cobra"
 )
@@ -32,34 +35,12 @@ var chatCmd = &cobra.Command{
 • QWEN_API_BASE - API Base URL（可选，默认: https://dashscope.aliyuncs.com/compatible-mode/v1）
 • QWEN_MODEL - 模型名称（可选，默认: qwen-plus）`,
 	Run: func(cmd *cobra.Command, args []string) {
-		// 获取配置
-		apiKey := os.Getenv("QWEN_API_KEY")
-		if apiKey == "" {
-			cli.PrintError("未找到 QWEN_API_KEY 环境变量")
-			fmt.Println("\n请确保设置了 QWEN_API_KEY 环境变量。")
-			fmt.Println("你可以创建一个 .env 文件并添加：")
-			fmt.Println("QWEN_API_KEY=your_api_key_here")
+		// 创建 Agent
+		agentInstance, ok := newAgent()
+		if !ok {
 			return
 		}
 
-		baseURL := os.Getenv("QWEN_API_BASE")
-		if baseURL == "" {
-			baseURL = "https://dashscope.aliyuncs.com/compatible-mode/v1"
-		}
-
-		model := os.Getenv("QWEN_MODEL")
-		if model == "" {
-			model = "qwen-plus"
-		}
-
-		// 创建 Agent
-		todoTools := tools.New(store)
-		agentInstance := agent.New(agent.Config{
-			APIKey:  apiKey,
-			BaseURL: baseURL,
-			Model:   model,
-		}, todoTools)
-
 		// 打印欢迎信息
 		cli.PrintAgentWelcome()
 
@@ -69,10 +50,10 @@ var chatCmd = &cobra.Command{
 
 		for {
 			// 获取用户输入
-			fmt.Print("\n你: ")
+			fmt.Print("\n" + i18n.T("chat.prompt"))
 			userInput, err := reader.ReadString('\n')
 			if err != nil {
-				cli.PrintError("读取输入失败: %v", err)
+				cli.Error("chat.read_failed", err)
 				break
 			}
 
@@ -83,30 +64,28 @@ var chatCmd = &cobra.Command{
 
 			// 检查退出命令
 			if userInput == "exit" || userInput == "quit" || userInput == "退出" || userInput == "q" {
-				cli.PrintInfo("再见！")
+				cli.Info("chat.bye")
 				break
 			}
 
+			// "+ " 开头的输入直接按快速添加语法添加任务，不调用大模型
+			if text, ok := strings.CutPrefix(userInput, "+ "); ok {
+				quickAddTask(text)
+				continue
+			}
+
 			// 处理快捷命令
 			switch userInput {
 			case "list", "ls", "列表", "显示":
-				userInput = "显示所有待办任务"
+				userInput = i18n.T("chat.ask_list")
 			case "stats", "statistics", "统计":
-				userInput = "显示统计信息和总结"
+				userInput = i18n.T("chat.ask_stats")
 			case "help", "h", "帮助":
-				fmt.Println("\n可用命令：")
-				fmt.Println("• list/ls - 显示所有任务")
-				fmt.Println("• stats - 显示统计信息")
-				fmt.Println("• help - 显示此帮助")
-				fmt.Println("• exit - 退出")
-				fmt.Println("\n或者直接用自然语言描述你想做什么，例如：")
-				fmt.Println("• '帮我添加一个任务：准备项目演示'")
-				fmt.Println("• '完成任务 3'")
-				fmt.Println("• '有哪些工作相关的未完成任务？'")
+				fmt.Println("\n" + i18n.T("chat.help"))
 				continue
 			case "clear", "cls", "清屏":
 				agentInstance.ClearHistory()
-				cli.PrintInfo("对话历史已清空")
+				cli.Info("chat.cleared")
 				continue
 			}
 
@@ -114,7 +93,7 @@ var chatCmd = &cobra.Command{
 			fmt.Println()
 			response, err := agentInstance.Chat(ctx, userInput)
 			if err != nil {
-				cli.PrintError("Agent 错误: %v", err)
+				cli.Error("chat.agent_failed", err)
 				continue
 			}
 
@@ -127,3 +106,53 @@ var chatCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(chatCmd)
 }
+
+// newAgent 根据环境变量创建 Agent，未配置 API Key 时打印提示并返回 false
+func newAgent() (*agent.Agent, bool) {
+	agentInstance, err := loadAgent()
+	if err != nil {
+		cli.Error("chat.no_api_key")
+		fmt.Println("\n" + i18n.T("chat.api_key_hint"))
+		return nil, false
+	}
+	return agentInstance, true
+}
+
+// loadAgent 根据配置中的 llm 项创建 Agent，不打印任何内容。
+// 环境变量 QWEN_API_KEY、QWEN_API_BASE、QWEN_MODEL 已在加载配置时合并
+func loadAgent() (*agent.Agent, error) {
+	if cfg.LLM.APIKey == "" {
+		return nil, fmt.Errorf("LLM API key is not set (QWEN_API_KEY or llm.api_key)")
+	}
+
+	return agent.New(agent.Config{
+		APIKey:  cfg.LLM.APIKey,
+		BaseURL: cfg.LLMBaseURL(),
+		Model:   cfg.LLMModel(),
+	}, tools.New(store)), nil
+}
+
+// quickAddTask 用快速添加语法直接添加任务
+func quickAddTask(text string) {
+	parsed, err := quickadd.Parse(text, time.Now())
+	if err != nil {
+		cli.Error("error.quick_add", err)
+		return
+	}
+
+	task := parsed.Task(defaultCategory(), defaultPriority())
+	if parsed.Project != "" {
+		project, err := resolveProject(parsed.Project)
+		if err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+		task.ProjectID = project.ID
+	}
+	cli.PrintParsedTask(task)
+	if err := store.AddTask(task); err != nil {
+		cli.Error("error.add_task", err)
+		return
+	}
+	cli.Success("task.added", task.ID)
+}
@@ -7,30 +7,40 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var uncomplete bool
+var (
+	uncomplete    bool
+	completeForce bool
+)
 
 var completeCmd = &cobra.Command{
 	Use:   "complete [task_id]",
 	Short: "标记任务完成/未完成",
-	Long:  "标记任务为已完成或未完成（使用 -u 参数）。",
+	Long:  "标记任务为已完成或未完成（使用 -u 参数）。不受看板工作流转换规则的限制，按规则移动请使用 'todo move'。",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		taskID, err := strconv.ParseInt(args[0], 10, 64)
 		if err != nil {
-			cli.PrintError("无效的任务 ID")
+			cli.Error("error.invalid_task_id")
 			return
 		}
 
 		task, err := store.GetTask(taskID)
 		if err != nil {
-			cli.PrintError("获取任务失败: %v", err)
+			cli.Error("error.get_task", err)
 			return
 		}
 		if task == nil {
-			cli.PrintError("任务 %d 不存在", taskID)
+			cli.Error("error.task_not_found", taskID)
+			return
+		}
+
+		if !uncomplete && task.IsBlocked() && !completeForce {
+			cli.Error("complete.blocked",
+				taskID, cli.FormatTaskIDs(task.BlockedBy))
 			return
 		}
 
+		// complete 是不经过工作流转换规则的快捷方式，任何状态都可以直接完成或重新打开
 		if uncomplete {
 			task.MarkPending()
 		} else {
@@ -38,14 +48,14 @@ var completeCmd = &cobra.Command{
 		}
 
 		if err := store.UpdateTask(task); err != nil {
-			cli.PrintError("更新任务失败: %v", err)
+			cli.Error("error.update_task", err)
 			return
 		}
 
 		if uncomplete {
-			cli.PrintSuccess("任务 %d 已标记为未完成", taskID)
+			cli.Success("complete.undone", taskID)
 		} else {
-			cli.PrintSuccess("任务 %d 已完成", taskID)
+			cli.Success("task.completed", taskID)
 		}
 
 		cli.PrintTask(task, false)
@@ -56,4 +66,5 @@ func init() {
 	rootCmd.AddCommand(completeCmd)
 
 	completeCmd.Flags().BoolVarP(&uncomplete, "uncomplete", "u", false, "标记为未完成")
+	completeCmd.Flags().BoolVarP(&completeForce, "force", "f", false, "忽略未完成的前置任务")
 }
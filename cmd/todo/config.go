@@ -0,0 +1,283 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/config"
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// cfg 合并后的配置，在 setupConfig 中加载
+	cfg *config.Resolved
+	// profileFlag --profile 参数，实际在 setupConfig 中提前解析
+	profileFlag string
+
+	// cfgErr 选中的 profile 不存在，除 'todo config set' 外的命令都会报错退出
+	cfgErr error
+
+	configLocal bool
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "查看和修改配置",
+	Long: `查看和修改配置。
+
+配置按优先级从低到高合并：内置默认值 < 用户配置 (~/.config/todo/config.yaml)
+< 项目配置 (从当前目录向上查找 .todo.yaml) < profile < 环境变量 < 命令行参数。
+项目配置中的 db、llm.provider、llm.api_key、llm.base_url 只在 trusted_projects 列出的项目目录中生效。
+
+使用 --profile 或 TODO_PROFILE 选择配置文件中 profiles 下的一组配置，例如：
+  todo config set db ~/todo/work.db --profile work
+  todo --profile work list`,
+	// 配置命令不需要打开数据库，配置有误时也可以用来修正
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		checkConfig(cmd)
+	},
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出生效的配置及其来源",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		// 应用 --db、--workspace 参数，显示实际使用的值
+		if _, err := resolveDBPath(cmd); err != nil {
+			cli.PrintError("%v", err)
+		}
+
+		table := cli.NewTable(
+			cli.Column{Header: i18n.T("config.column_key")},
+			cli.Column{Header: i18n.T("config.column_value"), MinWidth: 10, Flex: true},
+			cli.Column{Header: i18n.T("config.column_source")},
+		)
+		for _, key := range config.Keys() {
+			value, source := effectiveValue(key)
+			if value != "" && config.IsSecret(key) {
+				value = maskSecret(value)
+			}
+			table.AddRow(key, value, source)
+		}
+		table.Render(os.Stdout)
+
+		fmt.Println()
+		cli.Info("config.user_file", describeConfigFile(cfg.UserPath, userConfigPath()))
+		cli.Info("config.project_file", describeConfigFile(cfg.ProjectPath, config.ProjectFile))
+		if cfg.Profile != "" {
+			cli.PrintInfo("Profile: %s", cfg.Profile)
+		}
+	},
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get [key]",
+	Short: "显示配置项生效的值",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if _, err := cfg.Get(args[0]); err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+		value, _ := effectiveValue(args[0])
+		fmt.Println(value)
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set [key] [value]",
+	Short: "修改配置项",
+	Long: `修改配置项，默认写入用户配置文件。使用 --local 写入当前目录的 .todo.yaml，
+使用 --profile 写入指定 profile，profile 不存在时会创建。value 为空字符串时删除该配置项。`,
+	Example: `  todo config set defaults.category work
+  todo config set output.columns id,status,title,due
+  todo config set llm.model qwen-max --profile work
+  todo config set db ./tasks.db --local`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		key, value := args[0], args[1]
+		if err := validateConfigValue(key, value); err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+
+		path := userConfigPath()
+		if configLocal {
+			path = config.ProjectFile
+		}
+
+		file, err := config.ReadFile(path)
+		if err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+		if file == nil {
+			file = &config.Config{}
+		}
+
+		target := file
+		if profileFlag != "" {
+			if file.Profiles == nil {
+				file.Profiles = make(map[string]*config.Config)
+			}
+			if file.Profiles[profileFlag] == nil {
+				file.Profiles[profileFlag] = &config.Config{}
+			}
+			target = file.Profiles[profileFlag]
+		}
+
+		if err := target.Set(key, value); err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+		if err := config.WriteFile(path, file); err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+
+		if profileFlag != "" {
+			cli.Success("config.set_profile", key, profileFlag, path)
+		} else {
+			cli.Success("config.set", key, path)
+		}
+		if configLocal && config.IsUserOnly(key) {
+			if dir, err := os.Getwd(); err == nil {
+				cli.Info("config.user_only", key, trustedProjectsWith(dir))
+			}
+		}
+	},
+}
+
+// setupConfig 加载配置并应用颜色设置，必须在 setupLocale 之前调用。
+// profile 不存在时先忽略它继续加载，错误留到 PersistentPreRun 中报告，
+// 这样 'todo config set --profile' 可以创建新的 profile
+func setupConfig(args []string) error {
+	resolved, err := config.Load(flagFromArgs(args, "profile"))
+	if errors.Is(err, config.ErrUnknownProfile) {
+		cfgErr, err = err, nil
+	}
+	if err != nil {
+		return err
+	}
+	cfg = resolved
+
+	if err := cli.SetColorMode(cfg.Colors.Mode); err != nil {
+		return err
+	}
+	return cli.SetPalette(cli.Palette{
+		Success: cfg.Colors.Success,
+		Error:   cfg.Colors.Error,
+		Info:    cfg.Colors.Info,
+		Warning: cfg.Colors.Warning,
+	})
+}
+
+// checkConfig 选中的 profile 不存在时报错退出，'todo config set' 除外。
+// 不受信任的项目配置中有被忽略的配置项时给出提示
+func checkConfig(cmd *cobra.Command) {
+	if cfgErr != nil && cmd != configSetCmd {
+		fmt.Fprintf(os.Stderr, "✗ %v\n", cfgErr)
+		os.Exit(1)
+	}
+	if len(cfg.Ignored) > 0 {
+		fmt.Fprintln(os.Stderr, "⚠ "+i18n.T("config.untrusted", cfg.ProjectPath, strings.Join(cfg.Ignored, ", "),
+			trustedProjectsWith(filepath.Dir(cfg.ProjectPath))))
+	}
+}
+
+// trustedProjectsWith 把 dir 加入 trusted_projects 后的值
+func trustedProjectsWith(dir string) string {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	return strings.Join(append(append([]string(nil), cfg.TrustedProjects...), dir), ",")
+}
+
+// defaultCategory 和 defaultPriority 添加任务时使用的默认值
+func defaultCategory() models.TaskCategory {
+	return models.TaskCategory(cfg.Defaults.Category)
+}
+
+func defaultPriority() models.Priority {
+	return models.Priority(cfg.Defaults.Priority)
+}
+
+// outputJSON 是否以 JSON 格式输出：指定了 --json 或配置了 output.format: json
+func outputJSON(flag bool) bool {
+	return flag || cfg.Output.Format == "json"
+}
+
+// effectiveValue 配置项实际使用的值及其来源，llm.base_url 和 llm.model 未设置时显示按 provider 确定的默认值
+func effectiveValue(key string) (string, string) {
+	value, _ := cfg.Get(key)
+	if value != "" {
+		return value, cfg.Sources[key]
+	}
+	switch key {
+	case "llm.base_url":
+		return cfg.LLMBaseURL(), config.SourceDefault
+	case "llm.model":
+		return cfg.LLMModel(), config.SourceDefault
+	}
+	return "", ""
+}
+
+// validateConfigValue 检查配置包之外才能校验的值，例如列名和颜色
+func validateConfigValue(key, value string) error {
+	if value == "" {
+		return nil
+	}
+	switch {
+	case key == "output.columns":
+		_, err := cli.ParseTaskColumns(value)
+		return err
+	case key == "defaults.category":
+		category := models.TaskCategory(value)
+		if category != models.CategoryWork && category != models.CategoryStudy &&
+			category != models.CategoryLife && category != models.CategoryOther {
+			return errors.New(i18n.T("error.invalid_category"))
+		}
+	case strings.HasPrefix(key, "colors.") && key != "colors.mode":
+		_, err := cli.ParseColor(value)
+		return err
+	}
+	return nil
+}
+
+// userConfigPath 用户配置文件路径，无法确定时使用当前目录
+func userConfigPath() string {
+	path, err := config.UserPath()
+	if err != nil {
+		return filepath.Join(".", "config.yaml")
+	}
+	return path
+}
+
+func describeConfigFile(loaded, fallback string) string {
+	if loaded != "" {
+		return loaded
+	}
+	return fallback + i18n.T("config.file_missing")
+}
+
+// maskSecret 只显示密钥的前后几位
+func maskSecret(value string) string {
+	if len(value) <= 8 {
+		return strings.Repeat("*", len(value))
+	}
+	return value[:4] + strings.Repeat("*", len(value)-8) + value[len(value)-4:]
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configListCmd, configGetCmd, configSetCmd)
+
+	configSetCmd.Flags().BoolVar(&configLocal, "local", false, "写入当前目录的 "+config.ProjectFile)
+}
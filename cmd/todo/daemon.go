@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/reminder"
+	"github.com/spf13/cobra"
+)
+
+var (
+	daemonNotifiers  string
+	daemonWebhookURL string
+	daemonInterval   time.Duration
+)
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "启动提醒后台进程",
+	Long: `启动提醒后台进程，在提醒到期时通过通知渠道发出通知。
+
+通知渠道 (--notify，逗号分隔):
+• stdout  - 打印到标准输出
+• bell    - 终端响铃
+• desktop - 桌面通知 (notify-send / osascript)
+• webhook - POST JSON 到 --webhook-url
+
+提醒触发后会立即记录到数据库，进程重启后不会重复通知。
+后台进程同时会投递 Webhook 发件箱中到期的重试。`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var notifiers []reminder.Notifier
+		for _, name := range strings.Split(daemonNotifiers, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			notifier, err := reminder.NewNotifier(name, os.Stdout, daemonWebhookURL)
+			if err != nil {
+				cli.PrintError("%v", err)
+				return
+			}
+			notifiers = append(notifiers, notifier)
+		}
+		if len(notifiers) == 0 {
+			cli.Error("daemon.no_notifier")
+			return
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		scheduler := reminder.NewScheduler(store, notifiers)
+		scheduler.PollInterval = daemonInterval
+		scheduler.OnTick = func(ctx context.Context) {
+			if _, err := webhookDispatcher.Flush(ctx); err != nil {
+				fmt.Fprintf(os.Stderr, "⚠ webhook: %v\n", err)
+			}
+		}
+
+		cli.Info("daemon.started", daemonNotifiers)
+		if err := scheduler.Run(ctx); err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+		cli.Info("daemon.stopped")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(daemonCmd)
+
+	daemonCmd.Flags().StringVarP(&daemonNotifiers, "notify", "n", "stdout,bell", "通知渠道 (stdout/bell/desktop/webhook)")
+	daemonCmd.Flags().StringVar(&daemonWebhookURL, "webhook-url", "", "webhook 通知渠道的 URL")
+	daemonCmd.Flags().DurationVar(&daemonInterval, "interval", time.Minute, "最长轮询间隔")
+}
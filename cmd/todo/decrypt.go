@@ -0,0 +1,32 @@
+package main
+
+import (
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+var decryptCmd = &cobra.Command{
+	Use:   "decrypt",
+	Short: "解密数据库",
+	Long: `解密数据库，恢复为普通的 SQLite 数据库。需要输入加密时设置的口令。
+
+解密后密钥代理中缓存的密钥不再使用，可以用 'todo lock' 清除。`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		mode := store.EncryptionMode()
+		if mode == "" {
+			cli.Error("error.not_encrypted")
+			return
+		}
+
+		if err := store.Decrypt(); err != nil {
+			cli.Error("decrypt.failed", err)
+			return
+		}
+		cli.Success("decrypt.done", mode)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(decryptCmd)
+}
@@ -8,6 +8,7 @@ import (
     "strings"
 
     "github.com/WHITE13452/toDoList/internal/cli"
+    "github.com/WHITE13452/toDoList/internal/i18n"
     "github.com/spf13/cobra"
 )
 
@@ -38,66 +39,64 @@ var deleteCmd = &cobra.Command{
 func deleteByID(taskID int64) {
     task, err := store.GetTask(taskID)
     if err != nil {
-        cli.PrintError("获取任务失败: %v", err)
+        cli.Error("error.get_task", err)
         return
     }
     if task == nil {
-        cli.PrintError("任务 %d 不存在", taskID)
+        cli.Error("error.task_not_found", taskID)
         return
     }
 
     if !skipConfirm {
         cli.PrintTask(task, false)
-        fmt.Printf("\n确定要删除任务 %d 吗？(y/N): ", taskID)
+        fmt.Print("\n" + i18n.T("delete.confirm", taskID))
         reader := bufio.NewReader(os.Stdin)
         response, _ := reader.ReadString('\n')
         response = strings.TrimSpace(strings.ToLower(response))
         if response != "y" && response != "yes" {
-            fmt.Println("已取消")
+            fmt.Println(i18n.T("prompt.cancelled"))
             return
         }
     }
 
     if err := store.DeleteTask(taskID); err != nil {
-        cli.PrintError("删除任务失败: %v", err)
+        cli.Error("error.delete_task", err)
         return
     }
 
-    cli.PrintSuccess("任务 %d 已删除", taskID)
+    cli.Success("delete.done", taskID)
 }
 
 // deleteByKeyword 按关键词搜索并删除任务
 func deleteByKeyword(keyword string) {
     // 搜索任务
-    tasks, err := store.SearchTasks(keyword)
+    tasks, err := store.SearchTasks(keyword, 0)
     if err != nil {
-        cli.PrintError("搜索失败: %v", err)
+        cli.Error("error.search", err)
         return
     }
 
     if len(tasks) == 0 {
-        fmt.Printf("未找到包含 '%s' 的任务\n", keyword)
+        fmt.Println(i18n.T("search.none", keyword))
         return
     }
 
     // 显示搜索结果
-    fmt.Printf("\n找到 %d 个匹配的任务:\n", len(tasks))
+    fmt.Println("\n" + i18n.T("search.found", len(tasks)))
     fmt.Println(strings.Repeat("-", 60))
     for i, task := range tasks {
-        status := "未完成"
+        status := i18n.T("delete.status_pending")
         if task.Status == "completed" {
-            status = "已完成"
+            status = i18n.T("delete.status_completed")
         }
         
-        fmt.Printf("选项 [%d] | 任务ID: %d | %s | %s\n", 
-            i+1, task.ID, status, task.Title)
+        fmt.Println(i18n.T("delete.option", i+1, task.ID, status, task.Title))
         
         if task.Description != "" {
-            fmt.Printf("         | 描述: %s\n", task.Description)
+            fmt.Println(i18n.T("delete.option_description", task.Description))
         }
         
-        priorityText := fmt.Sprintf("优先级%d", task.Priority)
-        fmt.Printf("         | 分类: %s | %s\n", task.Category, priorityText)
+        fmt.Println(i18n.T("delete.option_category", cli.CategoryText(task.Category), task.Priority))
         
         if i < len(tasks)-1 {
             fmt.Println(strings.Repeat("-", 60))
@@ -106,7 +105,7 @@ func deleteByKeyword(keyword string) {
     fmt.Println(strings.Repeat("-", 60))
 
     // 提示用户选择
-    fmt.Printf("\n请输入选项序号 (1-%d) 来删除任务，输入 0 取消: ", len(tasks))
+    fmt.Print("\n" + i18n.T("delete.choose", len(tasks)))
     reader := bufio.NewReader(os.Stdin)
     input, _ := reader.ReadString('\n')
     input = strings.TrimSpace(input)
@@ -114,12 +113,12 @@ func deleteByKeyword(keyword string) {
     // 解析选择
     choice, err := strconv.Atoi(input)
     if err != nil || choice < 0 || choice > len(tasks) {
-        cli.PrintError("无效的选择")
+        cli.Error("delete.invalid_choice")
         return
     }
 
     if choice == 0 {
-        fmt.Println("已取消删除")
+        fmt.Println(i18n.T("prompt.cancelled"))
         return
     }
 
@@ -128,26 +127,26 @@ func deleteByKeyword(keyword string) {
 
     // 二次确认
     if !skipConfirm {
-        fmt.Println("\n您选择删除的任务:")
+        fmt.Println("\n" + i18n.T("delete.selected"))
         fmt.Println(strings.Repeat("=", 60))
         cli.PrintTask(selectedTask, true)
         fmt.Println(strings.Repeat("=", 60))
-        fmt.Printf("\n确定要删除任务 ID:%d 吗？(y/N): ", selectedTask.ID)
+        fmt.Print("\n" + i18n.T("delete.confirm", selectedTask.ID))
         response, _ := reader.ReadString('\n')
         response = strings.TrimSpace(strings.ToLower(response))
         if response != "y" && response != "yes" {
-            fmt.Println("已取消删除")
+            fmt.Println(i18n.T("prompt.cancelled"))
             return
         }
     }
 
     // 执行删除
     if err := store.DeleteTask(selectedTask.ID); err != nil {
-        cli.PrintError("删除任务失败: %v", err)
+        cli.Error("error.delete_task", err)
         return
     }
 
-    cli.PrintSuccess("任务 ID:%d 已成功删除", selectedTask.ID)
+    cli.Success("delete.done", selectedTask.ID)
 }
 
 func init() {
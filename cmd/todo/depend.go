@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dependOn     []int64
+	dependRemove bool
+)
+
+var dependCmd = &cobra.Command{
+	Use:   "depend [task_id]",
+	Short: "管理任务依赖",
+	Long: `设置任务的前置任务：前置任务完成之前，任务处于等待状态 (⊘)，不能直接完成。
+
+示例：
+  todo depend 5 --on 3          # 任务 5 需要等任务 3 完成
+  todo depend 5 --on 3,4        # 同时依赖多个任务
+  todo depend 5 --on 3 -r       # 移除依赖
+  todo depend 5                 # 查看任务 5 的前置任务和后续任务
+
+形成环的依赖会被拒绝。`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		taskID, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			cli.Error("error.invalid_task_id")
+			return
+		}
+
+		for _, id := range dependOn {
+			if dependRemove {
+				removed, err := store.RemoveDependency(taskID, id)
+				if err != nil {
+					cli.Error("depend.remove_failed", err)
+					return
+				}
+				if removed {
+					cli.Success("depend.removed", taskID, id)
+				} else {
+					cli.Info("depend.not_found", taskID, id)
+				}
+				continue
+			}
+
+			if err := store.AddDependency(taskID, id); err != nil {
+				var cycle *models.CycleError
+				switch {
+				case errors.Is(err, models.ErrSelfDependency):
+					cli.Error("depend.self")
+				case errors.As(err, &cycle):
+					cli.Error("depend.cycle", cycle.PathString())
+				default:
+					cli.Error("depend.add_failed", err)
+				}
+				return
+			}
+			cli.Success("depend.added", taskID, id)
+		}
+
+		printDependencies(taskID)
+	},
+}
+
+// printDependencies 打印任务的前置任务和后续任务
+func printDependencies(taskID int64) {
+	prerequisites, err := store.GetPrerequisites(taskID)
+	if err != nil {
+		cli.Error("depend.prerequisites_failed", err)
+		return
+	}
+	dependents, err := store.GetDependents(taskID)
+	if err != nil {
+		cli.Error("depend.dependents_failed", err)
+		return
+	}
+
+	cli.Info("depend.prerequisites", taskID)
+	if len(prerequisites) == 0 {
+		cli.Info("depend.none")
+	}
+	for _, task := range prerequisites {
+		cli.PrintTask(task, false)
+	}
+
+	cli.Info("depend.dependents", taskID)
+	if len(dependents) == 0 {
+		cli.Info("depend.none")
+	}
+	for _, task := range dependents {
+		cli.PrintTask(task, false)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(dependCmd)
+
+	dependCmd.Flags().Int64SliceVar(&dependOn, "on", nil, "前置任务 ID，多个用逗号分隔")
+	dependCmd.Flags().BoolVarP(&dependRemove, "remove", "r", false, "移除 --on 指定的依赖")
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"errors"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/WHITE13452/toDoList/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	doctorFix  bool
+	doctorJSON bool
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "检查数据库",
+	Long: `检查数据库并给出修复建议：
+  - PRAGMA integrity_check
+  - schema 版本
+  - 索引是否完整
+  - 引用了不存在的任务的依赖关系、提醒、计时和番茄钟记录，引用了不存在的项目的任务，
+    引用了不存在的订阅的 Webhook 投递记录
+
+检查时以只读方式打开数据库，不会升级 schema 或修改数据库，数据库损坏时也可以使用。
+使用 --fix 自动修复：升级 schema、重建索引并清理上述记录，修复前会先备份数据库。发现问题时以非零状态退出。`,
+	Args: cobra.NoArgs,
+	// 不初始化存储：打开存储时的建表和迁移会修改可能已经损坏的数据库
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		setupDBPath(cmd)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		report, err := storage.CheckFile(dbPath, storageOptions())
+		if err != nil {
+			cli.Error("error.check_db", err)
+			exitCode = 1
+			return
+		}
+
+		if doctorFix && report.Fixable() {
+			if report, err = repairDatabase(); err != nil {
+				cli.PrintError("%v", err)
+				return
+			}
+		}
+
+		if outputJSON(doctorJSON) {
+			if err := cli.PrintJSON(report); err != nil {
+				cli.Error("error.json", err)
+			}
+		} else {
+			cli.PrintHealthReport(report)
+		}
+
+		if !report.Healthy() {
+			failed := 0
+			for _, check := range report.Checks {
+				if !check.OK() {
+					failed++
+				}
+			}
+			cli.Error("doctor.unhealthy", failed)
+			exitCode = 1
+		}
+	},
+}
+
+// repairDatabase 备份数据库文件，然后打开存储并修复，返回修复后的检查结果。打开存储时旧版本的数据库会先升级
+func repairDatabase() (report *models.HealthReport, err error) {
+	dest := storage.BackupPath(storage.BackupDir(dbPath), dbPath, time.Now(), models.BackupReasonPreRepair)
+	if err := storage.BackupFile(dbPath, dest); err != nil {
+		return nil, errors.New(i18n.T("doctor.backup_failed", err))
+	}
+	cli.Info("doctor.backed_up", dest)
+
+	s, err := storage.NewWithOptions(dbPath, storageOptions())
+	if err != nil {
+		return nil, errors.New(i18n.T("doctor.repair_failed", err))
+	}
+	// 整库加密时关闭存储才会写回数据库文件
+	defer func() {
+		if closeErr := s.Close(); closeErr != nil && err == nil {
+			report, err = nil, errors.New(i18n.T("doctor.repair_failed", closeErr))
+		}
+	}()
+
+	if err := s.Repair(); err != nil {
+		return nil, errors.New(i18n.T("doctor.repair_failed", err))
+	}
+	cli.Success("doctor.repaired")
+
+	if report, err = s.Check(); err != nil {
+		return nil, errors.New(i18n.T("error.check_db", err))
+	}
+	return report, nil
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "自动修复可以修复的问题，修复前先备份")
+	doctorCmd.Flags().BoolVar(&doctorJSON, "json", false, "以 JSON 格式输出")
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var (
+	editTitle       string
+	editDescription string
+	editCategory    string
+	editPriority    int
+	editDue         string
+	editEstimate    string
+	editProject     string
+	editTags        string
+)
+
+// editFields edit 命令可以修改的字段
+var editFields = []string{"title", "description", "category", "priority", "due", "estimate", "project", "tags"}
+
+var editCmd = &cobra.Command{
+	Use:   "edit [task_id]",
+	Short: "修改任务",
+	Long: `修改任务的标题、描述、分类、优先级、截止时间、估算、项目或标签，只修改指定的字段。
+
+  todo edit 5 --estimate 3h
+  todo edit 5 --due "next friday" -p 3
+  todo edit 5 --due none --estimate none   # 清除截止时间和估算
+  todo edit 5 --project none               # 移出项目`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		taskID, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			cli.Error("error.invalid_task_id")
+			return
+		}
+
+		task, err := store.GetTask(taskID)
+		if err != nil {
+			cli.Error("error.get_task", err)
+			return
+		}
+		if task == nil {
+			cli.Error("error.task_not_found", taskID)
+			return
+		}
+
+		flags := cmd.Flags()
+		changed := false
+		for _, name := range editFields {
+			changed = changed || flags.Changed(name)
+		}
+		if !changed {
+			cli.Error("edit.nothing")
+			return
+		}
+
+		if flags.Changed("title") {
+			if strings.TrimSpace(editTitle) == "" {
+				cli.Error("edit.empty_title")
+				return
+			}
+			task.Title = editTitle
+		}
+		if flags.Changed("description") {
+			task.Description = editDescription
+		}
+		if flags.Changed("category") {
+			category := models.TaskCategory(editCategory)
+			if category != models.CategoryWork && category != models.CategoryStudy &&
+				category != models.CategoryLife && category != models.CategoryOther {
+				cli.Error("error.invalid_category")
+				return
+			}
+			task.Category = category
+		}
+		if flags.Changed("priority") {
+			priority := models.Priority(editPriority)
+			if priority < models.PriorityLow || priority > models.PriorityUrgent {
+				cli.Error("error.invalid_priority")
+				return
+			}
+			task.Priority = priority
+		}
+		if flags.Changed("due") {
+			if isNone(editDue) {
+				task.DueAt = nil
+			} else {
+				due, err := parseTime(editDue, dueDefaultClock)
+				if err != nil {
+					cli.PrintError("%v", err)
+					return
+				}
+				task.DueAt = &due
+			}
+		}
+		if flags.Changed("estimate") {
+			estimate, err := parseEstimate(editEstimate)
+			if err != nil {
+				cli.PrintError("%v", err)
+				return
+			}
+			task.Estimate = estimate
+		}
+		if flags.Changed("project") {
+			if isNone(editProject) {
+				task.ProjectID = 0
+			} else {
+				project, err := resolveProject(editProject)
+				if err != nil {
+					cli.PrintError("%v", err)
+					return
+				}
+				if project.Archived {
+					cli.Error("error.project_archived", project.Name)
+					return
+				}
+				task.ProjectID = project.ID
+			}
+		}
+		if flags.Changed("tags") {
+			task.Tags = nil
+			if !isNone(editTags) {
+				for _, tag := range strings.Split(editTags, ",") {
+					task.AddTag(strings.TrimSpace(tag))
+				}
+			}
+		}
+
+		if err := store.UpdateTask(task); err != nil {
+			cli.Error("error.update_task", err)
+			return
+		}
+
+		cli.Success("edit.done", taskID)
+		cli.PrintTask(task, true)
+	},
+}
+
+// isNone 参数值是否表示清除该字段
+func isNone(value string) bool {
+	value = strings.ToLower(strings.TrimSpace(value))
+	return value == "" || value == "none"
+}
+
+func init() {
+	rootCmd.AddCommand(editCmd)
+
+	editCmd.Flags().StringVar(&editTitle, "title", "", "新的标题")
+	editCmd.Flags().StringVarP(&editDescription, "description", "d", "", "任务描述")
+	editCmd.Flags().StringVarP(&editCategory, "category", "c", "", "任务分类 (work/study/life/other)")
+	editCmd.Flags().IntVarP(&editPriority, "priority", "p", 0, "优先级 (1:低 2:中 3:高 4:紧急)")
+	editCmd.Flags().StringVar(&editDue, "due", "", "截止时间，none 表示清除")
+	editCmd.Flags().StringVarP(&editEstimate, "estimate", "e", "", "工作量估算 (例如 2h、3pt)，none 表示清除")
+	editCmd.Flags().StringVarP(&editProject, "project", "P", "", "所属项目 (名称或 ID)，none 表示移出项目")
+	editCmd.Flags().StringVarP(&editTags, "tags", "t", "", "标签，逗号分隔，会替换原有标签；none 表示清除")
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/encryption"
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/WHITE13452/toDoList/internal/keyagent"
+	"github.com/WHITE13452/toDoList/internal/storage"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// passphraseEnv 非交互环境中提供口令的环境变量
+const passphraseEnv = "TODO_PASSPHRASE"
+
+var encryptMode string
+
+// unlockedKeys 本进程中已经验证过的密钥，按加密参数的 ID 缓存，避免重复输入口令
+var unlockedKeys = make(map[string][]byte)
+
+var encryptCmd = &cobra.Command{
+	Use:   "encrypt",
+	Short: "加密数据库",
+	Long: `使用口令加密数据库。密钥由口令通过 Argon2id 派生，数据使用 AES-256-GCM 加密。
+
+加密模式 (--mode):
+• fields - 只加密任务的标题和描述（以及 Webhook 发件箱中的任务快照），其余字段仍为明文，
+           搜索在内存中解密后进行
+• store  - 加密整个数据库文件，使用时解密到内存中的工作副本，命令结束时重新加密；
+           同一时间只能有一个 todo 进程使用该数据库，todo daemon 和 TUI 运行期间其他命令会报错退出，
+           需要常驻后台进程时请使用 fields 模式。进程异常退出时，未重新加密的修改会丢失
+
+口令从终端读取，非交互环境可以通过 TODO_PASSPHRASE 环境变量提供。
+使用 'todo unlock' 把密钥缓存一段时间，之后的命令不需要再输入口令。
+已有的备份不会被加密，需要时请手动删除。
+
+  todo encrypt                # 加密标题和描述
+  todo encrypt --mode store   # 加密整个数据库`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if mode := store.EncryptionMode(); mode != "" {
+			cli.Error("encrypt.already", mode)
+			return
+		}
+
+		passphrase, err := newPassphrase()
+		if err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+		h, key, err := encryption.NewHeader(encryptMode, passphrase)
+		if err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+		if err := store.Encrypt(h, key); err != nil {
+			cli.Error("encrypt.failed", err)
+			return
+		}
+		unlockedKeys[h.ID()] = key
+		cli.Success("encrypt.done", h.Mode)
+		cli.Info("encrypt.unlock_hint")
+
+		// 加密前的备份不会被加密
+		backups, _ := storage.ListBackups(storage.BackupDir(store.Path()), store.Path())
+		plain := 0
+		for _, backup := range backups {
+			if sealed, err := encryption.IsSealedFile(backup.Path); err == nil && !sealed {
+				plain++
+			}
+		}
+		if plain > 0 {
+			fmt.Fprintln(os.Stderr, "⚠ "+i18n.T("encrypt.plain_backups", storage.BackupDir(store.Path()), plain))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(encryptCmd)
+
+	encryptCmd.Flags().StringVar(&encryptMode, "mode", encryption.ModeFields, "加密模式 (fields/store)")
+}
+
+// storageOptions 打开存储时使用的选项，加密数据库的密钥由 provideKey 获取
+func storageOptions() storage.Options {
+	return storage.Options{KeyProvider: provideKey}
+}
+
+// provideKey 获取加密数据库的密钥：依次尝试本进程的缓存、密钥代理、TODO_PASSPHRASE 和终端输入
+func provideKey(h *encryption.Header) ([]byte, error) {
+	if key, ok := unlockedKeys[h.ID()]; ok {
+		return key, nil
+	}
+
+	key, err := keyagent.Get(h.ID())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ %v\n", err)
+	}
+	if key == nil || h.Verify(key) != nil {
+		passphrase, err := readPassphrase(i18n.T("encrypt.enter_passphrase"))
+		if err != nil {
+			return nil, err
+		}
+		if key, err = h.DeriveKey(passphrase); err != nil {
+			return nil, err
+		}
+		if err := h.Verify(key); err != nil {
+			return nil, err
+		}
+	}
+
+	unlockedKeys[h.ID()] = key
+	return key, nil
+}
+
+// readPassphrase 读取口令：优先使用 TODO_PASSPHRASE，否则在终端中提示输入（不回显）
+func readPassphrase(prompt string) (string, error) {
+	if passphrase := os.Getenv(passphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("%w: run 'todo unlock' in a terminal or set %s", encryption.ErrLocked, passphraseEnv)
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	data, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	return string(data), nil
+}
+
+// newPassphrase 读取新口令，在终端中输入时需要确认一次
+func newPassphrase() (string, error) {
+	if passphrase := os.Getenv(passphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+
+	passphrase, err := readPassphrase(i18n.T("encrypt.new_passphrase"))
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(passphrase) == "" {
+		return "", errors.New(i18n.T("encrypt.empty_passphrase"))
+	}
+	confirm, err := readPassphrase(i18n.T("encrypt.confirm_passphrase"))
+	if err != nil {
+		return "", err
+	}
+	if confirm != passphrase {
+		return "", errors.New(i18n.T("encrypt.passphrase_mismatch"))
+	}
+	return passphrase, nil
+}
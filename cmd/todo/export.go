@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/WHITE13452/toDoList/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFormat   string
+	exportOutput   string
+	exportStatus   string
+	exportCategory string
+	exportProject  string
+)
+
+// exportColumns CSV 导出的列
+var exportColumns = []string{
+	"id", "title", "description", "status", "state", "category", "priority", "project_id", "tags",
+	"due_at", "defer_until", "estimate", "created_at", "updated_at", "completed_at",
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "导出任务",
+	Long: `导出任务，包括延后中的任务。任务逐批从数据库读取并立即写出，数千个任务也不会占用大量内存。
+
+导出格式 (--format):
+• jsonl - 每行一个 JSON 对象，字段与 'todo list --json' 相同
+• csv   - 带表头的 CSV，时间为 RFC 3339 格式，标签用逗号分隔
+
+  todo export > tasks.jsonl
+  todo export --format csv -o tasks.csv
+  todo export -s pending -P website`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if exportFormat != "jsonl" && exportFormat != "csv" {
+			cli.Error("export.invalid_format")
+			return
+		}
+
+		projectID, err := resolveProjectID(exportProject)
+		if err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+		filter := storage.TaskFilter{
+			Status:          models.TaskStatus(exportStatus),
+			Category:        models.TaskCategory(exportCategory),
+			ProjectID:       projectID,
+			IncludeDeferred: true,
+			SortBy:          "created_at",
+		}
+
+		var out io.Writer = os.Stdout
+		if exportOutput != "" {
+			file, err := os.Create(exportOutput)
+			if err != nil {
+				cli.Error("export.create_failed", err)
+				return
+			}
+			defer file.Close()
+			out = file
+		}
+
+		count, err := exportTasks(out, filter)
+		if err != nil {
+			cli.Error("export.failed", err)
+			if exportOutput != "" {
+				os.Remove(exportOutput)
+			}
+			return
+		}
+		if exportOutput != "" {
+			cli.Success("export.done", count, exportOutput)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "jsonl", "导出格式 (jsonl/csv)")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "输出文件，默认输出到标准输出")
+	exportCmd.Flags().StringVarP(&exportStatus, "status", "s", "", "按状态过滤 (pending/completed)")
+	exportCmd.Flags().StringVarP(&exportCategory, "category", "c", "", "按分类过滤 (work/study/life/other)")
+	exportCmd.Flags().StringVarP(&exportProject, "project", "P", "", "按项目过滤 (名称或 ID)")
+}
+
+// exportTasks 按 exportFormat 流式写出符合 filter 的任务，返回导出的任务数
+func exportTasks(out io.Writer, filter storage.TaskFilter) (int, error) {
+	var write func(task *models.Task) error
+	var flush func() error
+
+	switch exportFormat {
+	case "csv":
+		writer := csv.NewWriter(out)
+		if err := writer.Write(exportColumns); err != nil {
+			return 0, err
+		}
+		write = func(task *models.Task) error { return writer.Write(csvRecord(task)) }
+		flush = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+	default:
+		encoder := json.NewEncoder(out)
+		write = func(task *models.Task) error { return encoder.Encode(task) }
+		flush = func() error { return nil }
+	}
+
+	count := 0
+	for task, err := range store.IterTasks(filter) {
+		if err != nil {
+			return count, err
+		}
+		if err := write(task); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, flush()
+}
+
+// csvRecord 任务对应的 CSV 行，顺序与 exportColumns 一致
+func csvRecord(task *models.Task) []string {
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+
+	estimate := ""
+	if !task.Estimate.IsZero() {
+		estimate = task.Estimate.String()
+	}
+
+	return []string{
+		strconv.FormatInt(task.ID, 10),
+		task.Title,
+		task.Description,
+		string(task.Status),
+		task.State,
+		string(task.Category),
+		strconv.Itoa(int(task.Priority)),
+		strconv.FormatInt(task.ProjectID, 10),
+		strings.Join(task.Tags, ","),
+		formatTime(task.DueAt),
+		formatTime(task.DeferUntil),
+		estimate,
+		formatTime(&task.CreatedAt),
+		formatTime(&task.UpdatedAt),
+		formatTime(task.CompletedAt),
+	}
+}
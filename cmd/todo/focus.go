@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/focus"
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/WHITE13452/toDoList/internal/reminder"
+	"github.com/spf13/cobra"
+)
+
+var (
+	focusConfig   = focus.DefaultConfig()
+	focusComplete bool
+	focusNotify   string
+	focusNoTrack  bool
+)
+
+var focusCmd = &cobra.Command{
+	Use:   "focus [task_id]",
+	Short: "对任务进行番茄钟专注",
+	Long: `对任务进行番茄钟专注：专注 --work，休息 --break，共 --rounds 轮。
+
+专注期间按回车记录一次中断（可以先输入中断原因），按 Ctrl+C 放弃当前轮次。
+每轮专注都会保存为番茄钟记录，专注时长同时计入任务的计时（--no-track 关闭）。
+使用 --complete 在所有轮次完成后将任务标记为完成。`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		taskID, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			cli.Error("error.invalid_task_id")
+			return
+		}
+
+		task, err := store.GetTask(taskID)
+		if err != nil {
+			cli.Error("error.get_task", err)
+			return
+		}
+		if task == nil {
+			cli.Error("error.task_not_found", taskID)
+			return
+		}
+		if task.Status == models.StatusCompleted {
+			cli.Error("focus.completed", taskID)
+			return
+		}
+		if err := focusConfig.Validate(); err != nil {
+			cli.Error("focus.invalid_config", err)
+			return
+		}
+
+		if !focusNoTrack {
+			// 番茄钟自己记录时长，避免与正在运行的计时器重复计时
+			running, err := store.GetRunningTimer()
+			if err != nil {
+				cli.Error("error.running_timer", err)
+				return
+			}
+			if running != nil {
+				cli.Error("focus.running", running.TaskID)
+				return
+			}
+		}
+
+		var notifiers []reminder.Notifier
+		for _, name := range strings.Split(focusNotify, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			notifier, err := reminder.NewNotifier(name, os.Stdout, "")
+			if err != nil {
+				cli.PrintError("%v", err)
+				return
+			}
+			notifiers = append(notifiers, notifier)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		runner := &focus.Runner{
+			Config: focusConfig,
+			Out:    os.Stdout,
+			Input:  readLines(os.Stdin),
+			Label:  task.Title,
+			OnRound: func(round focus.Round) error {
+				return saveFocusRound(task, round)
+			},
+			OnPhaseEnd: func(message string) {
+				for _, notifier := range notifiers {
+					notifier.Notify(ctx, reminder.Notification{
+						Title:     i18n.T("focus.title"),
+						Message:   message,
+						Task:      task,
+						TriggerAt: time.Now(),
+					})
+				}
+			},
+		}
+
+		cli.Info("focus.started",
+			taskID, task.Title, focusConfig.Work, focusConfig.Rounds)
+		completed, err := runner.Run(ctx)
+		if err != nil && ctx.Err() == nil {
+			cli.Error("focus.save_failed", err)
+			return
+		}
+
+		if completed < focusConfig.Rounds {
+			cli.Info("focus.abandoned", completed, focusConfig.Rounds)
+			return
+		}
+		cli.Success("focus.done", completed)
+
+		if focusComplete {
+			completeFocusedTask(task)
+		}
+	},
+}
+
+// saveFocusRound 保存一轮番茄钟，并将专注时长计入任务计时
+func saveFocusRound(task *models.Task, round focus.Round) error {
+	session := &models.FocusSession{
+		TaskID:        task.ID,
+		StartedAt:     round.StartedAt,
+		EndedAt:       round.EndedAt,
+		Planned:       focusConfig.Work,
+		Completed:     round.Completed,
+		Interruptions: len(round.Interruptions),
+		Notes:         strings.TrimSpace(strings.Join(round.Interruptions, "\n")),
+		CreatedAt:     time.Now(),
+	}
+	if err := store.AddFocusSession(session); err != nil {
+		return err
+	}
+
+	if focusNoTrack || session.Duration() < time.Minute {
+		return nil
+	}
+	entry := models.NewLoggedTimeEntry(task.ID, session.Duration(), round.EndedAt, i18n.T("focus.title"))
+	return store.LogTime(entry)
+}
+
+// completeFocusedTask 所有番茄钟完成后将任务标记为完成
+func completeFocusedTask(task *models.Task) {
+	// 重新读取任务，专注期间任务可能已被修改
+	task, err := store.GetTask(task.ID)
+	if err != nil || task == nil {
+		cli.Error("error.get_task", err)
+		return
+	}
+	if task.IsBlocked() {
+		cli.Error("focus.blocked", task.ID, cli.FormatTaskIDs(task.BlockedBy))
+		return
+	}
+
+	workflow := store.Workflow()
+	if err := workflow.Transition(task, workflow.DoneState()); err != nil {
+		cli.Error("focus.transition_failed", err)
+		return
+	}
+	if err := store.UpdateTask(task); err != nil {
+		cli.Error("error.update_task", err)
+		return
+	}
+	cli.Success("task.completed", task.ID)
+}
+
+// readLines 在后台逐行读取输入，读到末尾时关闭通道
+func readLines(f *os.File) <-chan string {
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			lines <- strings.TrimSpace(scanner.Text())
+		}
+	}()
+	return lines
+}
+
+func init() {
+	rootCmd.AddCommand(focusCmd)
+
+	focusCmd.Flags().DurationVar(&focusConfig.Work, "work", focusConfig.Work, "每轮专注时长")
+	focusCmd.Flags().DurationVar(&focusConfig.Break, "break", focusConfig.Break, "每轮之间的休息时长")
+	focusCmd.Flags().IntVar(&focusConfig.Rounds, "rounds", focusConfig.Rounds, "专注轮数")
+	focusCmd.Flags().BoolVarP(&focusComplete, "complete", "c", false, "全部轮次完成后将任务标记为完成")
+	focusCmd.Flags().StringVarP(&focusNotify, "notify", "n", "bell", "每轮结束时的通知渠道 (stdout/bell/desktop)，为空时不通知")
+	focusCmd.Flags().BoolVar(&focusNoTrack, "no-track", false, "不将专注时长计入任务计时")
+}
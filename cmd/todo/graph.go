@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/WHITE13452/toDoList/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	graphFormat string
+	graphAll    bool
+)
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "输出任务依赖图",
+	Long: `以 Graphviz DOT 或 Mermaid 格式输出任务依赖图，边从前置任务指向依赖它的任务。
+已完成的任务以浅色填充，等待前置任务的任务以红色边框标出。
+
+示例：
+  todo graph | dot -Tpng -o deps.png
+  todo graph --format mermaid > deps.mmd`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if graphFormat != "dot" && graphFormat != "mermaid" {
+			cli.Error("graph.invalid_format")
+			return
+		}
+
+		graph, err := store.GetDependencyGraph()
+		if err != nil {
+			cli.Error("graph.failed", err)
+			return
+		}
+
+		tasks, err := store.GetAllTasks(storage.TaskFilter{IncludeDeferred: true})
+		if err != nil {
+			cli.Error("error.list_tasks", err)
+			return
+		}
+
+		byID := make(map[int64]*models.Task, len(tasks))
+		for _, task := range tasks {
+			byID[task.ID] = task
+		}
+
+		// 默认只输出有依赖关系的任务，--all 时加入所有未完成任务
+		nodes := graph.Nodes()
+		if graphAll {
+			seen := make(map[int64]bool, len(nodes))
+			for _, id := range nodes {
+				seen[id] = true
+			}
+			for _, task := range tasks {
+				if !seen[task.ID] && task.Status != models.StatusCompleted {
+					nodes = append(nodes, task.ID)
+				}
+			}
+		}
+
+		if graphFormat == "mermaid" {
+			err = cli.WriteMermaid(os.Stdout, byID, nodes, graph)
+		} else {
+			err = cli.WriteDOT(os.Stdout, byID, nodes, graph)
+		}
+		if err != nil {
+			cli.Error("graph.write_failed", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(graphCmd)
+
+	graphCmd.Flags().StringVarP(&graphFormat, "format", "f", "dot", "输出格式 (dot/mermaid)")
+	graphCmd.Flags().BoolVarP(&graphAll, "all", "a", false, "包含没有依赖关系的未完成任务")
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/WHITE13452/toDoList/internal/keyagent"
+	"github.com/spf13/cobra"
+)
+
+// keyAgentCmd 密钥代理进程，由 'todo unlock' 在后台启动
+var keyAgentCmd = &cobra.Command{
+	Use:              keyagent.Command,
+	Short:            "密钥代理进程",
+	Hidden:           true,
+	Args:             cobra.NoArgs,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+	Run: func(cmd *cobra.Command, args []string) {
+		// 启动它的终端关闭后继续运行，直到密钥过期
+		signal.Ignore(syscall.SIGHUP)
+		if err := keyagent.Serve(); err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(keyAgentCmd)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/WHITE13452/toDoList/internal/config"
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// langFlag --lang 参数，实际在 setupLocale 中提前解析
+var langFlag string
+
+// setupLocale 根据 --lang 参数、配置和环境变量设置界面语言，并替换命令帮助。
+// 必须在 setupConfig 之后、rootCmd.Execute 之前调用：--help 在解析参数后直接输出，不会经过 PersistentPreRun
+func setupLocale(args []string) error {
+	if value := flagFromArgs(args, "lang"); value != "" {
+		if _, ok := i18n.ParseLocale(value); !ok {
+			return fmt.Errorf("unsupported language %q (available: %s)", value, joinLocales())
+		}
+		_ = cfg.Override("lang", value, config.SourceFlag)
+	}
+
+	i18n.SetLocale(i18n.Detect(cfg.Lang))
+	localizeCommand(rootCmd)
+	return nil
+}
+
+// flagFromArgs 在 Cobra 解析参数之前从命令行参数中找出全局参数 --name 的值，"--" 之后的参数不处理
+func flagFromArgs(args []string, name string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if value, ok := strings.CutPrefix(arg, "--"+name+"="); ok {
+			return value
+		}
+		if arg == "--"+name && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// localizeCommand 用当前语言的消息目录替换命令及其子命令的帮助，目录中没有的保持原样。
+// 只翻译了简介的命令会清空详细说明，帮助中显示翻译后的简介
+func localizeCommand(cmd *cobra.Command) {
+	key := commandKey(cmd)
+	if short, ok := i18n.Lookup("cmd." + key + ".short"); ok {
+		cmd.Short = short
+		cmd.Long, _ = i18n.Lookup("cmd." + key + ".long")
+	}
+
+	for _, flags := range []*pflag.FlagSet{cmd.LocalNonPersistentFlags(), cmd.PersistentFlags()} {
+		flags.VisitAll(func(flag *pflag.Flag) {
+			if usage, ok := i18n.Lookup("flag." + key + "." + flag.Name); ok {
+				flag.Usage = usage
+			}
+		})
+	}
+
+	for _, sub := range cmd.Commands() {
+		localizeCommand(sub)
+	}
+}
+
+// commandKey 命令在消息目录中的路径，例如 "project.create"，根命令为 "root"
+func commandKey(cmd *cobra.Command) string {
+	if !cmd.HasParent() {
+		return "root"
+	}
+	return strings.ReplaceAll(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "), " ", ".")
+}
+
+func joinLocales() string {
+	names := make([]string, 0, len(i18n.Locales()))
+	for _, locale := range i18n.Locales() {
+		names = append(names, string(locale))
+	}
+	return strings.Join(names, ", ")
+}
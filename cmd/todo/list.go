@@ -1,8 +1,11 @@
 package main
 
 import (
+    "strings"
+
     "github.com/WHITE13452/toDoList/internal/cli"
     "github.com/WHITE13452/toDoList/internal/models"
+    "github.com/WHITE13452/toDoList/internal/storage"
     "github.com/spf13/cobra"
 )
 
@@ -10,12 +13,23 @@ var (
     filterStatus   string
     filterCategory string
     sortBy         string  // 新增:排序字段
+    listAll        bool
+    dueBefore      string
+    dueAfter       string
+    filterTag      string
+    filterProject  string
+    filterState    string
+    listActionable bool
+    listColumns    string
+    listJSON       bool
+    listLimit      int
+    listAfter      string
 )
 
 var listCmd = &cobra.Command{
     Use:   "list",
     Short: "列出任务",
-    Long:  "列出所有待办事项。可以使用 -s 和 -c 参数进行过滤,-o 参数进行排序。",
+    Long:  "列出所有待办事项。可以使用 -s 和 -c 参数进行过滤,-o 参数进行排序,-C 参数选择显示的列,例如 -C id,title,due,tags,默认列可以通过配置项 output.columns 修改。任务很多时可以用 --limit 分页,按提示使用 --after 查看下一页。延后中的任务默认隐藏,使用 --all 显示。表格宽度跟随终端,标题过长时截断。",
     Run: func(cmd *cobra.Command, args []string) {
         var status models.TaskStatus
         var category models.TaskCategory
@@ -23,7 +37,7 @@ var listCmd = &cobra.Command{
         if filterStatus != "" {
             status = models.TaskStatus(filterStatus)
             if status != models.StatusPending && status != models.StatusCompleted {
-                cli.PrintError("无效的状态,必须是 pending 或 completed")
+                cli.Error("list.invalid_status")
                 return
             }
         }
@@ -32,24 +46,102 @@ var listCmd = &cobra.Command{
             category = models.TaskCategory(filterCategory)
             if category != models.CategoryWork && category != models.CategoryStudy &&
                 category != models.CategoryLife && category != models.CategoryOther {
-                cli.PrintError("无效的分类,必须是 work, study, life 或 other")
+                cli.Error("error.invalid_category")
                 return
             }
         }
 
         // 验证排序参数
-        if sortBy != "" && sortBy != "priority" && sortBy != "created_at" && sortBy != "updated_at" {
-            cli.PrintError("无效的排序字段,必须是 priority, created_at 或 updated_at")
+        if sortBy != "" && sortBy != "priority" && sortBy != "created_at" && sortBy != "updated_at" && sortBy != "due_at" {
+            cli.Error("list.invalid_sort")
             return
         }
 
-        tasks, err := store.GetAllTasks(status, category, sortBy)
+        if filterState != "" {
+            if _, ok := store.Workflow().State(filterState); !ok {
+                cli.Error("list.invalid_state", strings.Join(store.Workflow().StateNames(), ", "))
+                return
+            }
+        }
+
+        // 未指定 -C 时使用配置中的 output.columns
+        columns := cli.DefaultTaskColumns
+        if listColumns == "" && len(cfg.Output.Columns) > 0 {
+            listColumns = strings.Join(cfg.Output.Columns, ",")
+        }
+        if listColumns != "" {
+            parsed, err := cli.ParseTaskColumns(listColumns)
+            if err != nil {
+                cli.PrintError("%v", err)
+                return
+            }
+            columns = parsed
+        }
+
+        projectID, err := resolveProjectID(filterProject)
         if err != nil {
-            cli.PrintError("获取任务列表失败: %v", err)
+            cli.PrintError("%v", err)
             return
         }
 
-        cli.PrintTaskTable(tasks)
+        filter := storage.TaskFilter{
+            Status:          status,
+            Category:        category,
+            SortBy:          sortBy,
+            IncludeDeferred: listAll,
+            Tag:             filterTag,
+            ProjectID:       projectID,
+            State:           filterState,
+            Actionable:      listActionable,
+            Page:            storage.Page{Limit: listLimit, After: listAfter},
+        }
+
+        // 截止时间过滤支持自然语言，例如 --due-before friday、--due-before 下周一
+        if dueBefore != "" {
+            t, err := parseTime(dueBefore, dueDefaultClock)
+            if err != nil {
+                cli.PrintError("%v", err)
+                return
+            }
+            filter.DueBefore = &t
+        }
+        if dueAfter != "" {
+            t, err := parseTime(dueAfter, 0)
+            if err != nil {
+                cli.PrintError("%v", err)
+                return
+            }
+            filter.DueAfter = &t
+        }
+
+        page, err := store.GetTaskPage(filter)
+        if err != nil {
+            cli.Error("error.list_tasks", err)
+            return
+        }
+        tasks := page.Tasks
+
+        if outputJSON(listJSON) {
+            if tasks == nil {
+                tasks = []*models.Task{}
+            }
+            if err := cli.PrintJSON(tasks); err != nil {
+                cli.Error("error.json", err)
+            }
+            return
+        }
+
+        cli.PrintTaskColumns(tasks, columns)
+
+        if page.Next != "" {
+            cli.Info("list.more", page.Next)
+        }
+
+        if !listAll {
+            if deferred, err := store.CountDeferredTasks(); err == nil && deferred > 0 {
+                cli.Info("list.deferred_hidden", deferred)
+            }
+        }
     },
 }
 
@@ -58,5 +150,16 @@ func init() {
 
     listCmd.Flags().StringVarP(&filterStatus, "status", "s", "", "按状态过滤 (pending/completed)")
     listCmd.Flags().StringVarP(&filterCategory, "category", "c", "", "按分类过滤 (work/study/life/other)")
-    listCmd.Flags().StringVarP(&sortBy, "sort", "o", "", "排序方式 (priority/created_at/updated_at)")
+    listCmd.Flags().StringVarP(&sortBy, "sort", "o", "", "排序方式 (priority/created_at/updated_at/due_at)")
+    listCmd.Flags().BoolVarP(&listAll, "all", "a", false, "包含延后中的任务")
+    listCmd.Flags().StringVarP(&filterTag, "tag", "t", "", "按标签过滤")
+    listCmd.Flags().BoolVar(&listActionable, "actionable", false, "只显示现在就可以做的任务 (前置任务都已完成)")
+    listCmd.Flags().StringVar(&filterState, "state", "", "按工作流状态过滤 (例如 in_progress、blocked)")
+    listCmd.Flags().StringVarP(&filterProject, "project", "P", "", "按项目过滤 (名称或 ID)")
+    listCmd.Flags().StringVar(&dueBefore, "due-before", "", "只显示在此时间之前截止的任务 (例如 friday、下周一)")
+    listCmd.Flags().StringVar(&dueAfter, "due-after", "", "只显示在此时间之后截止的任务 (例如 today、2026-11-01)")
+    listCmd.Flags().StringVarP(&listColumns, "columns", "C", "", "显示的列，逗号分隔 (可选: "+strings.Join(cli.TaskColumnNames(), ",")+")")
+    listCmd.Flags().BoolVar(&listJSON, "json", false, "以 JSON 格式输出")
+    listCmd.Flags().IntVarP(&listLimit, "limit", "n", 0, "每页显示的任务数，0 表示全部")
+    listCmd.Flags().StringVar(&listAfter, "after", "", "从上一页给出的游标之后开始显示")
 }
\ No newline at end of file
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/keyagent"
+	"github.com/spf13/cobra"
+)
+
+var lockCmd = &cobra.Command{
+	Use:   "lock",
+	Short: "清除缓存的密钥",
+	Long:  `清除密钥代理中缓存的所有密钥并停止代理，之后使用加密数据库需要重新输入口令。`,
+	Args:  cobra.NoArgs,
+	// 不需要打开数据库，整库加密时打开数据库会要求输入口令
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		checkConfig(cmd)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		running, err := keyagent.Lock()
+		if err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+		if !running {
+			cli.Info("lock.none")
+			return
+		}
+		cli.Success("lock.done")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(lockCmd)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logNote string
+	logAt   string
+)
+
+var logCmd = &cobra.Command{
+	Use:   "log [task_id] [duration]",
+	Short: "补录任务耗时或查看计时记录",
+	Long: `手动补录任务耗时，例如忘记开计时器时：
+
+  todo log 5 1h30m
+  todo log 5 45m -m "code review" --at "yesterday 18:00"
+
+--at 为这段时间的结束时间，默认为现在。
+只写任务 ID 时列出该任务的所有计时记录。`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		taskID, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			cli.Error("error.invalid_task_id")
+			return
+		}
+
+		task, err := store.GetTask(taskID)
+		if err != nil {
+			cli.Error("error.get_task", err)
+			return
+		}
+		if task == nil {
+			cli.Error("error.task_not_found", taskID)
+			return
+		}
+
+		if len(args) == 1 {
+			entries, err := store.ListTimeEntries(taskID)
+			if err != nil {
+				cli.Error("error.time_entries", err)
+				return
+			}
+			cli.PrintTimeEntries(entries)
+			return
+		}
+
+		d, err := parseDuration(args[1])
+		if err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+		if d <= 0 {
+			cli.Error("log.invalid_duration")
+			return
+		}
+
+		end := time.Now()
+		if logAt != "" {
+			end, err = parseTime(logAt, 0)
+			if err != nil {
+				cli.PrintError("%v", err)
+				return
+			}
+		}
+
+		entry := models.NewLoggedTimeEntry(taskID, d, end, logNote)
+		if err := store.LogTime(entry); err != nil {
+			cli.Error("log.failed", err)
+			return
+		}
+
+		total, err := store.GetTrackedTime(taskID)
+		if err != nil {
+			cli.Error("error.tracked_time", err)
+			return
+		}
+
+		cli.Success("log.done", taskID, models.FormatDuration(d), models.FormatDuration(total))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(logCmd)
+
+	logCmd.Flags().StringVarP(&logNote, "message", "m", "", "备注")
+	logCmd.Flags().StringVar(&logAt, "at", "", "结束时间，默认为现在")
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+var moveForce bool
+
+var moveCmd = &cobra.Command{
+	Use:   "move [task_id] [state]",
+	Short: "移动任务到看板的另一个状态",
+	Long: `按工作流移动任务的状态，只允许工作流定义的转换。
+
+默认工作流：
+  todo        → in_progress, blocked, done
+  in_progress → todo, blocked, review, done
+  blocked     → todo, in_progress
+  review      → in_progress, blocked, done
+  done        → todo, in_progress
+
+进入完成状态的任务会被标记为已完成，离开完成状态会标记为未完成。
+工作流可以通过 ~/.config/todo/workflow.json（或 TODO_WORKFLOW 环境变量）自定义。`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		taskID, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			cli.Error("error.invalid_task_id")
+			return
+		}
+
+		task, err := store.GetTask(taskID)
+		if err != nil {
+			cli.Error("error.get_task", err)
+			return
+		}
+		if task == nil {
+			cli.Error("error.task_not_found", taskID)
+			return
+		}
+
+		workflow := store.Workflow()
+		from := workflow.StateOf(task)
+		if target, ok := workflow.State(args[1]); ok && target.Done && task.IsBlocked() && !moveForce {
+			cli.Error("move.blocked", taskID, cli.FormatTaskIDs(task.BlockedBy))
+			return
+		}
+		if err := workflow.Transition(task, args[1]); err != nil {
+			cli.Error("move.failed", err)
+			return
+		}
+
+		if err := store.UpdateTask(task); err != nil {
+			cli.Error("error.update_task", err)
+			return
+		}
+
+		cli.Success("move.done", taskID, from, args[1])
+		if next := workflow.Allowed(args[1]); len(next) > 0 {
+			cli.Info("move.next", strings.Join(next, ", "))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(moveCmd)
+
+	moveCmd.Flags().BoolVarP(&moveForce, "force", "f", false, "忽略未完成的前置任务")
+}
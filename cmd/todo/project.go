@@ -0,0 +1,219 @@
+package main
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/WHITE13452/toDoList/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	projectDescription string
+	projectStatus      string
+	projectName        string
+	projectListAll     bool
+	projectUnarchive   bool
+)
+
+var projectCmd = &cobra.Command{
+	Use:   "project",
+	Short: "管理项目",
+	Long: `管理项目。任务可以归属于某个项目，分类仍然是全局的。
+
+添加任务时使用 --project 或快速添加语法 @项目名 指定项目，
+list、search、stats 使用 --project 只查看某个项目。`,
+}
+
+var projectCreateCmd = &cobra.Command{
+	Use:   "create [name]",
+	Short: "创建项目",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+
+		existing, err := store.GetProjectByName(name)
+		if err != nil {
+			cli.Error("error.create_project", err)
+			return
+		}
+		if existing != nil {
+			cli.Error("project.exists", name, existing.ID)
+			return
+		}
+
+		project := models.NewProject(name, projectDescription)
+		if err := store.AddProject(project); err != nil {
+			cli.Error("error.create_project", err)
+			return
+		}
+
+		cli.Success("project.created", project.ID)
+	},
+}
+
+var projectListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出项目及进度",
+	Run: func(cmd *cobra.Command, args []string) {
+		projects, err := store.ListProjects(projectListAll)
+		if err != nil {
+			cli.Error("error.list_projects", err)
+			return
+		}
+
+		var progress []*models.ProjectProgress
+		for _, project := range projects {
+			p, err := store.GetProjectProgress(project)
+			if err != nil {
+				cli.Error("error.project_progress", err)
+				return
+			}
+			progress = append(progress, p)
+		}
+
+		cli.PrintProjects(progress)
+	},
+}
+
+var projectShowCmd = &cobra.Command{
+	Use:   "show [name|id]",
+	Short: "显示项目详情和任务",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		project, err := resolveProject(args[0])
+		if err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+
+		progress, err := store.GetProjectProgress(project)
+		if err != nil {
+			cli.Error("error.project_progress", err)
+			return
+		}
+
+		tasks, err := store.GetAllTasks(storage.TaskFilter{
+			ProjectID:       project.ID,
+			IncludeDeferred: true,
+		})
+		if err != nil {
+			cli.Error("error.list_tasks", err)
+			return
+		}
+
+		cli.PrintProject(progress)
+		cli.PrintTaskTable(tasks)
+	},
+}
+
+var projectArchiveCmd = &cobra.Command{
+	Use:   "archive [name|id]",
+	Short: "归档项目",
+	Long:  "归档项目。归档后项目不再出现在 'todo project list' 中，也不能再向其中添加任务；使用 --undo 取消归档。",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		project, err := resolveProject(args[0])
+		if err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+
+		project.Archived = !projectUnarchive
+		if err := store.UpdateProject(project); err != nil {
+			cli.Error("error.update_project", err)
+			return
+		}
+
+		if project.Archived {
+			cli.Success("project.archived_msg", project.Name)
+		} else {
+			cli.Success("project.unarchived", project.Name)
+		}
+	},
+}
+
+var projectEditCmd = &cobra.Command{
+	Use:   "edit [name|id]",
+	Short: "修改项目名称、描述或状态",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		project, err := resolveProject(args[0])
+		if err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+
+		if cmd.Flags().Changed("name") {
+			project.Name = projectName
+		}
+		if cmd.Flags().Changed("description") {
+			project.Description = projectDescription
+		}
+		if cmd.Flags().Changed("status") {
+			status := models.ProjectStatus(projectStatus)
+			if !status.IsValid() {
+				cli.Error("project.invalid_status")
+				return
+			}
+			project.Status = status
+		}
+
+		if err := store.UpdateProject(project); err != nil {
+			cli.Error("error.update_project", err)
+			return
+		}
+
+		cli.Success("project.updated", project.Name)
+	},
+}
+
+// resolveProject 按名称或 ID 查找项目
+func resolveProject(ref string) (*models.Project, error) {
+	project, err := store.GetProjectByName(ref)
+	if err != nil {
+		return nil, errors.New(i18n.T("project.get_failed", err))
+	}
+	if project == nil {
+		if id, err := strconv.ParseInt(ref, 10, 64); err == nil {
+			project, err = store.GetProject(id)
+			if err != nil {
+				return nil, errors.New(i18n.T("project.get_failed", err))
+			}
+		}
+	}
+	if project == nil {
+		return nil, errors.New(i18n.T("project.not_found", ref))
+	}
+	return project, nil
+}
+
+// resolveProjectID 解析 --project 参数，为空时返回 0
+func resolveProjectID(ref string) (int64, error) {
+	if ref == "" {
+		return 0, nil
+	}
+	project, err := resolveProject(ref)
+	if err != nil {
+		return 0, err
+	}
+	return project.ID, nil
+}
+
+func init() {
+	rootCmd.AddCommand(projectCmd)
+	projectCmd.AddCommand(projectCreateCmd, projectListCmd, projectShowCmd, projectArchiveCmd, projectEditCmd)
+
+	projectCreateCmd.Flags().StringVarP(&projectDescription, "description", "d", "", "项目描述")
+
+	projectListCmd.Flags().BoolVarP(&projectListAll, "all", "a", false, "包含已归档的项目")
+
+	projectArchiveCmd.Flags().BoolVar(&projectUnarchive, "undo", false, "取消归档")
+
+	projectEditCmd.Flags().StringVar(&projectName, "name", "", "新的项目名称")
+	projectEditCmd.Flags().StringVarP(&projectDescription, "description", "d", "", "项目描述")
+	projectEditCmd.Flags().StringVarP(&projectStatus, "status", "s", "", "项目状态 (active/on_hold/completed)")
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var (
+	remindAt      string
+	remindBefore  string
+	remindShowAll bool
+)
+
+var remindCmd = &cobra.Command{
+	Use:   "remind",
+	Short: "管理任务提醒",
+	Long: `管理任务提醒。提醒由 'todo daemon' 后台进程负责触发。
+
+提醒可以是绝对时间（--at），也可以是相对截止时间的偏移（--before）。`,
+}
+
+var remindAddCmd = &cobra.Command{
+	Use:   "add [task_id]",
+	Short: "为任务添加提醒",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		taskID, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			cli.Error("error.invalid_task_id")
+			return
+		}
+
+		task, err := store.GetTask(taskID)
+		if err != nil {
+			cli.Error("error.get_task", err)
+			return
+		}
+		if task == nil {
+			cli.Error("error.task_not_found", taskID)
+			return
+		}
+
+		var reminder *models.Reminder
+		switch {
+		case remindAt != "" && remindBefore != "":
+			cli.Error("remind.conflict")
+			return
+		case remindAt != "":
+			at, err := parseTime(remindAt, remindDefaultClock)
+			if err != nil {
+				cli.PrintError("%v", err)
+				return
+			}
+			reminder = models.NewReminderAt(taskID, at)
+		case remindBefore != "":
+			if task.DueAt == nil {
+				cli.Error("remind.no_due", taskID)
+				return
+			}
+			offset, err := parseDuration(remindBefore)
+			if err != nil {
+				cli.PrintError("%v", err)
+				return
+			}
+			reminder = models.NewReminderBeforeDue(taskID, offset)
+		default:
+			cli.Error("remind.missing")
+			return
+		}
+
+		if err := store.AddReminder(reminder); err != nil {
+			cli.Error("remind.add_failed", err)
+			return
+		}
+
+		triggerAt, _ := reminder.TriggerAt(task)
+		cli.Success("remind.added", reminder.ID, triggerAt.Format("2006-01-02 15:04"))
+	},
+}
+
+var remindListCmd = &cobra.Command{
+	Use:   "list [task_id]",
+	Short: "列出提醒",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var taskID int64
+		if len(args) == 1 {
+			var err error
+			taskID, err = strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				cli.Error("error.invalid_task_id")
+				return
+			}
+		}
+
+		reminders, err := store.ListReminders(taskID, remindShowAll)
+		if err != nil {
+			cli.Error("remind.list_failed", err)
+			return
+		}
+
+		tasks := make(map[int64]*models.Task)
+		for _, reminder := range reminders {
+			if _, ok := tasks[reminder.TaskID]; ok {
+				continue
+			}
+			task, err := store.GetTask(reminder.TaskID)
+			if err != nil {
+				cli.Error("error.get_task", err)
+				return
+			}
+			tasks[reminder.TaskID] = task
+		}
+
+		cli.PrintReminders(reminders, tasks)
+	},
+}
+
+var remindSnoozeCmd = &cobra.Command{
+	Use:   "snooze [reminder_id] [duration|time]",
+	Short: "推迟提醒",
+	Long:  "将提醒推迟指定时长（例如 10m、2h、1d）或推迟到指定时间（例如 \"tomorrow 9am\"、\"明天上午\"），已触发的提醒会在新的时间再次触发。",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			cli.Error("error.invalid_reminder_id")
+			return
+		}
+
+		until, err := parseTimeOrDuration(args[1], remindDefaultClock)
+		if err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+		if err := store.SnoozeReminder(id, until); err != nil {
+			cli.Error("remind.snooze_failed", err)
+			return
+		}
+
+		cli.Success("remind.snoozed", id, until.Format("2006-01-02 15:04"))
+	},
+}
+
+var remindDeleteCmd = &cobra.Command{
+	Use:   "delete [reminder_id]",
+	Short: "删除提醒",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			cli.Error("error.invalid_reminder_id")
+			return
+		}
+
+		if err := store.DeleteReminder(id); err != nil {
+			cli.Error("remind.delete_failed", err)
+			return
+		}
+
+		cli.Success("remind.deleted", id)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(remindCmd)
+	remindCmd.AddCommand(remindAddCmd, remindListCmd, remindSnoozeCmd, remindDeleteCmd)
+
+	remindAddCmd.Flags().StringVar(&remindAt, "at", "", "提醒时间 (例如 \"tomorrow 9am\"、\"明天下午三点\"、\"2026-11-01 09:00\")")
+	remindAddCmd.Flags().StringVar(&remindBefore, "before", "", "在截止时间之前多久提醒 (例如 30m, 2h, 1d)")
+	remindListCmd.Flags().BoolVarP(&remindShowAll, "all", "a", false, "包含已触发的提醒")
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/WHITE13452/toDoList/internal/report"
+	"github.com/WHITE13452/toDoList/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportPeriod   string
+	reportFormat   string
+	reportDate     string
+	reportTemplate string
+	reportOutput   string
+	reportProject  string
+	reportAI       bool
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "生成日报或周报",
+	Long: `根据任务列表生成日报或周报，包括已完成、进行中、已逾期和新增四部分，每部分按分类分组。
+
+  todo report                                # 本周周报 (Markdown)
+  todo report --period day --format html -o today.html
+  todo report --date "last friday"           # 上周的周报
+  todo report --ai                           # 由 AI 在开头写一段总结
+
+模板使用 Go text/template，可以用 --template 指定模板文件，
+或者放在配置目录下的 report.md.tmpl / report.html.tmpl（例如 ~/.config/todo/report.md.tmpl）。`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		format := report.Format(reportFormat)
+		tmpl, err := report.LoadTemplate(format, reportTemplate)
+		if err != nil {
+			cli.Error("report.template_failed", err)
+			return
+		}
+
+		now := time.Now()
+		at := now
+		if reportDate != "" {
+			if at, err = parseTime(reportDate, 0); err != nil {
+				cli.PrintError("%v", err)
+				return
+			}
+		}
+
+		projectID, err := resolveProjectID(reportProject)
+		if err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+
+		tasks, err := store.GetAllTasks(storage.TaskFilter{ProjectID: projectID, IncludeDeferred: true})
+		if err != nil {
+			cli.Error("error.list_tasks", err)
+			return
+		}
+
+		data, err := report.Build(tasks, store.Workflow(), report.Period(reportPeriod), at, now)
+		if err != nil {
+			cli.Error("report.failed", err)
+			return
+		}
+
+		if reportAI {
+			summary, err := summarizeReport(data)
+			if err != nil {
+				cli.Error("report.summary_failed", err)
+				return
+			}
+			data.Summary = summary
+		}
+
+		var buf bytes.Buffer
+		if err := report.Render(&buf, tmpl, data); err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+
+		if reportOutput == "" {
+			fmt.Print(buf.String())
+			return
+		}
+		if err := os.WriteFile(reportOutput, buf.Bytes(), 0644); err != nil {
+			cli.Error("report.write_failed", err)
+			return
+		}
+		cli.Success("report.written", reportOutput)
+	},
+}
+
+// summarizeReport 将 Markdown 版本的报告交给 Agent，生成一段总结
+func summarizeReport(data *report.Data) (string, error) {
+	tmpl, err := report.LoadTemplate(report.FormatMarkdown, "")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := report.Render(&buf, tmpl, data); err != nil {
+		return "", err
+	}
+
+	agentInstance, ok := newAgent()
+	if !ok {
+		return "", errors.New(i18n.T("chat.no_api_key"))
+	}
+
+	prompt := i18n.T("report.summary_prompt", data.Title, buf.String())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	summary, err := agentInstance.Chat(ctx, prompt)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(summary), nil
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+
+	reportCmd.Flags().StringVar(&reportPeriod, "period", "week", "报告周期 (day/week)")
+	reportCmd.Flags().StringVarP(&reportFormat, "format", "f", "md", "输出格式 (md/html)")
+	reportCmd.Flags().StringVar(&reportDate, "date", "", "报告周期内的任意一天，默认为今天 (例如 yesterday、last friday)")
+	reportCmd.Flags().StringVarP(&reportTemplate, "template", "t", "", "自定义模板文件")
+	reportCmd.Flags().StringVarP(&reportOutput, "output", "o", "", "写入文件，默认输出到标准输出")
+	reportCmd.Flags().StringVarP(&reportProject, "project", "P", "", "只包含该项目下的任务 (名称或 ID)")
+	reportCmd.Flags().BoolVar(&reportAI, "ai", false, "由 AI 生成一段总结放在报告开头")
+}
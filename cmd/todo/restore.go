@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/WHITE13452/toDoList/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var restoreYes bool
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore [file]",
+	Short: "从备份恢复数据库",
+	Long: `用备份文件替换当前数据库的全部内容。
+
+恢复前会检查备份的完整性和 schema 版本：比当前程序新的备份需要先升级 todo，
+旧版本的备份恢复后自动升级。恢复前会先备份当前数据库，可以用 'todo backup --list' 找到它。
+
+恢复时不打开当前数据库，而是直接用备份文件替换它，数据库损坏、无法打开时也可以恢复。
+恢复后数据库的加密方式与备份相同。恢复前请先停止 'todo daemon' 等正在使用数据库的进程。`,
+	Args: cobra.ExactArgs(1),
+	// 不初始化存储：当前数据库可能已经损坏，打开时的迁移也会修改它
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		setupDBPath(cmd)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		path := args[0]
+		info, err := storage.InspectBackup(path, storageOptions())
+		if err != nil {
+			cli.Error("restore.invalid_backup", err)
+			return
+		}
+
+		cli.Info("restore.info", info.Path, info.SchemaVersion, info.Tasks)
+		if !restoreYes {
+			fmt.Print("\n" + i18n.T("restore.confirm", dbPath))
+			reader := bufio.NewReader(os.Stdin)
+			response, _ := reader.ReadString('\n')
+			response = strings.TrimSpace(strings.ToLower(response))
+			if response != "y" && response != "yes" {
+				fmt.Println(i18n.T("prompt.cancelled"))
+				return
+			}
+		}
+
+		// 数据库文件还不存在时没有需要备份的数据
+		safety := ""
+		if _, err := os.Stat(dbPath); err == nil {
+			safety = storage.BackupPath(storage.BackupDir(dbPath), dbPath, time.Now(), models.BackupReasonPreRestore)
+			if err := storage.BackupFile(dbPath, safety); err != nil {
+				cli.Error("restore.backup_failed", err)
+				return
+			}
+			cli.Info("restore.backed_up", safety)
+		}
+
+		if err := storage.RestoreFile(dbPath, path, storageOptions()); err != nil {
+			cli.Error("restore.failed", err)
+			if safety != "" {
+				cli.Info("restore.undo_hint", safety)
+			}
+			return
+		}
+		cli.Success("restore.done", path)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(restoreCmd)
+
+	restoreCmd.Flags().BoolVarP(&restoreYes, "yes", "y", false, "跳过确认")
+}
@@ -1,17 +1,31 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/events"
+	"github.com/WHITE13452/toDoList/internal/hooks"
+	"github.com/WHITE13452/toDoList/internal/i18n"
 	"github.com/WHITE13452/toDoList/internal/storage"
+	"github.com/WHITE13452/toDoList/internal/webhook"
+	"github.com/WHITE13452/toDoList/internal/workflow"
 	"github.com/joho/godotenv"
 	"github.com/spf13/cobra"
 )
 
 var (
-	dbPath  string
-	store   *storage.Storage
+	dbPath     string
+	store      *storage.Storage
+	eventBus   *events.Bus
+	hookRunner *hooks.Runner
+
+	webhookDispatcher *webhook.Dispatcher
+
+	// exitCode 命令执行完（包括 PersistentPostRun）之后的退出状态，例如 doctor 发现问题时为 1
+	exitCode int
 )
 
 var rootCmd = &cobra.Command{
@@ -22,32 +36,138 @@ var rootCmd = &cobra.Command{
 支持传统 CLI 命令和 AI Agent 交互两种模式。`,
 	Version: "1.0.0",
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		// 加载 .env 文件
-		_ = godotenv.Load()
+		setupDBPath(cmd)
 
 		// 初始化存储
 		var err error
-		store, err = storage.New(dbPath)
+		store, err = storage.NewWithOptions(dbPath, storageOptions())
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to initialize storage: %v\n", err)
 			os.Exit(1)
 		}
+
+		// 初始化事件总线和钩子
+		eventBus = events.NewBus()
+		store.SetEventBus(eventBus)
+		if err := setupHooks(); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠ %v\n", err)
+		}
+
+		if err := setupWorkflow(); err != nil {
+			fmt.Fprintln(os.Stderr, "⚠ "+i18n.T("workflow.fallback", err))
+		}
+
+		webhookDispatcher = webhook.NewDispatcher(store)
+		webhookDispatcher.Attach(eventBus)
+
+		cli.ProjectName = func(id int64) string {
+			if project, err := store.GetProject(id); err == nil && project != nil {
+				return project.Name
+			}
+			return fmt.Sprintf("#%d", id)
+		}
 	},
 	PersistentPostRun: func(cmd *cobra.Command, args []string) {
-		// 关闭存储
+		// 短暂等待钩子执行结束，仍未结束的钩子留在后台运行，不阻塞命令退出
+		if hookRunner != nil {
+			hookRunner.Wait(hooks.ExitGrace)
+		}
+
+		// 投递发件箱中到期的 Webhook：本次命令产生的新事件，以及之前失败后到了重试时间的记录。
+		// 没有新事件时只用很短的时间重试，失败的记录留在发件箱等待下一次
+		if webhookDispatcher != nil {
+			budget := webhook.RetryBudget
+			if webhookDispatcher.HasEnqueued() {
+				budget = webhook.FlushBudget
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), budget)
+			if _, err := webhookDispatcher.Flush(ctx); err != nil {
+				fmt.Fprintf(os.Stderr, "⚠ webhook: %v\n", err)
+			}
+			cancel()
+		}
+
+		// 关闭存储，整库加密时会重新加密写回数据库文件
 		if store != nil {
-			store.Close()
+			if err := store.Close(); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to close storage: %v\n", err)
+				os.Exit(1)
+			}
 		}
 	},
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringVar(&dbPath, "db", "", "数据库文件路径 (默认: ~/.todolist.db)")
+	rootCmd.PersistentFlags().StringVar(&dbPath, "db", "", "数据库文件路径 (默认使用当前工作区的数据库)")
+	rootCmd.PersistentFlags().StringVar(&workspaceFlag, "workspace", "", "本次命令使用的工作区，默认读取配置中的 workspace")
+	rootCmd.PersistentFlags().StringVar(&profileFlag, "profile", "", "使用配置文件中的 profile，默认读取 TODO_PROFILE")
+	rootCmd.PersistentFlags().StringVar(&langFlag, "lang", "", "界面语言 (zh-CN/en-US)，默认读取 TODO_LANG 或 LANG")
+}
+
+// setupDBPath 检查配置并确定数据库路径：--db、--workspace、配置，最后是默认工作区，出错时退出。
+// doctor 和 restore 只调用它而不打开存储，其他命令在此之后初始化存储
+func setupDBPath(cmd *cobra.Command) {
+	checkConfig(cmd)
+
+	var err error
+	dbPath, err = resolveDBPath(cmd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "✗ %v\n", err)
+		os.Exit(1)
+	}
+	warnLegacyDatabase(dbPath)
+}
+
+// setupHooks 加载钩子配置并订阅事件总线
+func setupHooks() error {
+	path, err := hooks.DefaultPath()
+	if err != nil {
+		return err
+	}
+
+	config, err := hooks.LoadConfig(path)
+	if err != nil {
+		return err
+	}
+
+	hookRunner = hooks.NewRunner(config)
+	hookRunner.Attach(eventBus)
+	return nil
+}
+
+// setupWorkflow 加载看板工作流配置
+func setupWorkflow() error {
+	path, err := workflow.DefaultPath()
+	if err != nil {
+		return err
+	}
+
+	w, err := workflow.Load(path)
+	if err != nil {
+		return err
+	}
+
+	store.SetWorkflow(w)
+	return nil
 }
 
 // Execute 执行根命令
 func Execute() {
+	// 加载 .env 文件，其中也可以设置 TODO_LANG、TODO_PROFILE 等环境变量
+	_ = godotenv.Load()
+
+	if err := setupConfig(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "✗ %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := setupLocale(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "✗ %v\n", err)
+		os.Exit(1)
+	}
+
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
 	}
+	os.Exit(exitCode)
 }
@@ -4,35 +4,58 @@ import (
 	"fmt"
 
 	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/WHITE13452/toDoList/internal/storage"
 	"github.com/spf13/cobra"
 )
 
+var (
+	searchProject string
+	searchLimit   int
+	searchAfter   string
+)
+
 var searchCmd = &cobra.Command{
 	Use:   "search [keyword]",
 	Short: "搜索任务",
-	Long:  "在待办事项中搜索包含指定关键词的任务（标题或描述）。",
+	Long:  "在待办事项中搜索包含指定关键词的任务（标题、描述或标签）。",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		keyword := args[0]
 
-		tasks, err := store.SearchTasks(keyword)
+		projectID, err := resolveProjectID(searchProject)
+		if err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+
+		page, err := store.SearchTaskPage(keyword, projectID, storage.Page{Limit: searchLimit, After: searchAfter})
 		if err != nil {
-			cli.PrintError("搜索失败: %v", err)
+			cli.Error("error.search", err)
 			return
 		}
+		tasks := page.Tasks
 
 		if len(tasks) == 0 {
-			fmt.Printf("未找到包含 '%s' 的任务\n", keyword)
+			fmt.Println(i18n.T("search.none", keyword))
 			return
 		}
 
-		fmt.Printf("\n找到 %d 个匹配的任务:\n\n", len(tasks))
+		fmt.Printf("\n%s\n\n", i18n.T("search.found", len(tasks)))
 		for _, task := range tasks {
 			cli.PrintTask(task, false)
 		}
+		if page.Next != "" {
+			fmt.Println()
+			cli.Info("search.more", page.Next)
+		}
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(searchCmd)
+
+	searchCmd.Flags().StringVarP(&searchProject, "project", "P", "", "只搜索该项目下的任务 (名称或 ID)")
+	searchCmd.Flags().IntVarP(&searchLimit, "limit", "n", 0, "每页显示的任务数，0 表示全部")
+	searchCmd.Flags().StringVar(&searchAfter, "after", "", "从上一页给出的游标之后开始显示")
 }
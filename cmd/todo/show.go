@@ -15,21 +15,35 @@ var showCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		taskID, err := strconv.ParseInt(args[0], 10, 64)
 		if err != nil {
-			cli.PrintError("无效的任务 ID")
+			cli.Error("error.invalid_task_id")
 			return
 		}
 
 		task, err := store.GetTask(taskID)
 		if err != nil {
-			cli.PrintError("获取任务失败: %v", err)
+			cli.Error("error.get_task", err)
 			return
 		}
 		if task == nil {
-			cli.PrintError("任务 %d 不存在", taskID)
+			cli.Error("error.task_not_found", taskID)
 			return
 		}
 
 		cli.PrintTask(task, true)
+
+		entries, err := store.ListTimeEntries(taskID)
+		if err != nil {
+			cli.Error("error.time_entries", err)
+			return
+		}
+		cli.PrintTrackedTime(entries)
+
+		focusStats, err := store.GetTaskFocusStats(taskID)
+		if err != nil {
+			cli.Error("error.focus_sessions", err)
+			return
+		}
+		cli.PrintFocusStats(focusStats)
 	},
 }
 
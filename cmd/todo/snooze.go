@@ -0,0 +1,76 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+var unsnooze bool
+
+var snoozeCmd = &cobra.Command{
+	Use:   "snooze [task_id] [until]",
+	Short: "延后任务",
+	Long: `延后任务，延后期间任务不会出现在 'todo list' 中，到期后自动重新出现。
+
+延后时间支持：
+• 时长：3d、12h、1d12h
+• 英文表达：tomorrow、next week、next monday、"in 3 days"、"friday 9am"
+• 中文表达：明天、下周一、"3天后"、"后天上午10点"
+• 日期：2026-11-01 或 "2026-11-01 09:00"
+
+只写日期时延后到当天 0 点。
+
+使用 -u 取消延后。`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		taskID, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			cli.Error("error.invalid_task_id")
+			return
+		}
+
+		task, err := store.GetTask(taskID)
+		if err != nil {
+			cli.Error("error.get_task", err)
+			return
+		}
+		if task == nil {
+			cli.Error("error.task_not_found", taskID)
+			return
+		}
+
+		if unsnooze {
+			task.Defer(nil)
+		} else {
+			if len(args) < 2 {
+				cli.Error("snooze.missing")
+				return
+			}
+			until, err := parseTimeOrDuration(args[1], deferDefaultClock)
+			if err != nil {
+				cli.PrintError("%v", err)
+				return
+			}
+			task.Defer(&until)
+		}
+
+		if err := store.UpdateTask(task); err != nil {
+			cli.Error("error.update_task", err)
+			return
+		}
+
+		if unsnooze {
+			cli.Success("snooze.cleared", taskID)
+		} else {
+			cli.Success("snooze.done", taskID, task.DeferUntil.Format("2006-01-02 15:04"))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(snoozeCmd)
+
+	snoozeCmd.Flags().BoolVarP(&unsnooze, "unsnooze", "u", false, "取消延后")
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var startSwitch bool
+
+var startCmd = &cobra.Command{
+	Use:   "start [task_id]",
+	Short: "开始为任务计时",
+	Long: `开始为任务计时，使用 'todo stop' 停止。
+
+同一时间只能有一个计时器在运行；使用 --switch 先停止当前计时器再开始新的计时。
+不带参数时显示正在运行的计时器。`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			printRunningTimer()
+			return
+		}
+
+		taskID, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			cli.Error("error.invalid_task_id")
+			return
+		}
+
+		task, err := store.GetTask(taskID)
+		if err != nil {
+			cli.Error("error.get_task", err)
+			return
+		}
+		if task == nil {
+			cli.Error("error.task_not_found", taskID)
+			return
+		}
+		if task.Status == models.StatusCompleted {
+			cli.Error("start.completed", taskID)
+			return
+		}
+
+		now := time.Now()
+		if startSwitch {
+			stopped, err := store.StopTimer(now)
+			if err != nil {
+				cli.Error("error.stop_timer", err)
+				return
+			}
+			if stopped != nil {
+				cli.Info("start.stopped", stopped.TaskID, models.FormatDuration(stopped.Duration(now)))
+			}
+		}
+
+		if _, err := store.StartTimer(taskID, now); err != nil {
+			if errors.Is(err, models.ErrTimerRunning) {
+				running, _ := store.GetRunningTimer()
+				if running != nil {
+					cli.Error("start.running", running.TaskID)
+					return
+				}
+			}
+			cli.Error("start.failed", err)
+			return
+		}
+
+		cli.Success("start.started", taskID, task.Title)
+	},
+}
+
+// printRunningTimer 打印正在运行的计时器
+func printRunningTimer() {
+	running, err := store.GetRunningTimer()
+	if err != nil {
+		cli.Error("error.running_timer", err)
+		return
+	}
+	if running == nil {
+		cli.Info("timer.none")
+		return
+	}
+
+	task, err := store.GetTask(running.TaskID)
+	if err != nil {
+		cli.Error("error.get_task", err)
+		return
+	}
+	cli.PrintRunningTimer(running, task)
+}
+
+func init() {
+	rootCmd.AddCommand(startCmd)
+
+	startCmd.Flags().BoolVarP(&startSwitch, "switch", "s", false, "停止当前计时器后再开始")
+}
@@ -1,25 +1,120 @@
 package main
 
 import (
+	"time"
+
 	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/WHITE13452/toDoList/internal/storage"
 	"github.com/spf13/cobra"
 )
 
+var (
+	statsProject  string
+	statsAccuracy bool
+	statsRange    string
+	statsBy       string
+	statsChart    bool
+)
+
+// chartTrendRange 不指定 --range 时图表中趋势的默认范围
+const chartTrendRange = 14 * 24 * time.Hour
+
 var statsCmd = &cobra.Command{
 	Use:   "stats",
 	Short: "显示统计信息",
-	Long:  "显示待办事项的统计信息，包括总数、完成数、完成率等。",
+	Long: `显示待办事项的统计信息，包括总数、完成数、完成率等。
+
+使用 --range 或 --by 查看一段时间内的趋势：每天/每周新建和完成的任务数、
+按分类和优先级的平均完成耗时、连续完成天数以及未完成任务的存在时长。
+
+  todo stats --range 30d --by week
+  todo stats --range 7d
+
+使用 --chart 以条形图、迷你图和燃尽图显示；输出不是终端时使用 ASCII 字符绘制。
+
+  todo stats --chart
+  todo stats --chart --range 12w --by week`,
 	Run: func(cmd *cobra.Command, args []string) {
-		stats, err := store.GetStatistics()
+		projectID, err := resolveProjectID(statsProject)
+		if err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+
+		if statsAccuracy {
+			report, err := store.GetEstimateAccuracy(storage.StatsFilter{ProjectID: projectID})
+			if err != nil {
+				cli.Error("stats.accuracy_failed", err)
+				return
+			}
+			cli.PrintAccuracyReport(report)
+			return
+		}
+
+		if cmd.Flags().Changed("range") || cmd.Flags().Changed("by") {
+			span, err := parseDuration(statsRange)
+			if err != nil {
+				cli.PrintError("%v", err)
+				return
+			}
+			granularity := models.Granularity(statsBy)
+			if !granularity.IsValid() {
+				cli.Error("stats.invalid_period")
+				return
+			}
+
+			now := time.Now()
+			trends, err := store.GetProductivityTrends(storage.TrendsFilter{
+				StatsFilter: storage.StatsFilter{ProjectID: projectID},
+				From:        models.RangeStart(now, span),
+				To:          now,
+				Granularity: granularity,
+			})
+			if err != nil {
+				cli.Error("error.trends", err)
+				return
+			}
+			if statsChart {
+				cli.PrintTrendsCharts(trends)
+			} else {
+				cli.PrintTrends(trends)
+			}
+			return
+		}
+
+		stats, err := store.GetStatistics(storage.StatsFilter{ProjectID: projectID})
 		if err != nil {
-			cli.PrintError("获取统计信息失败: %v", err)
+			cli.Error("stats.failed", err)
 			return
 		}
 
-		cli.PrintStatistics(stats)
+		if !statsChart {
+			cli.PrintStatistics(stats)
+			return
+		}
+
+		now := time.Now()
+		trends, err := store.GetProductivityTrends(storage.TrendsFilter{
+			StatsFilter: storage.StatsFilter{ProjectID: projectID},
+			From:        models.RangeStart(now, chartTrendRange),
+			To:          now,
+			Granularity: models.GranularityDay,
+		})
+		if err != nil {
+			cli.Error("error.trends", err)
+			return
+		}
+		cli.PrintStatisticsCharts(stats, trends)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(statsCmd)
+
+	statsCmd.Flags().StringVarP(&statsProject, "project", "P", "", "只统计该项目下的任务 (名称或 ID)")
+	statsCmd.Flags().BoolVar(&statsAccuracy, "accuracy", false, "对比已完成任务的估算与实际耗时")
+	statsCmd.Flags().StringVarP(&statsRange, "range", "r", "30d", "趋势统计的时间范围 (例如 7d、30d、12w)")
+	statsCmd.Flags().StringVarP(&statsBy, "by", "b", "day", "趋势统计的粒度 (day/week)")
+	statsCmd.Flags().BoolVar(&statsChart, "chart", false, "以图表形式显示")
 }
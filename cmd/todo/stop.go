@@ -0,0 +1,40 @@
+package main
+
+import (
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var stopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "停止正在运行的计时器",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		now := time.Now()
+		entry, err := store.StopTimer(now)
+		if err != nil {
+			cli.Error("error.stop_timer", err)
+			return
+		}
+		if entry == nil {
+			cli.Info("timer.none")
+			return
+		}
+
+		total, err := store.GetTrackedTime(entry.TaskID)
+		if err != nil {
+			cli.Error("error.tracked_time", err)
+			return
+		}
+
+		cli.Success("stop.done",
+			entry.TaskID, models.FormatDuration(entry.Duration(now)), models.FormatDuration(total))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(stopCmd)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/dateparse"
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+// 只写日期不写时刻时使用的默认时刻
+const (
+	dueDefaultClock    = 23*time.Hour + 59*time.Minute // 截止时间：当天结束前
+	remindDefaultClock = 9 * time.Hour                 // 提醒：当天上午 9 点
+	deferDefaultClock  = 0                             // 延后：当天 0 点
+)
+
+// parseTime 解析命令行传入的时间表达式，例如 "tomorrow 9am"、"下周一"、"2026-11-01 14:00"
+func parseTime(value string, defaultClock time.Duration) (time.Time, error) {
+	t, err := dateparse.ParseWithOptions(value, time.Now(), dateparse.Options{DefaultClock: defaultClock})
+	if err != nil {
+		return time.Time{}, errors.New(i18n.T("time.invalid", value))
+	}
+	return t, nil
+}
+
+// parseDuration 解析时长，支持周 (w) 和天 (d)，例如 "1d12h"、"1h30m"
+func parseDuration(value string) (time.Duration, error) {
+	d, err := dateparse.ParseDuration(value)
+	if err != nil {
+		return 0, errors.New(i18n.T("time.invalid_duration", value))
+	}
+	return d, nil
+}
+
+// parseTimeOrDuration 先按时长解析（从现在起算），失败再按时间表达式解析
+func parseTimeOrDuration(value string, defaultClock time.Duration) (time.Time, error) {
+	if d, err := dateparse.ParseDuration(value); err == nil {
+		return time.Now().Add(d), nil
+	}
+	return parseTime(value, defaultClock)
+}
+
+// parseEstimate 解析工作量估算，时长 (2h、1h30m) 或故事点 (3pt)
+func parseEstimate(value string) (models.Estimate, error) {
+	estimate, err := models.ParseEstimate(value)
+	if err != nil {
+		return models.Estimate{}, errors.New(i18n.T("time.invalid_estimate", value))
+	}
+	return estimate, nil
+}
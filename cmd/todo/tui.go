@@ -0,0 +1,38 @@
+package main
+
+import (
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "启动全屏终端界面",
+	Long: `启动全屏终端界面，在一个窗口中浏览、过滤、编辑任务并与 AI 助手对话。
+
+快捷键：
+  j/k ↑/↓   上下移动
+  space/x   切换完成状态
+  a / e     添加 / 编辑任务
+  d / u     删除 / 撤销删除（退出时才真正删除）
+  /         过滤，例如 "周报 #work +release @launch !3 is:done"
+  c         切换到 AI 对话（需要 QWEN_API_KEY）
+  Tab       在列表、过滤栏、对话之间切换
+  ?         显示帮助
+  q         退出`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !cli.IsTerminal() {
+			cli.Error("tui.no_terminal")
+			return
+		}
+		if err := tui.New(store, loadAgent).Run(); err != nil {
+			cli.Error("tui.failed", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+}
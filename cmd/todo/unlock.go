@@ -0,0 +1,49 @@
+package main
+
+import (
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/keyagent"
+	"github.com/spf13/cobra"
+)
+
+var unlockTimeout time.Duration
+
+var unlockCmd = &cobra.Command{
+	Use:   "unlock",
+	Short: "缓存加密数据库的密钥",
+	Long: `输入口令，把密钥交给后台的密钥代理缓存 --timeout 时间。
+有效期内使用同一数据库的命令不需要再输入口令，再次执行会重新计时。
+
+密钥只保存在代理进程的内存中，代理通过运行时目录中只有当前用户可以访问的 socket 提供密钥。
+使用 'todo lock' 立即清除所有缓存的密钥。
+
+  todo unlock               # 缓存 15 分钟
+  todo unlock --timeout 8h`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		h := store.EncryptionHeader()
+		if h == nil {
+			cli.Error("error.not_encrypted")
+			return
+		}
+
+		key, err := provideKey(h)
+		if err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+		if err := keyagent.Add(h.ID(), key, unlockTimeout); err != nil {
+			cli.Error("unlock.failed", err)
+			return
+		}
+		cli.Success("unlock.done", unlockTimeout)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(unlockCmd)
+
+	unlockCmd.Flags().DurationVar(&unlockTimeout, "timeout", 15*time.Minute, "密钥的缓存时间")
+}
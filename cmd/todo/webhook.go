@@ -0,0 +1,284 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/events"
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/WHITE13452/toDoList/internal/webhook"
+	"github.com/spf13/cobra"
+)
+
+var (
+	webhookEvents     string
+	webhookFilter     string
+	webhookSecret     string
+	webhookDeliveries bool
+	webhookFailed     bool
+)
+
+var webhookCmd = &cobra.Command{
+	Use:   "webhook",
+	Short: "管理 Webhook 订阅",
+	Long: `管理任务变更的 Webhook 订阅。
+
+任务事件发生时，匹配的订阅会写入发件箱并通过 HTTP POST 投递 JSON，
+请求头 X-Todo-Signature 携带 HMAC-SHA256 签名。投递失败会按指数退避重试。`,
+}
+
+var webhookAddCmd = &cobra.Command{
+	Use:   "add [url]",
+	Short: "添加 Webhook 订阅",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if _, err := webhook.ParseFilter(webhookFilter); err != nil {
+			cli.Error("webhook.invalid_filter", err)
+			return
+		}
+
+		var eventTypes []string
+		for _, e := range strings.Split(webhookEvents, ",") {
+			e = strings.TrimSpace(e)
+			if e == "" {
+				continue
+			}
+			if !validEventType(e) {
+				cli.Error("webhook.invalid_event", e)
+				return
+			}
+			eventTypes = append(eventTypes, e)
+		}
+
+		secret := webhookSecret
+		if secret == "" {
+			var err error
+			secret, err = webhook.GenerateSecret()
+			if err != nil {
+				cli.PrintError("%v", err)
+				return
+			}
+		}
+
+		hook := &models.Webhook{
+			URL:       args[0],
+			Secret:    secret,
+			Events:    eventTypes,
+			Filter:    webhookFilter,
+			Active:    true,
+			CreatedAt: time.Now(),
+		}
+		if err := store.AddWebhook(hook); err != nil {
+			cli.Error("webhook.add_failed", err)
+			return
+		}
+
+		cli.Success("webhook.added", hook.ID)
+		fmt.Println(i18n.T("webhook.secret", secret))
+	},
+}
+
+var webhookListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出 Webhook 订阅",
+	Run: func(cmd *cobra.Command, args []string) {
+		hooks, err := store.ListWebhooks()
+		if err != nil {
+			cli.Error("webhook.list_failed", err)
+			return
+		}
+		cli.PrintWebhooks(hooks)
+
+		if webhookDeliveries {
+			deliveries, err := store.ListDeliveries("", 20)
+			if err != nil {
+				cli.Error("error.deliveries", err)
+				return
+			}
+			fmt.Println()
+			cli.PrintDeliveries(deliveries)
+		}
+	},
+}
+
+var webhookRemoveCmd = &cobra.Command{
+	Use:   "remove [webhook_id]",
+	Short: "删除 Webhook 订阅",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			cli.Error("error.invalid_webhook_id")
+			return
+		}
+
+		if err := store.DeleteWebhook(id); err != nil {
+			cli.Error("webhook.delete_failed", err)
+			return
+		}
+
+		cli.Success("webhook.deleted", id)
+	},
+}
+
+var webhookDisableCmd = &cobra.Command{
+	Use:   "disable [webhook_id]",
+	Short: "停用 Webhook 订阅",
+	Long:  "停用 Webhook 订阅。停用期间不再写入新的事件，发件箱中尚未投递的记录标记为失败，重新启用后可以用 'todo webhook replay --failed' 重新投递。",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		setWebhookActive(args[0], false)
+	},
+}
+
+var webhookEnableCmd = &cobra.Command{
+	Use:   "enable [webhook_id]",
+	Short: "重新启用 Webhook 订阅",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		setWebhookActive(args[0], true)
+	},
+}
+
+// setWebhookActive 启用或停用 Webhook 订阅
+func setWebhookActive(arg string, active bool) {
+	id, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		cli.Error("error.invalid_webhook_id")
+		return
+	}
+
+	if err := store.SetWebhookActive(id, active); err != nil {
+		cli.Error("webhook.update_failed", err)
+		return
+	}
+
+	if active {
+		cli.Success("webhook.enabled", id)
+	} else {
+		cli.Success("webhook.disabled", id)
+	}
+}
+
+var webhookTestCmd = &cobra.Command{
+	Use:   "test [webhook_id]",
+	Short: "发送测试事件",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			cli.Error("error.invalid_webhook_id")
+			return
+		}
+
+		hook, err := store.GetWebhook(id)
+		if err != nil {
+			cli.Error("webhook.get_failed", err)
+			return
+		}
+		if hook == nil {
+			cli.Error("webhook.not_found", id)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := webhookDispatcher.Test(ctx, hook); err != nil {
+			cli.Error("webhook.test_failed", err)
+			return
+		}
+
+		cli.Success("webhook.tested", hook.URL)
+	},
+}
+
+var webhookReplayCmd = &cobra.Command{
+	Use:   "replay [delivery_id]",
+	Short: "重新投递",
+	Long:  "重新投递指定的投递记录，使用 --failed 重新投递所有失败的记录。",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var ids []int64
+		if webhookFailed {
+			deliveries, err := store.ListDeliveries(models.DeliveryFailed, 1000)
+			if err != nil {
+				cli.Error("error.deliveries", err)
+				return
+			}
+			for _, delivery := range deliveries {
+				ids = append(ids, delivery.ID)
+			}
+		} else {
+			if len(args) == 0 {
+				cli.Error("webhook.retry_usage")
+				return
+			}
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				cli.Error("webhook.invalid_delivery_id")
+				return
+			}
+			ids = append(ids, id)
+		}
+
+		if len(ids) == 0 {
+			cli.Info("webhook.nothing_to_retry")
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		for _, id := range ids {
+			delivery, err := webhookDispatcher.Replay(ctx, id)
+			if err != nil {
+				cli.Error("webhook.redeliver_failed", id, err)
+				continue
+			}
+			if delivery.Status == models.DeliveryDelivered {
+				cli.Success("webhook.delivered", id)
+			} else {
+				cli.Error("webhook.delivery_failed", id, delivery.LastError)
+			}
+		}
+	},
+}
+
+var webhookFlushCmd = &cobra.Command{
+	Use:   "flush",
+	Short: "投递发件箱中到期的记录",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		delivered, err := webhookDispatcher.Flush(ctx)
+		if err != nil {
+			cli.Error("webhook.flush_failed", err)
+			return
+		}
+
+		cli.Success("webhook.flushed", delivered)
+	},
+}
+
+func validEventType(eventType string) bool {
+	return eventType == "*" || events.Type(eventType).IsValid()
+}
+
+func init() {
+	rootCmd.AddCommand(webhookCmd)
+	webhookCmd.AddCommand(webhookAddCmd, webhookListCmd, webhookRemoveCmd,
+		webhookDisableCmd, webhookEnableCmd, webhookTestCmd, webhookReplayCmd, webhookFlushCmd)
+
+	webhookAddCmd.Flags().StringVarP(&webhookEvents, "events", "e", "", "订阅的事件类型，逗号分隔 (默认全部)")
+	webhookAddCmd.Flags().StringVarP(&webhookFilter, "filter", "f", "", "任务过滤条件，例如 priority>=4")
+	webhookAddCmd.Flags().StringVar(&webhookSecret, "secret", "", "签名密钥 (默认随机生成)")
+	webhookListCmd.Flags().BoolVar(&webhookDeliveries, "deliveries", false, "同时显示最近的投递记录")
+	webhookReplayCmd.Flags().BoolVar(&webhookFailed, "failed", false, "重新投递所有失败的记录")
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/config"
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/WHITE13452/toDoList/internal/storage"
+	"github.com/WHITE13452/toDoList/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// workspaceFlag --workspace 参数
+	workspaceFlag string
+
+	workspaceLocal bool
+)
+
+var workspaceCmd = &cobra.Command{
+	Use:   "workspace",
+	Short: "管理工作区",
+	Long: `管理工作区。每个工作区有独立的数据库，存放在 XDG 数据目录
+($XDG_DATA_HOME/todo，默认 ~/.local/share/todo) 下，在任何目录执行命令都使用同一个工作区。
+
+数据库按以下顺序确定：--db 参数 > --workspace 参数 > 配置项 db > 配置项 workspace > 默认工作区。
+在仓库根目录的 .todo.yaml 中设置 workspace 或 db，可以让该目录及其子目录使用独立的任务列表：
+  todo workspace use myrepo --local        # 当前目录使用 myrepo 工作区
+  todo config set db .todolist.db --local  # 当前目录使用仓库内的数据库文件`,
+	// 工作区命令不需要打开数据库，当前工作区不存在时也可以使用
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		checkConfig(cmd)
+	},
+}
+
+var workspaceCreateCmd = &cobra.Command{
+	Use:   "create [name]",
+	Short: "创建工作区",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		exists, err := workspace.Exists(name)
+		if err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+		if exists {
+			cli.Error("workspace.exists", name)
+			return
+		}
+
+		path, err := workspace.Path(name)
+		if err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+		s, err := storage.New(path)
+		if err != nil {
+			cli.Error("workspace.create_failed", err)
+			return
+		}
+		s.Close()
+
+		cli.Success("workspace.created", name, path)
+		cli.Info("workspace.use_hint", name)
+	},
+}
+
+var workspaceUseCmd = &cobra.Command{
+	Use:   "use [name]",
+	Short: "切换当前工作区",
+	Long:  "切换当前工作区，写入用户配置文件。使用 --local 只对当前目录及其子目录生效，写入当前目录的 .todo.yaml。",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		exists, err := workspace.Exists(name)
+		if err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+		if !exists {
+			cli.Error("workspace.not_found", name, name)
+			return
+		}
+
+		path := userConfigPath()
+		if workspaceLocal {
+			path = config.ProjectFile
+		}
+		file, err := config.ReadFile(path)
+		if err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+		if file == nil {
+			file = &config.Config{}
+		}
+
+		value := name
+		if name == workspace.Default && !workspaceLocal {
+			value = ""
+		}
+		if err := file.Set("workspace", value); err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+		if err := config.WriteFile(path, file); err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+
+		cli.Success("workspace.switched", name, path)
+		if file.DB != "" {
+			cli.Info("workspace.db_overrides", path)
+		}
+	},
+}
+
+var workspaceListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出工作区",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		workspaces, err := workspace.List()
+		if err != nil {
+			cli.Error("workspace.list_failed", err)
+			return
+		}
+
+		current, err := resolveDBPath(cmd)
+		if err != nil {
+			cli.PrintError("%v", err)
+			return
+		}
+
+		table := cli.NewTable(
+			cli.Column{Header: " "},
+			cli.Column{Header: i18n.T("workspace.column_name")},
+			cli.Column{Header: i18n.T("workspace.column_db"), Flex: true},
+		)
+		inWorkspace := false
+		for _, w := range workspaces {
+			marker := ""
+			if samePath(w.Path, current) {
+				marker = "*"
+				inWorkspace = true
+			}
+			table.AddRow(marker, w.Name, w.Path)
+		}
+		table.Render(os.Stdout)
+
+		if !inWorkspace {
+			cli.Info("workspace.outside", current)
+		}
+	},
+}
+
+// resolveDBPath 确定使用的数据库：--db 参数 > --workspace 参数 > 配置项 db > 配置项 workspace > 默认工作区
+func resolveDBPath(cmd *cobra.Command) (string, error) {
+	flags := cmd.Flags()
+	switch {
+	case flags.Changed("db"):
+		_ = cfg.Override("db", dbPath, config.SourceFlag)
+		return dbPath, nil
+	case flags.Changed("workspace"):
+		if err := cfg.Override("workspace", workspaceFlag, config.SourceFlag); err != nil {
+			return "", err
+		}
+		return workspacePath(workspaceFlag)
+	case cfg.DB != "":
+		return cfg.DBPath(), nil
+	default:
+		return workspacePath(cfg.Workspace)
+	}
+}
+
+// workspacePath 已创建的工作区的数据库路径
+func workspacePath(name string) (string, error) {
+	exists, err := workspace.Exists(name)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", errors.New(i18n.T("workspace.not_found", name, name))
+	}
+	return workspace.Path(name)
+}
+
+// warnLegacyDatabase 旧版本默认使用当前目录的 .todolist.db，现在默认使用工作区。
+// 当前目录有旧数据库且没有被使用时给出提示，避免看起来任务丢失了
+func warnLegacyDatabase(current string) {
+	if _, err := os.Stat(workspace.LegacyFile); err != nil {
+		return
+	}
+	if samePath(workspace.LegacyFile, current) {
+		return
+	}
+	fmt.Fprintln(os.Stderr, "⚠ "+i18n.T("workspace.legacy", workspace.LegacyFile, current, workspace.LegacyFile))
+}
+
+// samePath 两个路径是否指向同一个文件
+func samePath(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
+
+func init() {
+	rootCmd.AddCommand(workspaceCmd)
+	workspaceCmd.AddCommand(workspaceCreateCmd, workspaceUseCmd, workspaceListCmd)
+
+	workspaceUseCmd.Flags().BoolVar(&workspaceLocal, "local", false, "写入当前目录的 "+config.ProjectFile+"，只对该目录及其子目录生效")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/WHITE13452/toDoList/internal/i18n"
 	"github.com/WHITE13452/toDoList/internal/tools"
 	"github.com/sashabaranov/go-openai"
 )
@@ -21,6 +22,8 @@ type Config struct {
 	APIKey  string
 	BaseURL string
 	Model   string
+	// Locale 系统提示词使用的语言，为空时使用 i18n.Current()
+	Locale i18n.Locale
 }
 
 // New 创建新的 Agent 实例
@@ -42,29 +45,8 @@ func New(config Config, tools *tools.TodoTools) *Agent {
 		model:  config.Model,
 		messages: []openai.ChatCompletionMessage{
 			{
-				Role: openai.ChatMessageRoleSystem,
-				Content: `你是一个智能待办事项管理助手。你可以帮助用户管理他们的任务列表。
-
-你的能力包括：
-1. 查看和总结待办事项
-2. 添加新任务
-3. 标记任务完成或未完成
-4. 删除任务
-5. 搜索特定任务
-6. 提供统计信息和分析
-7. 批量操作任务
-
-使用技巧：
-- 当用户询问任务情况时，先调用 get_all_tasks 或 get_statistics 获取信息
-- 对于模糊的任务描述，可以使用 search_tasks 查找
-- 批量操作时使用 batch_complete_tasks 或 batch_delete_tasks
-- 提供建议时要考虑任务的优先级和分类
-- 用清晰、友好的中文与用户交流
-
-重要：
-- 在执行删除等重要操作前，最好确认用户的意图
-- 提供统计和总结时，用简洁明了的方式呈现
-- 如果任务很多，可以先总结再列出重点`,
+				Role:    openai.ChatMessageRoleSystem,
+				Content: systemPrompt(config.Locale),
 			},
 		},
 	}
@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/clipperhouse/displaywidth"
+)
+
+const (
+	agendaTitleWidth = 36
+	calendarCell     = 8
+)
+
+// PrintAgenda 打印议程：已逾期的任务，然后按天列出即将到期的任务
+func PrintAgenda(agenda *models.Agenda) {
+	now := time.Now()
+
+	if len(agenda.Overdue) > 0 {
+		errorColor.Println(i18n.T("agenda.overdue", len(agenda.Overdue)))
+		for _, task := range agenda.Overdue {
+			printAgendaTask(task, task.DueAt.Format("01-02 15:04"), true)
+		}
+		fmt.Println()
+	}
+
+	total := len(agenda.Overdue)
+	for _, day := range agenda.Days {
+		total += len(day.Tasks)
+		header := i18n.T("agenda.day", day.Day.Format("01-02"), weekdayName(day.Day.Weekday()))
+		switch int(day.Day.Sub(agenda.From).Hours()+12) / 24 {
+		case 0:
+			header = i18n.T("agenda.today", header)
+		case 1:
+			header = i18n.T("agenda.tomorrow", header)
+		}
+
+		if len(day.Tasks) == 0 {
+			dimColor.Println(header)
+			continue
+		}
+		infoColor.Printf("%s (%d)\n", header, len(day.Tasks))
+		for _, task := range day.Tasks {
+			printAgendaTask(task, task.DueAt.Format("15:04"), task.IsOverdue(now))
+		}
+	}
+
+	if total == 0 {
+		dimColor.Println("\n" + i18n.T("agenda.empty"))
+	}
+}
+
+// printAgendaTask 打印议程中的一行：时间、ID、优先级、标题、分类、项目和标签
+func printAgendaTask(task *models.Task, when string, overdue bool) {
+	line := fmt.Sprintf("  %s %s %-5d %-4s %s %s",
+		when, StatusIcon(task), task.ID, strings.Repeat("!", int(task.Priority)),
+		fit(task.Title, agendaTitleWidth), CategoryText(task.Category))
+	if task.ProjectID != 0 {
+		line += " @" + ProjectName(task.ProjectID)
+	}
+	if len(task.Tags) > 0 {
+		line += " " + formatTags(task.Tags)
+	}
+
+	if overdue {
+		errorColor.Println(line)
+	} else {
+		fmt.Println(line)
+	}
+}
+
+// PrintCalendar 打印月历，每天显示到期任务数：
+// "!" 有逾期任务，"✓" 全部已完成，"•" 还有未完成的任务
+func PrintCalendar(calendar *models.Calendar) {
+	today := models.GranularityDay.PeriodStart(time.Now())
+
+	title := i18n.T("calendar.title", calendar.Start.Year(), int(calendar.Start.Month()), calendar.Start.Month().String())
+	padding := (calendarCell*7 - displaywidth.String(title)) / 2
+	fmt.Println(strings.Repeat(" ", padding) + title)
+
+	var headers strings.Builder
+	for i := 0; i < 7; i++ {
+		headers.WriteString(fit(weekdayName(time.Weekday((i+1)%7)), calendarCell))
+	}
+	infoColor.Println(strings.TrimRight(headers.String(), " "))
+
+	// 周从周一开始，第一行前面补空格
+	offset := (int(calendar.Start.Weekday()) + 6) % 7
+	fmt.Print(strings.Repeat(" ", offset*calendarCell))
+
+	due, overdue := 0, 0
+	for i, day := range calendar.Days {
+		due += day.Due
+		overdue += day.Overdue
+
+		cell := fmt.Sprintf("%2d", i+1)
+		switch {
+		case day.Overdue > 0:
+			cell += fmt.Sprintf(" !%d", day.Due)
+		case day.Due > 0 && day.Completed == day.Due:
+			cell += fmt.Sprintf(" ✓%d", day.Due)
+		case day.Due > 0:
+			cell += fmt.Sprintf(" •%d", day.Due)
+		}
+		cell = fit(cell, calendarCell)
+
+		switch {
+		case day.Day.Equal(today):
+			highlightColor.Print(cell)
+		case day.Overdue > 0:
+			errorColor.Print(cell)
+		case day.Due > 0 && day.Completed < day.Due:
+			warningColor.Print(cell)
+		case day.Due > 0:
+			successColor.Print(cell)
+		default:
+			fmt.Print(cell)
+		}
+
+		if (offset+i+1)%7 == 0 {
+			fmt.Println()
+		}
+	}
+	if (offset+len(calendar.Days))%7 != 0 {
+		fmt.Println()
+	}
+
+	fmt.Println()
+	dimColor.Print(i18n.T("calendar.summary", due))
+	if overdue > 0 {
+		dimColor.Print(i18n.T("calendar.overdue", overdue))
+	}
+	dimColor.Println(i18n.T("calendar.legend"))
+}
+
+// weekdayName 周几的简称，例如 "一"、"Mon"
+func weekdayName(day time.Weekday) string {
+	return i18n.T("weekday." + strconv.Itoa(int(day)))
+}
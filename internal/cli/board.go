@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"golang.org/x/term"
+)
+
+const (
+	defaultTerminalWidth = 120
+	minColumnWidth       = 14
+)
+
+// PrintBoard 以看板形式打印任务，每个工作流状态一列
+func PrintBoard(workflow *models.Workflow, tasks []*models.Task) {
+	columns := make(map[string][]*models.Task)
+	for _, task := range tasks {
+		state := workflow.StateOf(task)
+		columns[state] = append(columns[state], task)
+	}
+
+	n := len(workflow.States)
+	width := (terminalWidth() - (n - 1)) / n
+	if width < minColumnWidth {
+		width = minColumnWidth
+	}
+
+	// 表头
+	headers := make([]string, n)
+	rows := 0
+	for i, state := range workflow.States {
+		headers[i] = fit(fmt.Sprintf("%s (%d)", StateLabel(state), len(columns[state.Name])), width)
+		if len(columns[state.Name]) > rows {
+			rows = len(columns[state.Name])
+		}
+	}
+	separator := strings.Repeat("─", width)
+	separators := make([]string, n)
+	for i := range separators {
+		separators[i] = separator
+	}
+
+	infoColor.Println(strings.Join(headers, "│"))
+	fmt.Println(strings.Join(separators, "┼"))
+
+	if rows == 0 {
+		dimColor.Println(i18n.T("task.none"))
+		return
+	}
+
+	for row := 0; row < rows; row++ {
+		cells := make([]string, n)
+		for i, state := range workflow.States {
+			cell := ""
+			if row < len(columns[state.Name]) {
+				task := columns[state.Name][row]
+				cell = fmt.Sprintf("%d %s %s", task.ID, strings.Repeat("!", int(task.Priority)), task.Title)
+				if task.IsBlocked() {
+					cell = "⊘" + cell
+				}
+			}
+			cells[i] = fit(cell, width)
+		}
+		fmt.Println(strings.Join(cells, "│"))
+	}
+}
+
+// terminalWidth 终端宽度：优先读取 COLUMNS 环境变量，其次查询终端，都没有时使用默认值
+func terminalWidth() int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	if width, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && width > 0 {
+		return width
+	}
+	return defaultTerminalWidth
+}
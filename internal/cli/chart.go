@@ -0,0 +1,284 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/clipperhouse/displaywidth"
+	"github.com/mattn/go-isatty"
+)
+
+// maxBarWidth 条形图中条形的最大宽度
+const maxBarWidth = 50
+
+// ChartItem 条形图中的一项
+type ChartItem struct {
+	Label string
+	Value float64
+	// Display 显示在条形后面的数值，为空时显示 Value
+	Display string
+}
+
+// chartGlyphs 图表使用的字符。终端中使用 Unicode 方块字符，
+// 输出被重定向（管道、文件）时退化为 ASCII，方便粘贴到邮件或日志里。
+type chartGlyphs struct {
+	// eighths 条形的 1/8 到 8/8 宽度
+	eighths []string
+	// levels 迷你图的 1/8 到 8/8 高度
+	levels []string
+	// cells 柱状图每格的 1/8 到 8/8 高度
+	cells    []string
+	axis     string
+	baseline string
+}
+
+var (
+	unicodeGlyphs = chartGlyphs{
+		eighths:  []string{"▏", "▎", "▍", "▌", "▋", "▊", "▉", "█"},
+		levels:   []string{"▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"},
+		cells:    []string{"▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"},
+		axis:     "│",
+		baseline: "─",
+	}
+	asciiGlyphs = chartGlyphs{
+		eighths:  []string{"", "", "", "", "#", "#", "#", "#"},
+		levels:   []string{"_", ".", "-", "-", "=", "=", "#", "#"},
+		cells:    []string{" ", " ", " ", " ", "#", "#", "#", "#"},
+		axis:     "|",
+		baseline: "-",
+	}
+)
+
+// IsTerminal 标准输出是否为终端
+func IsTerminal() bool {
+	fd := os.Stdout.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
+// glyphs 根据标准输出是否为终端选择图表字符
+func glyphs() chartGlyphs {
+	if IsTerminal() {
+		return unicodeGlyphs
+	}
+	return asciiGlyphs
+}
+
+// BarChart 绘制水平条形图，标签按显示宽度对齐，条形按最大值缩放到 width 列
+func BarChart(w io.Writer, items []ChartItem, width int) {
+	g := glyphs()
+
+	labelWidth := 0
+	peak := 0.0
+	for _, item := range items {
+		labelWidth = max(labelWidth, displaywidth.String(item.Label))
+		peak = math.Max(peak, item.Value)
+	}
+	labelWidth = min(labelWidth, 20)
+	barWidth := min(max(width-labelWidth-12, 10), maxBarWidth)
+
+	for _, item := range items {
+		display := item.Display
+		if display == "" {
+			display = formatValue(item.Value)
+		}
+		fmt.Fprintf(w, "  %s %s%s %s\n", fit(item.Label, labelWidth), g.axis, hbar(g, item.Value, peak, barWidth), display)
+	}
+}
+
+// hbar 长度为 value/peak*width 列的条形，末尾用 1/8 方块表示小数部分
+func hbar(g chartGlyphs, value, peak float64, width int) string {
+	if peak <= 0 || value <= 0 {
+		return ""
+	}
+	eighths := int(math.Round(value / peak * float64(width) * 8))
+	if eighths == 0 {
+		eighths = 1
+	}
+	s := strings.Repeat(g.eighths[7], eighths/8)
+	if rest := eighths % 8; rest > 0 {
+		s += g.eighths[rest-1]
+	}
+	if s == "" {
+		// ASCII 模式下不足半格的条形至少显示一个字符
+		s = g.eighths[7]
+	}
+	return s
+}
+
+// Sparkline 将一组数值绘制为单行迷你图
+func Sparkline(values []float64) string {
+	g := glyphs()
+
+	peak := 0.0
+	for _, v := range values {
+		peak = math.Max(peak, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		if peak <= 0 || v <= 0 {
+			b.WriteString(" ")
+			continue
+		}
+		level := int(math.Ceil(v/peak*8)) - 1
+		b.WriteString(g.levels[max(level, 0)])
+	}
+	return b.String()
+}
+
+// ColumnChart 绘制高度为 height 行的柱状图，每个值一列，左侧为纵轴刻度
+func ColumnChart(w io.Writer, values []float64, labels []string, height int) {
+	g := glyphs()
+
+	peak := 0.0
+	for _, v := range values {
+		peak = math.Max(peak, v)
+	}
+	if peak == 0 {
+		peak = 1
+	}
+	axisWidth := len(formatValue(peak))
+
+	for row := height; row >= 1; row-- {
+		tick := ""
+		if row == height {
+			tick = formatValue(peak)
+		}
+		fmt.Fprintf(w, "  %*s %s", axisWidth, tick, g.axis)
+		for _, v := range values {
+			// 每行 8 个高度单位
+			units := int(math.Round(v / peak * float64(height) * 8))
+			filled := units - (row-1)*8
+			switch {
+			case filled >= 8:
+				fmt.Fprint(w, g.cells[7])
+			case filled > 0:
+				fmt.Fprint(w, g.cells[filled-1])
+			default:
+				fmt.Fprint(w, " ")
+			}
+		}
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintf(w, "  %*s %s\n", axisWidth, "0", strings.Repeat(g.baseline, len(values)))
+
+	// 只标出首尾的周期
+	if len(labels) > 0 {
+		first, last := labels[0], labels[len(labels)-1]
+		gap := len(values) - displaywidth.String(first) - displaywidth.String(last)
+		if gap < 1 {
+			fmt.Fprintf(w, "  %*s  %s\n", axisWidth, "", first)
+		} else {
+			fmt.Fprintf(w, "  %*s  %s%s%s\n", axisWidth, "", first, strings.Repeat(" ", gap), last)
+		}
+	}
+}
+
+// PrintStatisticsCharts 以图表形式打印分类、优先级分布和完成趋势
+func PrintStatisticsCharts(stats *models.Statistics, trends *models.ProductivityTrends) {
+	width := min(terminalWidth(), 100)
+	fmt.Println(strings.Repeat("═", 60))
+	infoColor.Println("                    " + i18n.T("chart.title"))
+	fmt.Println(strings.Repeat("═", 60))
+
+	fmt.Println(i18n.T("chart.rate", stats.CompletionRate, stats.Completed, stats.Total))
+	fmt.Printf("  %s\n", hbar(glyphs(), stats.CompletionRate, 100, min(width-4, 50)))
+
+	if len(stats.ByCategory) > 0 {
+		fmt.Println("\n" + i18n.T("chart.by_category"))
+		var items []ChartItem
+		for _, category := range []models.TaskCategory{models.CategoryWork, models.CategoryStudy, models.CategoryLife, models.CategoryOther} {
+			if count, ok := stats.ByCategory[category]; ok {
+				items = append(items, ChartItem{Label: CategoryText(category), Value: float64(count)})
+			}
+		}
+		BarChart(os.Stdout, items, width)
+	}
+
+	if len(stats.ByPriority) > 0 {
+		fmt.Println("\n" + i18n.T("chart.by_priority"))
+		var items []ChartItem
+		for priority := models.PriorityUrgent; priority >= models.PriorityLow; priority-- {
+			if count, ok := stats.ByPriority[priority]; ok {
+				items = append(items, ChartItem{Label: getPriorityText(priority), Value: float64(count)})
+			}
+		}
+		BarChart(os.Stdout, items, width)
+	}
+
+	if trends != nil {
+		printTrendCharts(trends)
+	}
+
+	fmt.Println(strings.Repeat("═", 60))
+}
+
+// PrintTrendsCharts 以图表形式打印生产力趋势
+func PrintTrendsCharts(trends *models.ProductivityTrends) {
+	fmt.Println(strings.Repeat("═", 60))
+	infoColor.Println("            " + i18n.T("trends.title",
+		trends.From.Format("2006-01-02"), trends.To.Format("2006-01-02")))
+	fmt.Println(strings.Repeat("═", 60))
+
+	printTrendCharts(trends)
+
+	if len(trends.Aging) > 0 && trends.OpenTasks > 0 {
+		fmt.Println("\n" + i18n.T("chart.aging"))
+		items := make([]ChartItem, len(trends.Aging))
+		for i, bucket := range trends.Aging {
+			items[i] = ChartItem{Label: agingLabel(bucket), Value: float64(bucket.Count)}
+		}
+		BarChart(os.Stdout, items, min(terminalWidth(), 100))
+	}
+
+	fmt.Println(strings.Repeat("═", 60))
+}
+
+// printTrendCharts 打印新建/完成迷你图和未完成任务燃尽图
+func printTrendCharts(trends *models.ProductivityTrends) {
+	created := make([]float64, len(trends.Periods))
+	completed := make([]float64, len(trends.Periods))
+	labels := make([]string, len(trends.Periods))
+	for i, period := range trends.Periods {
+		created[i] = float64(period.Created)
+		completed[i] = float64(period.Completed)
+		labels[i] = periodLabel(trends.Granularity, period)
+	}
+
+	title := "chart.daily"
+	if trends.Granularity == models.GranularityWeek {
+		title = "chart.weekly"
+	}
+	fmt.Println("\n" + i18n.T(title, trends.Created, trends.Completed))
+	fmt.Printf("  %s %s%s%s\n", fit(i18n.T("trends.created"), 4), glyphs().axis, Sparkline(created), glyphs().axis)
+	fmt.Printf("  %s %s", fit(i18n.T("trends.completed"), 4), glyphs().axis)
+	successColor.Print(Sparkline(completed))
+	fmt.Printf("%s\n", glyphs().axis)
+
+	fmt.Println("\n" + i18n.T("chart.burndown"))
+	ColumnChart(os.Stdout, BurnDown(trends), labels, 8)
+}
+
+// BurnDown 根据当前未完成任务数和每个周期的新建、完成数，倒推每个周期结束时未完成的任务数
+func BurnDown(trends *models.ProductivityTrends) []float64 {
+	remaining := make([]float64, len(trends.Periods))
+	open := trends.OpenTasks
+	for i := len(trends.Periods) - 1; i >= 0; i-- {
+		remaining[i] = float64(max(open, 0))
+		open -= trends.Periods[i].Created - trends.Periods[i].Completed
+	}
+	return remaining
+}
+
+// formatValue 整数不显示小数部分
+func formatValue(v float64) string {
+	if v == math.Trunc(v) {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.1f", v)
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// colorAttributes 配置文件中可以使用的颜色和样式名称
+var colorAttributes = map[string]color.Attribute{
+	"black":     color.FgBlack,
+	"red":       color.FgRed,
+	"green":     color.FgGreen,
+	"yellow":    color.FgYellow,
+	"blue":      color.FgBlue,
+	"magenta":   color.FgMagenta,
+	"cyan":      color.FgCyan,
+	"white":     color.FgWhite,
+	"hiblack":   color.FgHiBlack,
+	"hired":     color.FgHiRed,
+	"higreen":   color.FgHiGreen,
+	"hiyellow":  color.FgHiYellow,
+	"hiblue":    color.FgHiBlue,
+	"himagenta": color.FgHiMagenta,
+	"hicyan":    color.FgHiCyan,
+	"hiwhite":   color.FgHiWhite,
+	"bold":      color.Bold,
+	"faint":     color.Faint,
+	"italic":    color.Italic,
+	"underline": color.Underline,
+}
+
+// Palette 可配置的消息颜色，每项为空格分隔的颜色和样式，例如 "green bold"，为空时保持原样
+type Palette struct {
+	Success string
+	Error   string
+	Info    string
+	Warning string
+}
+
+// ParseColor 解析颜色描述，例如 "green bold"、"hiblue underline"
+func ParseColor(spec string) (*color.Color, error) {
+	var attrs []color.Attribute
+	for _, name := range strings.Fields(strings.ToLower(spec)) {
+		attr, ok := colorAttributes[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown color %q", name)
+		}
+		attrs = append(attrs, attr)
+	}
+	if len(attrs) == 0 {
+		return nil, fmt.Errorf("empty color")
+	}
+	return color.New(attrs...), nil
+}
+
+// SetColorMode 设置是否输出颜色：auto 只在终端中使用颜色，always 总是使用，never 从不使用
+func SetColorMode(mode string) error {
+	switch mode {
+	case "", "auto":
+	case "always":
+		color.NoColor = false
+	case "never":
+		color.NoColor = true
+	default:
+		return fmt.Errorf("invalid color mode %q: must be auto, always or never", mode)
+	}
+	return nil
+}
+
+// SetPalette 替换消息颜色，任何一项无效时都不做修改
+func SetPalette(p Palette) error {
+	targets := []struct {
+		spec   string
+		target **color.Color
+	}{
+		{p.Success, &successColor},
+		{p.Error, &errorColor},
+		{p.Info, &infoColor},
+		{p.Warning, &warningColor},
+	}
+
+	parsed := make([]*color.Color, len(targets))
+	for i, t := range targets {
+		if t.spec == "" {
+			continue
+		}
+		c, err := ParseColor(t.spec)
+		if err != nil {
+			return err
+		}
+		parsed[i] = c
+	}
+	for i, t := range targets {
+		if parsed[i] != nil {
+			*t.target = parsed[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+// taskColumn 任务表格中可选的一列，Header 为消息目录中的键
+type taskColumn struct {
+	Column
+	value func(task *models.Task, now time.Time) string
+}
+
+// DefaultTaskColumns 'todo list' 默认显示的列
+var DefaultTaskColumns = []string{"id", "status", "title", "category", "priority", "created"}
+
+// taskColumns 任务表格所有可选的列，键为 --columns 中使用的名称
+var taskColumns = map[string]taskColumn{
+	"id": {Column{Header: "field.id", Align: AlignRight}, func(task *models.Task, now time.Time) string {
+		return fmt.Sprint(task.ID)
+	}},
+	"status": {Column{Header: "field.status"}, func(task *models.Task, now time.Time) string {
+		if task.Status != models.StatusCompleted && task.IsDeferred(now) {
+			return "⏸"
+		}
+		return StatusIcon(task)
+	}},
+	"title": {Column{Header: "field.title", MinWidth: 10, MaxWidth: 50, Flex: true}, func(task *models.Task, now time.Time) string {
+		return task.Title
+	}},
+	"category": {Column{Header: "field.category"}, func(task *models.Task, now time.Time) string {
+		return CategoryText(task.Category)
+	}},
+	"priority": {Column{Header: "field.priority"}, func(task *models.Task, now time.Time) string {
+		return strings.Repeat("!", int(task.Priority))
+	}},
+	"state": {Column{Header: "field.state"}, func(task *models.Task, now time.Time) string {
+		return task.State
+	}},
+	"project": {Column{Header: "field.project", MaxWidth: 20, Flex: true}, func(task *models.Task, now time.Time) string {
+		if task.ProjectID == 0 {
+			return ""
+		}
+		return ProjectName(task.ProjectID)
+	}},
+	"tags": {Column{Header: "field.tags", MaxWidth: 30, Flex: true}, func(task *models.Task, now time.Time) string {
+		if len(task.Tags) == 0 {
+			return ""
+		}
+		return formatTags(task.Tags)
+	}},
+	"due": {Column{Header: "field.due"}, func(task *models.Task, now time.Time) string {
+		if task.DueAt == nil {
+			return ""
+		}
+		return task.DueAt.Format("2006-01-02 15:04")
+	}},
+	"estimate": {Column{Header: "field.estimate", Align: AlignRight}, func(task *models.Task, now time.Time) string {
+		if task.Estimate.IsZero() {
+			return ""
+		}
+		return task.Estimate.String()
+	}},
+	"created": {Column{Header: "field.created"}, func(task *models.Task, now time.Time) string {
+		return task.CreatedAt.Format("2006-01-02 15:04")
+	}},
+	"updated": {Column{Header: "field.updated"}, func(task *models.Task, now time.Time) string {
+		return task.UpdatedAt.Format("2006-01-02 15:04")
+	}},
+}
+
+// TaskColumnNames 所有可选列的名称
+func TaskColumnNames() []string {
+	return []string{"id", "status", "title", "category", "priority", "state", "project", "tags", "due", "estimate", "created", "updated"}
+}
+
+// ParseTaskColumns 解析逗号分隔的列名，例如 "id,title,due"
+func ParseTaskColumns(spec string) ([]string, error) {
+	var names []string
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if _, ok := taskColumns[name]; !ok {
+			return nil, errors.New(i18n.T("column.unknown", name, strings.Join(TaskColumnNames(), ", ")))
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil, errors.New(i18n.T("column.empty", strings.Join(TaskColumnNames(), ", ")))
+	}
+	return names, nil
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+// maxProblemLines 每个检查项最多显示的问题数
+const maxProblemLines = 5
+
+// PrintHealthReport 打印数据库检查报告
+func PrintHealthReport(report *models.HealthReport) {
+	fmt.Println(strings.Repeat("═", 60))
+	infoColor.Println("                   🩺 " + i18n.T("doctor.title"))
+	fmt.Println(strings.Repeat("═", 60))
+	fmt.Println(i18n.T("doctor.database", report.Path, report.SchemaVersion))
+	fmt.Println(strings.Repeat("─", 60))
+
+	for _, check := range report.Checks {
+		// 检查项的名称和修复建议，没有翻译的检查项直接显示名称
+		title, ok := i18n.Lookup("doctor.check." + check.Name)
+		if !ok {
+			title = check.Name
+		}
+		if check.OK() {
+			successColor.Printf("✓ %s\n", title)
+			continue
+		}
+
+		errorColor.Println("✗ " + i18n.T("doctor.problems", title, len(check.Problems)))
+		for i, problem := range check.Problems {
+			if i == maxProblemLines {
+				dimColor.Println("    " + i18n.T("doctor.more", len(check.Problems)-maxProblemLines))
+				break
+			}
+			fmt.Printf("    %s\n", problem)
+		}
+		if advice, ok := i18n.Lookup("doctor.advice." + check.Name); ok {
+			warningColor.Printf("    → %s\n", advice)
+		}
+	}
+
+	fmt.Println(strings.Repeat("═", 60))
+	if report.Healthy() {
+		Success("doctor.healthy")
+	}
+}
+
+// PrintBackups 打印备份列表
+func PrintBackups(backups []models.BackupFile) {
+	if len(backups) == 0 {
+		dimColor.Println(i18n.T("backup.none"))
+		return
+	}
+
+	table := NewTable(
+		Column{Header: i18n.T("backup.column_time")},
+		Column{Header: i18n.T("backup.column_size"), Align: AlignRight},
+		Column{Header: i18n.T("backup.column_kind")},
+		Column{Header: i18n.T("backup.column_file"), Flex: true},
+	)
+	for _, backup := range backups {
+		kind := i18n.T("backup.kind_manual")
+		switch {
+		case strings.HasPrefix(backup.Reason, "pre-v"):
+			kind = i18n.T("backup.kind_pre_migration", strings.TrimPrefix(backup.Reason, "pre-v"))
+		case backup.Reason == models.BackupReasonPreRestore:
+			kind = i18n.T("backup.kind_pre_restore")
+		case backup.Reason == models.BackupReasonPreRepair:
+			kind = i18n.T("backup.kind_pre_repair")
+		case backup.Reason != "":
+			kind = backup.Reason
+		}
+		table.AddRow(backup.CreatedAt.Format("2006-01-02 15:04:05"), formatSize(backup.Size), kind, backup.Path)
+	}
+	table.Render(os.Stdout)
+}
+
+// formatSize 格式化文件大小，例如 12.3 KB
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value := float64(size) / unit
+	for _, suffix := range []string{"KB", "MB", "GB"} {
+		if value < unit {
+			return fmt.Sprintf("%.1f %s", value, suffix)
+		}
+		value /= unit
+	}
+	return fmt.Sprintf("%.1f TB", value)
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+// PrintAccuracyReport 打印估算准确度报告
+func PrintAccuracyReport(report *models.AccuracyReport) {
+	if len(report.Tasks) == 0 {
+		dimColor.Println(i18n.T("estimate.none"))
+		return
+	}
+
+	fmt.Println(strings.Repeat("═", 80))
+	infoColor.Println("                         " + i18n.T("estimate.title"))
+	fmt.Println(strings.Repeat("═", 80))
+	table := NewTable(
+		Column{Header: i18n.T("field.id"), Align: AlignRight},
+		Column{Header: i18n.T("field.title"), MinWidth: 10, MaxWidth: 40, Flex: true},
+		Column{Header: i18n.T("field.estimate"), Align: AlignRight},
+		Column{Header: i18n.T("estimate.column_actual"), Align: AlignRight},
+		Column{Header: i18n.T("estimate.column_ratio"), Align: AlignRight},
+		Column{Header: i18n.T("estimate.column_source")},
+	)
+	for _, item := range report.Tasks {
+		ratio := "-"
+		if item.Ratio > 0 {
+			ratio = fmt.Sprintf("%.2f", item.Ratio)
+		}
+		source := i18n.T("estimate.source_tracked")
+		if item.Source == "elapsed" {
+			source = i18n.T("estimate.source_elapsed")
+		}
+		cells := []string{fmt.Sprint(item.TaskID), item.Title, item.Estimate.String(),
+			models.FormatDuration(item.Actual), ratio, source}
+		switch {
+		case item.Ratio > 1.25:
+			table.addRow(errorColor, cells)
+		case item.Ratio > 0 && item.Ratio >= 0.75:
+			table.addRow(successColor, cells)
+		default:
+			table.AddRow(cells...)
+		}
+	}
+	table.Render(os.Stdout)
+
+	fmt.Println(strings.Repeat("─", 80))
+	if report.DurationTasks > 0 {
+		fmt.Println(i18n.T("estimate.duration_summary", report.DurationTasks, report.MeanRatio, report.WithinRange))
+		switch {
+		case report.MeanRatio > 1.25:
+			dimColor.Println(i18n.T("estimate.optimistic"))
+		case report.MeanRatio < 0.75:
+			dimColor.Println(i18n.T("estimate.pessimistic"))
+		}
+	}
+	if report.PointTasks > 0 {
+		fmt.Println(i18n.T("estimate.points_summary", report.PointTasks, report.HoursPerPoint))
+	}
+	fmt.Println(strings.Repeat("═", 80))
+}
+
+// formatEffort 格式化剩余工作量，例如 "12h30m + 8pt (3 个任务，2 个未估算)"
+func formatEffort(bucket models.EffortBucket) string {
+	var parts []string
+	if bucket.Remaining > 0 {
+		parts = append(parts, models.FormatDuration(bucket.Remaining))
+	}
+	if bucket.Points > 0 {
+		parts = append(parts, strconv.FormatFloat(bucket.Points, 'f', -1, 64)+"pt")
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "0m")
+	}
+
+	detail := i18n.T("estimate.tasks", bucket.Tasks)
+	if bucket.Unestimated > 0 {
+		detail += i18n.T("estimate.unestimated", bucket.Unestimated)
+	}
+	return fmt.Sprintf("%s (%s)", strings.Join(parts, " + "), detail)
+}
+
+// printEffortBuckets 打印统计中的一组剩余工作量
+func printEffortBuckets(title string, buckets []models.EffortBucket) {
+	if len(buckets) == 0 {
+		return
+	}
+	fmt.Printf("  %s:\n", title)
+	for _, bucket := range buckets {
+		fmt.Printf("    • %s: %s\n", bucket.Label, formatEffort(bucket))
+	}
+}
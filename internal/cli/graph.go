@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+// WriteDOT 以 Graphviz DOT 格式输出依赖图，边从前置任务指向依赖它的任务
+func WriteDOT(w io.Writer, tasks map[int64]*models.Task, nodes []int64, graph models.DependencyGraph) error {
+	var b strings.Builder
+	b.WriteString("digraph tasks {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=rounded];\n")
+
+	for _, id := range nodes {
+		task := tasks[id]
+		if task == nil {
+			continue
+		}
+		attrs := fmt.Sprintf("label=%q", fmt.Sprintf("#%d %s", task.ID, task.Title))
+		switch {
+		case task.Status == models.StatusCompleted:
+			attrs += `, style="rounded,filled", fillcolor="#d4edda", fontcolor="#555555"`
+		case task.IsBlocked():
+			attrs += `, color="#cc0000"`
+		}
+		fmt.Fprintf(&b, "  t%d [%s];\n", id, attrs)
+	}
+
+	for _, id := range nodes {
+		for _, dep := range graph[id] {
+			if tasks[id] == nil || tasks[dep] == nil {
+				continue
+			}
+			fmt.Fprintf(&b, "  t%d -> t%d;\n", dep, id)
+		}
+	}
+
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMermaid 以 Mermaid flowchart 格式输出依赖图
+func WriteMermaid(w io.Writer, tasks map[int64]*models.Task, nodes []int64, graph models.DependencyGraph) error {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	var done, blocked []string
+	for _, id := range nodes {
+		task := tasks[id]
+		if task == nil {
+			continue
+		}
+		fmt.Fprintf(&b, "  t%d[\"#%d %s\"]\n", id, task.ID, mermaidEscape(task.Title))
+		switch {
+		case task.Status == models.StatusCompleted:
+			done = append(done, fmt.Sprintf("t%d", id))
+		case task.IsBlocked():
+			blocked = append(blocked, fmt.Sprintf("t%d", id))
+		}
+	}
+
+	for _, id := range nodes {
+		for _, dep := range graph[id] {
+			if tasks[id] == nil || tasks[dep] == nil {
+				continue
+			}
+			fmt.Fprintf(&b, "  t%d --> t%d\n", dep, id)
+		}
+	}
+
+	b.WriteString("  classDef done fill:#d4edda,color:#555555\n")
+	b.WriteString("  classDef blocked stroke:#cc0000\n")
+	if len(done) > 0 {
+		fmt.Fprintf(&b, "  class %s done\n", strings.Join(done, ","))
+	}
+	if len(blocked) > 0 {
+		fmt.Fprintf(&b, "  class %s blocked\n", strings.Join(blocked, ","))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// mermaidEscape 转义 Mermaid 标签中的双引号
+func mermaidEscape(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
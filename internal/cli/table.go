@@ -0,0 +1,199 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/clipperhouse/displaywidth"
+	"github.com/clipperhouse/uax29/v2/graphemes"
+	"github.com/fatih/color"
+)
+
+const (
+	// columnGap 列之间的空格数
+	columnGap = 1
+	ellipsis  = "…"
+)
+
+// Align 列的对齐方式
+type Align int
+
+const (
+	AlignLeft Align = iota
+	AlignRight
+)
+
+// Column 表格的一列
+type Column struct {
+	Header string
+	// Width 固定宽度，0 表示按内容自适应
+	Width int
+	// MinWidth/MaxWidth 自适应宽度的范围，MaxWidth 为 0 表示不限
+	MinWidth int
+	MaxWidth int
+	// Flex 为 true 时表格超出总宽度会先压缩这一列，例如标题
+	Flex  bool
+	Align Align
+}
+
+type tableRow struct {
+	cells []string
+	color *color.Color
+}
+
+// Table 按显示宽度对齐的表格。中文、emoji 等宽字符占两列，
+// 超长的内容在字素边界截断并以 "…" 结尾，不会截断多字节字符
+type Table struct {
+	Columns []Column
+	// Width 表格的最大总宽度，0 表示终端宽度
+	Width int
+	rows  []tableRow
+}
+
+// NewTable 创建表格
+func NewTable(columns ...Column) *Table {
+	return &Table{Columns: columns}
+}
+
+// AddRow 添加一行，多余的单元格会被忽略
+func (t *Table) AddRow(cells ...string) {
+	t.addRow(nil, cells)
+}
+
+// addRow 添加一行，c 不为 nil 时整行使用该颜色
+func (t *Table) addRow(c *color.Color, cells []string) {
+	t.rows = append(t.rows, tableRow{cells: cells, color: c})
+}
+
+// Widths 计算每一列的显示宽度
+func (t *Table) Widths() []int {
+	widths := make([]int, len(t.Columns))
+	total := columnGap * (len(t.Columns) - 1)
+	for i, col := range t.Columns {
+		if col.Width > 0 {
+			widths[i] = col.Width
+		} else {
+			widths[i] = displaywidth.String(col.Header)
+			for _, row := range t.rows {
+				if i < len(row.cells) {
+					widths[i] = max(widths[i], displaywidth.String(row.cells[i]))
+				}
+			}
+			widths[i] = max(widths[i], col.MinWidth)
+			if col.MaxWidth > 0 {
+				widths[i] = min(widths[i], col.MaxWidth)
+			}
+		}
+		total += widths[i]
+	}
+
+	limit := t.Width
+	if limit <= 0 {
+		limit = terminalWidth()
+	}
+	// 超出总宽度时压缩 Flex 列，但不小于表头和 MinWidth
+	for i, col := range t.Columns {
+		if total <= limit {
+			break
+		}
+		if !col.Flex {
+			continue
+		}
+		floor := max(col.MinWidth, displaywidth.String(col.Header), 1)
+		shrink := min(total-limit, widths[i]-floor)
+		if shrink > 0 {
+			widths[i] -= shrink
+			total -= shrink
+		}
+	}
+	return widths
+}
+
+// Render 输出表头、分隔线和所有行
+func (t *Table) Render(w io.Writer) {
+	widths := t.Widths()
+
+	headers := make([]string, len(t.Columns))
+	for i, col := range t.Columns {
+		headers[i] = col.Header
+	}
+	infoColor.Fprintln(w, t.line(headers, widths))
+	fmt.Fprintln(w, strings.Repeat("─", totalWidth(widths)))
+
+	for _, row := range t.rows {
+		line := t.line(row.cells, widths)
+		if row.color != nil {
+			row.color.Fprintln(w, line)
+		} else {
+			fmt.Fprintln(w, line)
+		}
+	}
+}
+
+// TotalWidth 表格的总显示宽度，包括列间距
+func (t *Table) TotalWidth() int {
+	return totalWidth(t.Widths())
+}
+
+func totalWidth(widths []int) int {
+	total := columnGap * (len(widths) - 1)
+	for _, width := range widths {
+		total += width
+	}
+	return total
+}
+
+// line 按列宽对齐一行，去掉行尾空格
+func (t *Table) line(cells []string, widths []int) string {
+	parts := make([]string, len(t.Columns))
+	for i, col := range t.Columns {
+		cell := ""
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		if col.Align == AlignRight {
+			parts[i] = padLeft(truncate(cell, widths[i]), widths[i])
+		} else {
+			parts[i] = fit(cell, widths[i])
+		}
+	}
+	return strings.TrimRight(strings.Join(parts, strings.Repeat(" ", columnGap)), " ")
+}
+
+// truncate 按显示宽度截断到 width 以内，超长时以 "…" 结尾。
+// 按字素截断，组合字符、emoji 序列不会被拆开
+func truncate(s string, width int) string {
+	if displaywidth.String(s) <= width {
+		return s
+	}
+	if width <= 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	used := 0
+	limit := width - displaywidth.String(ellipsis)
+	iter := graphemes.FromString(s)
+	for iter.Next() {
+		g := iter.Value()
+		w := displaywidth.String(g)
+		if used+w > limit {
+			break
+		}
+		b.WriteString(g)
+		used += w
+	}
+	return b.String() + ellipsis
+}
+
+// fit 按显示宽度截断并在右侧补齐到 width，中文等宽字符占两列
+func fit(s string, width int) string {
+	s = truncate(s, width)
+	return s + strings.Repeat(" ", max(width-displaywidth.String(s), 0))
+}
+
+// padLeft 在左侧补齐到 width，用于右对齐的数字列
+func padLeft(s string, width int) string {
+	return strings.Repeat(" ", max(width-displaywidth.String(s), 0)) + s
+}
@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/clipperhouse/displaywidth"
+	"github.com/fatih/color"
+)
+
+// update 重新生成 testdata 中的 golden 文件：go test ./internal/cli -update
+var update = flag.Bool("update", false, "update golden files")
+
+// goldenNow 固定时钟，表格中的时间和延后状态都基于它
+var goldenNow = time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC)
+
+// goldenTasks 中英文、emoji、ZWJ 序列和组合字符混合的任务
+func goldenTasks() []*models.Task {
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 10, day, hour, 30, 0, 0, time.UTC)
+	}
+	due := at(20, 18)
+	deferred := goldenNow.Add(48 * time.Hour)
+	completed := at(13, 9)
+
+	return []*models.Task{
+		{
+			ID: 1, Title: "Write quarterly report for the finance team before Friday", Status: models.StatusPending,
+			Category: models.CategoryWork, Priority: models.PriorityUrgent, CreatedAt: at(1, 9), UpdatedAt: at(2, 9),
+			DueAt: &due, Tags: []string{"report", "q4"}, ProjectID: 1,
+			Estimate: models.Estimate{Duration: 90 * time.Minute},
+		},
+		{
+			ID: 2, Title: "准备下周一的产品演示和发布会材料", Status: models.StatusPending,
+			Category: models.CategoryWork, Priority: models.PriorityHigh, CreatedAt: at(2, 14), UpdatedAt: at(3, 14),
+			Tags: []string{"发布", "演示"}, ProjectID: 2, State: "review",
+		},
+		{
+			ID: 3, Title: "🎉 家庭聚会 👨‍👩‍👧‍👦 买蛋糕 🎂 and balloons 🎈", Status: models.StatusPending,
+			Category: models.CategoryLife, Priority: models.PriorityMedium, CreatedAt: at(3, 20), UpdatedAt: at(3, 20),
+			DeferUntil: &deferred, Tags: []string{"🎂"},
+		},
+		{
+			ID: 4, Title: "Read 《深入理解计算机系统》 chapter 3", Status: models.StatusCompleted,
+			Category: models.CategoryStudy, Priority: models.PriorityLow, CreatedAt: at(4, 8), UpdatedAt: at(13, 9),
+			CompletedAt: &completed, Estimate: models.Estimate{Points: 3},
+		},
+		{
+			ID: 12, Title: "Café 🇨🇳🇺🇸 flags and combining é 👩🏽‍💻 emoji", Status: models.StatusPending,
+			Category: models.CategoryOther, Priority: models.PriorityMedium, CreatedAt: at(5, 11), UpdatedAt: at(5, 11),
+			BlockedBy: []int64{2},
+		},
+	}
+}
+
+func TestTaskColumnsGolden(t *testing.T) {
+	color.NoColor = true
+	ProjectName = func(id int64) string {
+		return map[int64]string{1: "Finance", 2: "产品发布 Launch"}[id]
+	}
+
+	tests := []struct {
+		name    string
+		columns []string
+		width   int
+		// fits 为 false 时固定宽度的列已经超出 width，只能把标题等 Flex 列压缩到最小
+		fits bool
+	}{
+		{"default_w120", DefaultTaskColumns, 120, true},
+		{"default_w80", DefaultTaskColumns, 80, true},
+		{"default_w60", DefaultTaskColumns, 60, true},
+		{"default_w40", DefaultTaskColumns, 40, false},
+		{"all_w160", TaskColumnNames(), 160, true},
+		{"all_w100", TaskColumnNames(), 100, false},
+		{"title_tags_w30", []string{"id", "title", "tags"}, 30, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writeTaskColumns(&buf, goldenTasks(), tt.columns, goldenNow, tt.width)
+			checkGolden(t, "task_columns_"+tt.name, buf.Bytes())
+
+			// 表格的每一行都不超过边框的宽度，放得下时边框不超过 width；最后一行是合计
+			lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+			frame := displaywidth.String(lines[0])
+			if tt.fits && frame > tt.width {
+				t.Errorf("table is %d columns wide, limit %d", frame, tt.width)
+			}
+			for _, line := range lines[:len(lines)-1] {
+				if w := displaywidth.String(line); w > frame {
+					t.Errorf("line is %d columns wide, frame %d: %q", w, frame, line)
+				}
+			}
+		})
+	}
+}
+
+func TestTaskColumnsEmpty(t *testing.T) {
+	color.NoColor = true
+	var buf bytes.Buffer
+	writeTaskColumns(&buf, nil, DefaultTaskColumns, goldenNow, 80)
+	checkGolden(t, "task_columns_empty", buf.Bytes())
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		input string
+		width int
+		want  string
+	}{
+		{"hello", 10, "hello"},
+		{"hello world", 8, "hello w…"},
+		{"中文标题很长", 7, "中文标…"},
+		{"中文标题很长", 8, "中文标…"},
+		{"中文标题很长", 12, "中文标题很长"},
+		{"ab中文", 4, "ab…"},
+		{"👨‍👩‍👧‍👦 family", 4, "👨‍👩‍👧‍👦 …"},
+		{"👨‍👩‍👧‍👦👨‍👩‍👧‍👦", 3, "👨‍👩‍👧‍👦…"},
+		{"🇨🇳🇺🇸🇯🇵🇬🇧", 3, "🇨🇳🇺🇸…"},
+		{"éééé", 3, "éé…"},
+		{"abc", 0, ""},
+		{"abc", 1, "…"},
+	}
+	for _, tt := range tests {
+		got := truncate(tt.input, tt.width)
+		if got != tt.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.input, tt.width, got, tt.want)
+		}
+		if w := displaywidth.String(got); w > tt.width {
+			t.Errorf("truncate(%q, %d) is %d columns wide", tt.input, tt.width, w)
+		}
+	}
+}
+
+func TestFitPadsToDisplayWidth(t *testing.T) {
+	for _, input := range []string{"abc", "中文", "👨‍👩‍👧‍👦", "🇨🇳 flag", "é"} {
+		for width := 1; width <= 8; width++ {
+			got := fit(input, width)
+			if w := displaywidth.String(got); w != width && !(width == 1 && w == 0) {
+				t.Errorf("fit(%q, %d) is %d columns wide: %q", input, width, w, got)
+			}
+		}
+	}
+}
+
+// checkGolden 与 testdata/<name>.golden 比较，-update 时重新写入
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s (run with -update to accept):\n--- got ---\n%s\n--- want ---\n%s", path, got, want)
+	}
+}
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+// recentDays 统计中按天显示的计时天数
+const recentDays = 7
+
+// PrintRunningTimer 打印正在运行的计时器
+func PrintRunningTimer(entry *models.TimeEntry, task *models.Task) {
+	title := i18n.T("timer.task_missing")
+	if task != nil {
+		title = task.Title
+	}
+	infoColor.Println(i18n.T("timer.running_task", entry.TaskID, title))
+	fmt.Println(i18n.T("timer.running_since",
+		entry.StartedAt.Format("2006-01-02 15:04"), models.FormatDuration(entry.Duration(time.Now()))))
+}
+
+// PrintTimeEntries 打印任务的计时记录
+func PrintTimeEntries(entries []*models.TimeEntry) {
+	if len(entries) == 0 {
+		dimColor.Println(i18n.T("timer.no_entries"))
+		return
+	}
+
+	now := time.Now()
+	var total time.Duration
+	fmt.Println(strings.Repeat("═", 60))
+	for _, entry := range entries {
+		d := entry.Duration(now)
+		total += d
+
+		end := i18n.T("timer.running")
+		if entry.EndedAt != nil {
+			end = entry.EndedAt.Format("15:04")
+		}
+		line := fmt.Sprintf("[%d] %s - %s  %6s", entry.ID, entry.StartedAt.Format("2006-01-02 15:04"), end, models.FormatDuration(d))
+		if entry.Note != "" {
+			line += "  " + entry.Note
+		}
+		if entry.IsRunning() {
+			infoColor.Println(line)
+		} else {
+			fmt.Println(line)
+		}
+	}
+	fmt.Println(strings.Repeat("─", 60))
+	fmt.Println(i18n.T("timer.entries_total", models.FormatDuration(total)))
+	fmt.Println(strings.Repeat("═", 60))
+}
+
+// PrintTrackedTime 在任务详情后打印累计计时
+func PrintTrackedTime(entries []*models.TimeEntry) {
+	if len(entries) == 0 {
+		return
+	}
+
+	now := time.Now()
+	var total time.Duration
+	running := false
+	for _, entry := range entries {
+		total += entry.Duration(now)
+		running = running || entry.IsRunning()
+	}
+
+	line := i18n.T("timer.tracked", models.FormatDuration(total), len(entries))
+	if running {
+		infoColor.Println(line + i18n.T("timer.tracked_running"))
+	} else {
+		fmt.Println(line)
+	}
+}
+
+// printTimeBuckets 打印统计中的一组计时汇总
+func printTimeBuckets(title string, buckets []models.TimeBucket) {
+	if len(buckets) == 0 {
+		return
+	}
+	fmt.Printf("  %s:\n", title)
+	for _, bucket := range buckets {
+		fmt.Printf("    • %s: %s\n", bucket.Label, models.FormatDuration(bucket.Duration))
+	}
+}
+
+// PrintFocusStats 在任务详情后打印番茄钟统计
+func PrintFocusStats(stats *models.FocusStats) {
+	if stats.Completed+stats.Abandoned == 0 {
+		return
+	}
+
+	line := i18n.T("stats.focus", stats.Completed)
+	if stats.Abandoned > 0 {
+		line += i18n.T("stats.focus_abandoned", stats.Abandoned)
+	}
+	line += i18n.T("timer.focus_interruptions", stats.Interruptions)
+	fmt.Println(line)
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+// trendBarWidth 趋势表中柱状条的最大宽度
+const trendBarWidth = 20
+
+// PrintTrends 打印生产力趋势
+func PrintTrends(trends *models.ProductivityTrends) {
+	fmt.Println(strings.Repeat("═", 60))
+	infoColor.Println("            " + i18n.T("trends.title",
+		trends.From.Format("2006-01-02"), trends.To.Format("2006-01-02")))
+	fmt.Println(strings.Repeat("═", 60))
+
+	fmt.Println(i18n.T("trends.summary", trends.Created, trends.Completed) + "\n")
+
+	peak := 1
+	for _, period := range trends.Periods {
+		peak = max(peak, period.Created, period.Completed)
+	}
+	fmt.Printf("%s %s %s ", fit(i18n.T("trends.column_period"), 10), fit(i18n.T("trends.created"), 5), fit(i18n.T("trends.completed"), 5))
+	dimColor.Print("░ " + i18n.T("trends.created") + " ")
+	successColor.Println("█ " + i18n.T("trends.completed"))
+	for _, period := range trends.Periods {
+		fmt.Printf("%s %-5d %-5d ", fit(periodLabel(trends.Granularity, period), 10), period.Created, period.Completed)
+		dimColor.Print(bar("░", period.Created, peak))
+		fmt.Print(" ")
+		successColor.Println(bar("█", period.Completed, peak))
+	}
+
+	if len(trends.LeadTimeByCategory) > 0 {
+		fmt.Println("\n" + i18n.T("trends.lead_by_category"))
+		for _, lead := range trends.LeadTimeByCategory {
+			fmt.Println(i18n.T("trends.lead_line", CategoryText(models.TaskCategory(lead.Key)), formatLeadTime(lead), lead.Count))
+		}
+		fmt.Println("\n" + i18n.T("trends.lead_by_priority"))
+		for _, lead := range trends.LeadTimeByPriority {
+			priority, _ := strconv.Atoi(lead.Key)
+			fmt.Println(i18n.T("trends.lead_line", getPriorityText(models.Priority(priority)), formatLeadTime(lead), lead.Count))
+		}
+	}
+
+	fmt.Print("\n" + i18n.T("trends.streak", trends.Streak.Current, trends.Streak.Longest))
+	if trends.Streak.LongestEnd != nil && trends.Streak.Longest > 1 {
+		fmt.Print(i18n.T("trends.streak_end", trends.Streak.LongestEnd.Format("2006-01-02")))
+	}
+	fmt.Println()
+
+	if trends.OpenTasks > 0 {
+		fmt.Println("\n" + i18n.T("trends.aging", trends.OpenTasks))
+		for _, bucket := range trends.Aging {
+			line := fmt.Sprintf("  • %s: %d", agingLabel(bucket), bucket.Count)
+			if bucket.MaxDays == 0 && bucket.Count > 0 {
+				errorColor.Println(line)
+			} else {
+				fmt.Println(line)
+			}
+		}
+	}
+
+	fmt.Println(strings.Repeat("═", 60))
+}
+
+// bar 按 peak 缩放的柱状条
+func bar(glyph string, value, peak int) string {
+	n := value * trendBarWidth / peak
+	if value > 0 && n == 0 {
+		n = 1
+	}
+	return strings.Repeat(glyph, n)
+}
+
+// formatLeadTime 不足一天时显示时长，否则显示天数
+func formatLeadTime(lead models.LeadTime) string {
+	if lead.Days < 1 {
+		return models.FormatDuration(lead.Average)
+	}
+	return i18n.T("trends.days", lead.Days)
+}
+
+// periodLabel 趋势中周期的显示名称，例如 "11-03" 或 "11-03 周"
+func periodLabel(granularity models.Granularity, period models.TrendPeriod) string {
+	if granularity == models.GranularityWeek {
+		return i18n.T("trends.week", period.Start.Format("01-02"))
+	}
+	return period.Start.Format("01-02")
+}
+
+// agingLabel 存在时长分组的显示名称，例如 "7 天内"、"8-30 天"、"90 天以上"
+func agingLabel(bucket models.AgingBucket) string {
+	switch {
+	case bucket.MaxDays == 0:
+		return i18n.T("trends.aging_over", bucket.MinDays-1)
+	case bucket.MinDays == 0:
+		return i18n.T("trends.aging_within", bucket.MaxDays)
+	default:
+		return i18n.T("trends.aging_range", bucket.MinDays, bucket.MaxDays)
+	}
+}
@@ -1,9 +1,14 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"strings"
+	"time"
 
+	"github.com/WHITE13452/toDoList/internal/i18n"
 	"github.com/WHITE13452/toDoList/internal/models"
 	"github.com/fatih/color"
 )
@@ -13,8 +18,16 @@ var (
 	errorColor   = color.New(color.FgRed, color.Bold)
 	infoColor    = color.New(color.FgCyan)
 	dimColor     = color.New(color.Faint)
+	// warningColor 和 highlightColor 用于月历：未完成的日期和今天
+	warningColor   = color.New(color.FgYellow)
+	highlightColor = color.New(color.ReverseVideo)
 )
 
+// ProjectName 根据项目 ID 返回项目名称，用于显示任务详情，由命令行入口设置
+var ProjectName = func(id int64) string {
+	return fmt.Sprintf("#%d", id)
+}
+
 // PrintSuccess 打印成功消息
 func PrintSuccess(format string, args ...interface{}) {
 	successColor.Printf("✓ "+format+"\n", args...)
@@ -30,118 +43,267 @@ func PrintInfo(format string, args ...interface{}) {
 	infoColor.Printf(format+"\n", args...)
 }
 
-// PrintTask 打印单个任务
-func PrintTask(task *models.Task, detailed bool) {
-	statusIcon := "○"
-	if task.Status == models.StatusCompleted {
-		statusIcon = "✓"
+// Success 打印消息目录中 key 对应的成功消息，args 按 i18n.T 格式化。
+// 翻译后的消息不再作为格式串使用，任务标题等参数中的 % 会原样输出
+func Success(key string, args ...interface{}) {
+	successColor.Println("✓ " + i18n.T(key, args...))
+}
+
+// Error 打印消息目录中 key 对应的错误消息
+func Error(key string, args ...interface{}) {
+	errorColor.Println("✗ " + i18n.T(key, args...))
+}
+
+// Info 打印消息目录中 key 对应的信息
+func Info(key string, args ...interface{}) {
+	infoColor.Println(i18n.T(key, args...))
+}
+
+// DetailField 任务详情中的一行
+type DetailField struct {
+	Label string
+	Value string
+	// Alert 为 true 时需要醒目显示，例如已逾期
+	Alert bool
+}
+
+// PrintJSON 以缩进的 JSON 格式输出 v，供脚本使用
+func PrintJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// StatusIcon 任务的状态图标
+func StatusIcon(task *models.Task) string {
+	switch {
+	case task.Status == models.StatusCompleted:
+		return "✓"
+	case task.IsBlocked():
+		return "⊘"
+	case task.State != "":
+		return "◐"
+	default:
+		return "○"
+	}
+}
+
+// TaskDetails 任务详情的各个字段，描述除外。'todo show' 和 TUI 的详情面板共用
+func TaskDetails(task *models.Task) []DetailField {
+	now := time.Now()
+	status := string(task.Status)
+	if task.State != "" {
+		status = task.State
 	}
 
+	fields := []DetailField{
+		{Label: i18n.T("field.id"), Value: fmt.Sprint(task.ID)},
+		{Label: i18n.T("field.title"), Value: task.Title},
+		{Label: i18n.T("field.status"), Value: StatusIcon(task) + " " + status},
+		{Label: i18n.T("field.category"), Value: CategoryText(task.Category)},
+	}
+	if task.ProjectID != 0 {
+		fields = append(fields, DetailField{Label: i18n.T("field.project"), Value: ProjectName(task.ProjectID)})
+	}
+	fields = append(fields,
+		DetailField{Label: i18n.T("field.priority"), Value: getPriorityText(task.Priority)},
+		DetailField{Label: i18n.T("field.created"), Value: task.CreatedAt.Format("2006-01-02 15:04:05")},
+		DetailField{Label: i18n.T("field.updated"), Value: task.UpdatedAt.Format("2006-01-02 15:04:05")},
+	)
+	if task.CompletedAt != nil {
+		fields = append(fields, DetailField{Label: i18n.T("field.completed"), Value: task.CompletedAt.Format("2006-01-02 15:04:05")})
+	}
+	if task.IsDeferred(now) {
+		fields = append(fields, DetailField{Label: i18n.T("field.deferred"), Value: task.DeferUntil.Format("2006-01-02 15:04")})
+	}
+	if task.DueAt != nil {
+		if task.IsOverdue(now) {
+			fields = append(fields, DetailField{Label: i18n.T("field.due"), Value: task.DueAt.Format("2006-01-02 15:04") + i18n.T("task.overdue"), Alert: true})
+		} else {
+			fields = append(fields, DetailField{Label: i18n.T("field.due"), Value: task.DueAt.Format("2006-01-02 15:04")})
+		}
+	}
+	if len(task.Tags) > 0 {
+		fields = append(fields, DetailField{Label: i18n.T("field.tags"), Value: formatTags(task.Tags)})
+	}
+	if !task.Estimate.IsZero() {
+		fields = append(fields, DetailField{Label: i18n.T("field.estimate"), Value: task.Estimate.String()})
+	}
+	if task.IsBlocked() {
+		fields = append(fields, DetailField{Label: i18n.T("field.blocked_by"), Value: FormatTaskIDs(task.BlockedBy), Alert: true})
+	}
+	return fields
+}
+
+// PrintTask 打印单个任务
+func PrintTask(task *models.Task, detailed bool) {
+	statusIcon := StatusIcon(task)
 	priorityText := getPriorityText(task.Priority)
 
 	if detailed {
 		fmt.Println(strings.Repeat("─", 60))
-		fmt.Printf("ID: %d\n", task.ID)
-		fmt.Printf("标题: %s\n", task.Title)
-		fmt.Printf("状态: %s %s\n", statusIcon, task.Status)
-		fmt.Printf("分类: %s\n", task.Category)
-		fmt.Printf("优先级: %s\n", priorityText)
-		fmt.Printf("创建时间: %s\n", task.CreatedAt.Format("2006-01-02 15:04:05"))
-		fmt.Printf("更新时间: %s\n", task.UpdatedAt.Format("2006-01-02 15:04:05"))
-		if task.CompletedAt != nil {
-			fmt.Printf("完成时间: %s\n", task.CompletedAt.Format("2006-01-02 15:04:05"))
+		for _, field := range TaskDetails(task) {
+			if field.Alert {
+				errorColor.Printf("%s: %s\n", field.Label, field.Value)
+			} else {
+				fmt.Printf("%s: %s\n", field.Label, field.Value)
+			}
 		}
 		if task.Description != "" {
-			fmt.Printf("\n描述:\n%s\n", task.Description)
+			fmt.Printf("\n%s:\n%s\n", i18n.T("field.description"), task.Description)
 		}
 		fmt.Println(strings.Repeat("─", 60))
 	} else {
+		tags := ""
+		if len(task.Tags) > 0 {
+			tags = " " + formatTags(task.Tags)
+		}
 		if task.Status == models.StatusCompleted {
-			successColor.Printf("[%d] %s %s (%s, %s)\n",
-				task.ID, statusIcon, task.Title, task.Category, priorityText)
+			successColor.Printf("[%d] %s %s (%s, %s)%s\n",
+				task.ID, statusIcon, task.Title, CategoryText(task.Category), priorityText, tags)
 		} else {
-			fmt.Printf("[%d] %s %s (%s, %s)\n",
-				task.ID, statusIcon, task.Title, task.Category, priorityText)
+			fmt.Printf("[%d] %s %s (%s, %s)%s\n",
+				task.ID, statusIcon, task.Title, CategoryText(task.Category), priorityText, tags)
 		}
 	}
 }
 
-// PrintTaskTable 以表格形式打印任务列表
+// PrintParsedTask 打印快速添加语法的解析结果，供用户确认
+func PrintParsedTask(task *models.Task) {
+	dimColor.Println(i18n.T("task.parsed"))
+	dimColor.Printf("  %s: %s\n", i18n.T("field.title"), task.Title)
+	dimColor.Printf("  %s: %s  %s: %s\n", i18n.T("field.category"), CategoryText(task.Category),
+		i18n.T("field.priority"), getPriorityText(task.Priority))
+	if task.ProjectID != 0 {
+		dimColor.Printf("  %s: %s\n", i18n.T("field.project"), ProjectName(task.ProjectID))
+	}
+	if len(task.Tags) > 0 {
+		dimColor.Printf("  %s: %s\n", i18n.T("field.tags"), formatTags(task.Tags))
+	}
+	if task.DueAt != nil {
+		dimColor.Printf("  %s: %s\n", i18n.T("field.due_short"), task.DueAt.Format("2006-01-02 15:04 (Mon)"))
+	}
+	if task.DeferUntil != nil {
+		dimColor.Printf("  %s: %s\n", i18n.T("field.deferred"), task.DeferUntil.Format("2006-01-02 15:04 (Mon)"))
+	}
+}
+
+// PrintTaskTable 以表格形式打印任务列表，使用默认的列
 func PrintTaskTable(tasks []*models.Task) {
+	PrintTaskColumns(tasks, DefaultTaskColumns)
+}
+
+// PrintTaskColumns 以表格形式打印任务列表，只显示 columns 中的列，列名见 TaskColumnNames
+func PrintTaskColumns(tasks []*models.Task, columns []string) {
+	writeTaskColumns(os.Stdout, tasks, columns, time.Now(), 0)
+}
+
+// writeTaskColumns 向 w 输出任务表格，width 为 0 时使用终端宽度
+func writeTaskColumns(w io.Writer, tasks []*models.Task, columns []string, now time.Time, width int) {
 	if len(tasks) == 0 {
-		dimColor.Println("暂无任务")
+		dimColor.Fprintln(w, i18n.T("task.none"))
 		return
 	}
 
-	// 打印表头
-	fmt.Println(strings.Repeat("═", 80))
-	fmt.Printf("%-6s %-6s %-32s %-10s %-8s %-16s\n",
-		"ID", "状态", "标题", "分类", "优先级", "创建时间")
-	fmt.Println(strings.Repeat("─", 80))
+	selected := make([]taskColumn, 0, len(columns))
+	table := NewTable()
+	table.Width = width
+	for _, name := range columns {
+		if col, ok := taskColumns[name]; ok {
+			selected = append(selected, col)
+			column := col.Column
+			column.Header = i18n.T(column.Header)
+			table.Columns = append(table.Columns, column)
+		}
+	}
 
-	// 打印任务
+	blocked := 0
 	for _, task := range tasks {
-		statusIcon := "○"
-		if task.Status == models.StatusCompleted {
-			statusIcon = "✓"
+		if task.IsBlocked() {
+			blocked++
 		}
 
-		priorityStr := strings.Repeat("!", int(task.Priority))
-
-		// 截断长标题
-		title := task.Title
-		if len(title) > 30 {
-			title = title[:27] + "..."
+		cells := make([]string, len(selected))
+		for i, col := range selected {
+			cells[i] = col.value(task, now)
 		}
-
 		if task.Status == models.StatusCompleted {
-			successColor.Printf("%-6d %-6s %-32s %-10s %-8s %-16s\n",
-				task.ID, statusIcon, title, task.Category, priorityStr,
-				task.CreatedAt.Format("2006-01-02 15:04"))
+			table.addRow(successColor, cells)
 		} else {
-			fmt.Printf("%-6d %-6s %-32s %-10s %-8s %-16s\n",
-				task.ID, statusIcon, title, task.Category, priorityStr,
-				task.CreatedAt.Format("2006-01-02 15:04"))
+			table.AddRow(cells...)
 		}
 	}
 
-	fmt.Println(strings.Repeat("═", 80))
-	dimColor.Printf("总计: %d 个任务\n", len(tasks))
+	frame := strings.Repeat("═", table.TotalWidth())
+	fmt.Fprintln(w, frame)
+	table.Render(w)
+	fmt.Fprintln(w, frame)
+	dimColor.Fprint(w, i18n.T("task.total", len(tasks)))
+	if blocked > 0 {
+		dimColor.Fprint(w, i18n.T("task.blocked", blocked))
+	}
+	fmt.Fprintln(w)
 }
 
 // PrintStatistics 打印统计信息
 func PrintStatistics(stats *models.Statistics) {
 	fmt.Println(strings.Repeat("═", 60))
-	infoColor.Println("                    📊 统计信息")
+	infoColor.Println("                    " + i18n.T("stats.title"))
 	fmt.Println(strings.Repeat("═", 60))
 
-	fmt.Printf("📋 总任务数: %d\n", stats.Total)
-	successColor.Printf("✓ 已完成: %d\n", stats.Completed)
-	fmt.Printf("○ 待完成: %d\n", stats.Pending)
-	fmt.Printf("📈 完成率: %.1f%%\n", stats.CompletionRate)
+	fmt.Println(i18n.T("stats.total", stats.Total))
+	successColor.Println(i18n.T("stats.completed", stats.Completed))
+	fmt.Println(i18n.T("stats.pending", stats.Pending))
+	fmt.Println(i18n.T("stats.rate", stats.CompletionRate))
+
+	if len(stats.ByState) > 0 {
+		fmt.Println("\n" + i18n.T("stats.by_state"))
+		for _, state := range stats.ByState {
+			fmt.Printf("  • %s: %d\n", StateLabel(models.WorkflowState{Name: state.State, Label: state.Label}), state.Count)
+		}
+	}
 
 	if len(stats.ByCategory) > 0 {
-		fmt.Println("\n📁 按分类统计:")
+		fmt.Println("\n" + i18n.T("stats.by_category"))
 		for cat, count := range stats.ByCategory {
-			fmt.Printf("  • %s: %d\n", cat, count)
+			fmt.Printf("  • %s: %d\n", CategoryText(cat), count)
 		}
 	}
 
 	if len(stats.ByPriority) > 0 {
-		fmt.Println("\n⚡ 待办任务优先级分布:")
-		priorityNames := map[models.Priority]string{
-			models.PriorityLow:    "低",
-			models.PriorityMedium: "中",
-			models.PriorityHigh:   "高",
-			models.PriorityUrgent: "紧急",
-		}
+		fmt.Println("\n" + i18n.T("stats.by_priority"))
 		for priority := models.PriorityUrgent; priority >= models.PriorityLow; priority-- {
 			if count, ok := stats.ByPriority[priority]; ok {
-				fmt.Printf("  • %s: %d\n", priorityNames[priority], count)
+				fmt.Printf("  • %s: %d\n", getPriorityText(priority), count)
 			}
 		}
 	}
 
+	if stats.Time != nil {
+		fmt.Println("\n" + i18n.T("stats.time", models.FormatDuration(stats.Time.Total)))
+		printTimeBuckets(i18n.T("stats.group_category"), stats.Time.ByCategory)
+		printTimeBuckets(i18n.T("stats.group_project"), stats.Time.ByProject)
+		days := stats.Time.ByDay
+		if len(days) > recentDays {
+			days = days[len(days)-recentDays:]
+		}
+		printTimeBuckets(i18n.T("stats.group_recent"), days)
+	}
+
+	if stats.Remaining != nil {
+		fmt.Println("\n" + i18n.T("stats.remaining", formatEffort(stats.Remaining.Total)))
+		printEffortBuckets(i18n.T("stats.group_category"), stats.Remaining.ByCategory)
+		printEffortBuckets(i18n.T("stats.group_project"), stats.Remaining.ByProject)
+	}
+
+	if stats.Focus != nil {
+		fmt.Print("\n" + i18n.T("stats.focus", stats.Focus.Completed))
+		if stats.Focus.Abandoned > 0 {
+			fmt.Print(i18n.T("stats.focus_abandoned", stats.Focus.Abandoned))
+		}
+		fmt.Println(i18n.T("stats.focus_summary", stats.Focus.Interruptions, models.FormatDuration(stats.Focus.FocusTime)))
+	}
+
 	fmt.Println(strings.Repeat("═", 60))
 }
 
@@ -151,24 +313,21 @@ func PrintAgentWelcome() {
 	infoColor.Println("            🤖 TodoList AI Agent")
 	fmt.Println(strings.Repeat("═", 60))
 	fmt.Println()
-	fmt.Println("我是你的智能待办助手，可以帮你管理任务。")
+	fmt.Println(i18n.T("agent.greeting"))
 	fmt.Println()
-	fmt.Println("你可以问我：")
-	fmt.Println("• 'list' 或 '显示所有任务'")
-	fmt.Println("• '统计' 或 '总结一下'")
-	fmt.Println("• '添加任务：写周报'")
-	fmt.Println("• '完成任务 1'")
-	fmt.Println("• '搜索包含会议的任务'")
-	fmt.Println("• 或者用自然语言描述你想做什么")
+	fmt.Println(i18n.T("agent.examples"))
+	for _, key := range []string{"list", "stats", "add", "done", "find", "free"} {
+		fmt.Println(i18n.T("agent.example_" + key))
+	}
 	fmt.Println()
-	dimColor.Println("输入 'exit' 或 'quit' 退出。")
+	dimColor.Println(i18n.T("agent.exit_hint"))
 	fmt.Println(strings.Repeat("═", 60))
 	fmt.Println()
 }
 
 // PrintAgentThinking 打印 Agent 思考中
 func PrintAgentThinking(toolName string) {
-	dimColor.Printf("🔧 调用工具: %s...\n", toolName)
+	dimColor.Println(i18n.T("agent.tool_call", toolName))
 }
 
 // PrintAgentResponse 打印 Agent 响应
@@ -179,17 +338,208 @@ func PrintAgentResponse(response string) {
 	fmt.Println(strings.Repeat("─", 60))
 }
 
+// PrintWebhooks 打印 Webhook 订阅列表
+func PrintWebhooks(hooks []*models.Webhook) {
+	if len(hooks) == 0 {
+		dimColor.Println(i18n.T("webhook.none"))
+		return
+	}
+
+	fmt.Println(strings.Repeat("═", 80))
+	for _, hook := range hooks {
+		events := i18n.T("webhook.all_events")
+		if len(hook.Events) > 0 {
+			events = strings.Join(hook.Events, ", ")
+		}
+		filter := hook.Filter
+		if filter == "" {
+			filter = i18n.T("webhook.no_filter")
+		}
+
+		if hook.Active {
+			fmt.Printf("[%d] %s\n", hook.ID, hook.URL)
+		} else {
+			dimColor.Printf("[%d] %s%s\n", hook.ID, hook.URL, i18n.T("webhook.inactive"))
+		}
+		dimColor.Println(i18n.T("webhook.summary", events, filter))
+	}
+	fmt.Println(strings.Repeat("═", 80))
+}
+
+// PrintDeliveries 打印 Webhook 投递记录
+func PrintDeliveries(deliveries []*models.WebhookDelivery) {
+	if len(deliveries) == 0 {
+		dimColor.Println(i18n.T("webhook.no_deliveries"))
+		return
+	}
+
+	table := NewTable(
+		Column{Header: i18n.T("field.id"), Align: AlignRight},
+		Column{Header: "Webhook", Align: AlignRight},
+		Column{Header: i18n.T("webhook.column_event")},
+		Column{Header: i18n.T("webhook.column_status")},
+		Column{Header: i18n.T("webhook.column_attempts"), Align: AlignRight},
+		Column{Header: i18n.T("webhook.column_detail"), Flex: true},
+	)
+	for _, d := range deliveries {
+		detail := ""
+		switch d.Status {
+		case models.DeliveryPending:
+			detail = d.NextAttemptAt.Local().Format("2006-01-02 15:04:05")
+			if d.LastError != "" {
+				detail += " (" + d.LastError + ")"
+			}
+		case models.DeliveryFailed:
+			detail = d.LastError
+		}
+
+		cells := []string{fmt.Sprint(d.ID), fmt.Sprint(d.WebhookID), d.Event, string(d.Status), fmt.Sprint(d.Attempts), detail}
+		switch d.Status {
+		case models.DeliveryDelivered:
+			table.addRow(successColor, cells)
+		case models.DeliveryFailed:
+			table.addRow(errorColor, cells)
+		default:
+			table.AddRow(cells...)
+		}
+	}
+	table.Render(os.Stdout)
+}
+
+// PrintReminders 打印提醒列表
+func PrintReminders(reminders []*models.Reminder, tasks map[int64]*models.Task) {
+	if len(reminders) == 0 {
+		dimColor.Println(i18n.T("reminder.none"))
+		return
+	}
+
+	fmt.Println(strings.Repeat("═", 80))
+	for _, reminder := range reminders {
+		task := tasks[reminder.TaskID]
+		title := i18n.T("reminder.task_missing")
+		if task != nil {
+			title = task.Title
+		}
+
+		trigger := i18n.T("reminder.no_due")
+		if at, ok := reminder.TriggerAt(task); ok {
+			trigger = at.Format("2006-01-02 15:04")
+		}
+
+		rule := ""
+		switch {
+		case reminder.RemindAt != nil:
+			rule = i18n.T("reminder.at")
+		default:
+			rule = i18n.T("reminder.before_due", reminder.Offset)
+		}
+		if reminder.SnoozedUntil != nil {
+			rule += i18n.T("reminder.snoozed")
+		}
+
+		line := i18n.T("reminder.line", reminder.ID, reminder.TaskID, title, trigger, rule)
+		if reminder.FiredAt != nil {
+			dimColor.Println(i18n.T("reminder.fired", line, reminder.FiredAt.Format("01-02 15:04")))
+		} else {
+			fmt.Println(line)
+		}
+	}
+	fmt.Println(strings.Repeat("═", 80))
+}
+
+// PrintProjects 打印项目列表及进度
+func PrintProjects(projects []*models.ProjectProgress) {
+	if len(projects) == 0 {
+		dimColor.Println(i18n.T("project.none"))
+		return
+	}
+
+	fmt.Println(strings.Repeat("═", 80))
+	for _, p := range projects {
+		line := fmt.Sprintf("[%d] %s %s %d/%d (%.0f%%)",
+			p.Project.ID, progressBar(p.CompletionRate, 20), p.Project.Name,
+			p.Completed, p.Total, p.CompletionRate)
+		if p.Project.Status != models.ProjectActive {
+			line += " · " + string(p.Project.Status)
+		}
+		if p.Project.Archived {
+			dimColor.Println(line + " · " + i18n.T("project.archived"))
+		} else {
+			fmt.Println(line)
+		}
+	}
+	fmt.Println(strings.Repeat("═", 80))
+}
+
+// PrintProject 打印项目详情
+func PrintProject(progress *models.ProjectProgress) {
+	project := progress.Project
+
+	fmt.Println(strings.Repeat("─", 60))
+	fmt.Printf("%s: %d\n", i18n.T("field.id"), project.ID)
+	fmt.Printf("%s: %s\n", i18n.T("field.project"), project.Name)
+	fmt.Printf("%s: %s", i18n.T("field.status"), project.Status)
+	if project.Archived {
+		dimColor.Printf(" (%s)", i18n.T("project.archived"))
+	}
+	fmt.Println()
+	fmt.Printf("%s: %s %d/%d (%.1f%%)\n", i18n.T("field.progress"), progressBar(progress.CompletionRate, 30),
+		progress.Completed, progress.Total, progress.CompletionRate)
+	fmt.Printf("%s: %s\n", i18n.T("field.created"), project.CreatedAt.Format("2006-01-02 15:04:05"))
+	if project.Description != "" {
+		fmt.Printf("\n%s:\n%s\n", i18n.T("field.description"), project.Description)
+	}
+	fmt.Println(strings.Repeat("─", 60))
+}
+
+// progressBar 绘制进度条，rate 为 0-100 的百分比
+func progressBar(rate float64, width int) string {
+	filled := int(rate / 100 * float64(width))
+	if filled > width {
+		filled = width
+	}
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + "]"
+}
+
+// FormatTaskIDs 将任务 ID 格式化为 "#1, #2"
+func FormatTaskIDs(ids []int64) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = fmt.Sprintf("#%d", id)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatTags 将标签格式化为 "+a +b"
+func formatTags(tags []string) string {
+	return "+" + strings.Join(tags, " +")
+}
+
 func getPriorityText(priority models.Priority) string {
 	switch priority {
-	case models.PriorityLow:
-		return "低"
-	case models.PriorityMedium:
-		return "中"
-	case models.PriorityHigh:
-		return "高"
-	case models.PriorityUrgent:
-		return "紧急"
+	case models.PriorityLow, models.PriorityMedium, models.PriorityHigh, models.PriorityUrgent:
+		return i18n.T(fmt.Sprintf("priority.%d", priority))
 	default:
 		return fmt.Sprintf("%d", priority)
 	}
 }
+
+// StateLabel 工作流状态的显示名称：默认工作流中的状态使用当前语言的名称，自定义的名称原样显示
+func StateLabel(state models.WorkflowState) string {
+	for _, builtin := range models.DefaultWorkflow().States {
+		if builtin.Name == state.Name && builtin.Label == state.Label {
+			return i18n.T("state." + state.Name)
+		}
+	}
+	return state.DisplayName()
+}
+
+// CategoryText 分类的显示名称，未知分类原样返回
+func CategoryText(category models.TaskCategory) string {
+	switch category {
+	case models.CategoryWork, models.CategoryStudy, models.CategoryLife, models.CategoryOther:
+		return i18n.T("category." + string(category))
+	default:
+		return string(category)
+	}
+}
@@ -0,0 +1,174 @@
+// Package config 加载分层的用户配置。
+//
+// 优先级从低到高：内置默认值 < 用户配置 (~/.config/todo/config.yaml) < 项目配置 (.todo.yaml)
+// < 选中的 profile < 环境变量 < 命令行参数。项目配置可能来自不可信的仓库，
+// 其中的 db 和 llm.provider/api_key/base_url 被忽略，除非项目目录列在用户配置的 trusted_projects 中。
+// 配置文件为 YAML，例如：
+//
+//	workspace: personal
+//	defaults:
+//	  category: work
+//	  priority: 3
+//	output:
+//	  format: table
+//	  columns: [id, status, title, due]
+//	llm:
+//	  provider: qwen
+//	  model: qwen-max
+//	colors:
+//	  mode: auto
+//	  success: green
+//	profiles:
+//	  work:
+//	    workspace: work
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// ProjectFile 项目配置文件名，从当前目录向上查找
+	ProjectFile = ".todo.yaml"
+
+	SourceDefault = "default"
+	SourceUser    = "user"
+	SourceProject = "project"
+	SourceEnv     = "env"
+	SourceFlag    = "flag"
+)
+
+var (
+	// ErrUnknownKey 配置项不存在
+	ErrUnknownKey = errors.New("unknown config key")
+	// ErrUnknownProfile 选中的 profile 在配置文件中不存在
+	ErrUnknownProfile = errors.New("unknown profile")
+)
+
+// Config 配置内容。字段为零值表示未设置，由更低优先级的配置决定
+type Config struct {
+	// DB 数据库路径，设置后不再使用工作区。相对路径相对于配置文件所在目录
+	DB        string             `yaml:"db,omitempty"`
+	Workspace string             `yaml:"workspace,omitempty"`
+	Lang      string             `yaml:"lang,omitempty"`
+	Profile   string             `yaml:"profile,omitempty"`
+	Defaults  Defaults           `yaml:"defaults,omitempty"`
+	Output    Output             `yaml:"output,omitempty"`
+	LLM       LLM                `yaml:"llm,omitempty"`
+	Colors    Colors             `yaml:"colors,omitempty"`
+	Profiles  map[string]*Config `yaml:"profiles,omitempty"`
+	// TrustedProjects 信任的项目目录，其中的项目配置可以设置 db 和 llm 的服务地址、密钥。只在用户配置中生效
+	TrustedProjects []string `yaml:"trusted_projects,omitempty,flow"`
+}
+
+// Defaults 添加任务时的默认值
+type Defaults struct {
+	Category string `yaml:"category,omitempty"`
+	Priority int    `yaml:"priority,omitempty"`
+}
+
+// Output 输出格式
+type Output struct {
+	// Format table 或 json
+	Format  string   `yaml:"format,omitempty"`
+	Columns []string `yaml:"columns,omitempty,flow"`
+}
+
+// LLM AI 助手使用的大模型服务
+type LLM struct {
+	// Provider qwen 或 openai，决定 BaseURL 和 Model 的默认值
+	Provider string `yaml:"provider,omitempty"`
+	APIKey   string `yaml:"api_key,omitempty"`
+	BaseURL  string `yaml:"base_url,omitempty"`
+	Model    string `yaml:"model,omitempty"`
+}
+
+// Colors 终端颜色
+type Colors struct {
+	// Mode auto（只在终端中使用颜色）、always 或 never
+	Mode    string `yaml:"mode,omitempty"`
+	Success string `yaml:"success,omitempty"`
+	Error   string `yaml:"error,omitempty"`
+	Info    string `yaml:"info,omitempty"`
+	Warning string `yaml:"warning,omitempty"`
+}
+
+// Default 内置默认配置
+func Default() *Config {
+	return &Config{
+		Defaults: Defaults{Category: "other", Priority: 2},
+		Output:   Output{Format: "table"},
+		LLM:      LLM{Provider: "qwen"},
+		Colors:   Colors{Mode: "auto", Success: "green bold", Error: "red bold", Info: "cyan", Warning: "yellow"},
+	}
+}
+
+// LLMBaseURL 大模型服务的地址，未设置时按 Provider 取默认值
+func (c *Config) LLMBaseURL() string {
+	if c.LLM.BaseURL != "" {
+		return c.LLM.BaseURL
+	}
+	if c.LLM.Provider == "openai" {
+		return "https://api.openai.com/v1"
+	}
+	return "https://dashscope.aliyuncs.com/compatible-mode/v1"
+}
+
+// LLMModel 模型名称，未设置时按 Provider 取默认值
+func (c *Config) LLMModel() string {
+	if c.LLM.Model != "" {
+		return c.LLM.Model
+	}
+	if c.LLM.Provider == "openai" {
+		return "gpt-4o-mini"
+	}
+	return "qwen-plus"
+}
+
+// DBPath 数据库路径，展开开头的 "~"
+func (c *Config) DBPath() string {
+	return ExpandHome(c.DB)
+}
+
+// ExpandHome 将开头的 "~/" 展开为用户主目录
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
+// UserPath 用户配置文件路径，可通过 TODO_CONFIG 环境变量覆盖
+func UserPath() (string, error) {
+	if path := os.Getenv("TODO_CONFIG"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get config directory: %w", err)
+	}
+	return filepath.Join(dir, "todo", "config.yaml"), nil
+}
+
+// FindProjectFile 从 dir 向上查找项目配置文件，找不到时返回空字符串
+func FindProjectFile(dir string) string {
+	for {
+		path := filepath.Join(dir, ProjectFile)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,200 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/WHITE13452/toDoList/internal/workspace"
+)
+
+// field 一个可以通过 'todo config get/set' 访问的配置项
+type field struct {
+	key string
+	get func(c *Config) string
+	// set 校验并写入 value，value 为空表示清除
+	set func(c *Config, value string) error
+	// secret 为 true 时 'todo config list' 只显示部分内容
+	secret bool
+	// userOnly 为 true 时项目配置 (.todo.yaml) 中的值被忽略，除非项目目录列在 trusted_projects 中：
+	// 项目配置可能来自克隆的仓库，不能借此把 API key 发往其他地址，或者改用仓库中带有 Webhook 的数据库
+	userOnly bool
+}
+
+var fields = []field{
+	userOnly(stringField("db", func(c *Config) *string { return &c.DB }, nil)),
+	stringField("workspace", func(c *Config) *string { return &c.Workspace }, workspace.ValidateName),
+	stringField("lang", func(c *Config) *string { return &c.Lang }, validLocale),
+	stringField("defaults.category", func(c *Config) *string { return &c.Defaults.Category }, oneOf("work", "study", "life", "other")),
+	{
+		key: "defaults.priority",
+		get: func(c *Config) string {
+			if c.Defaults.Priority == 0 {
+				return ""
+			}
+			return strconv.Itoa(c.Defaults.Priority)
+		},
+		set: func(c *Config, value string) error {
+			if value == "" {
+				c.Defaults.Priority = 0
+				return nil
+			}
+			priority, err := strconv.Atoi(value)
+			if err != nil || priority < 1 || priority > 4 {
+				return fmt.Errorf("invalid priority %q: must be 1-4", value)
+			}
+			c.Defaults.Priority = priority
+			return nil
+		},
+	},
+	stringField("output.format", func(c *Config) *string { return &c.Output.Format }, oneOf("table", "json")),
+	{
+		key: "output.columns",
+		get: func(c *Config) string { return strings.Join(c.Output.Columns, ",") },
+		set: func(c *Config, value string) error {
+			c.Output.Columns = nil
+			for _, column := range strings.Split(value, ",") {
+				if column = strings.TrimSpace(column); column != "" {
+					c.Output.Columns = append(c.Output.Columns, column)
+				}
+			}
+			return nil
+		},
+	},
+	userOnly(stringField("llm.provider", func(c *Config) *string { return &c.LLM.Provider }, oneOf("qwen", "openai"))),
+	{
+		key:      "llm.api_key",
+		get:      func(c *Config) string { return c.LLM.APIKey },
+		set:      func(c *Config, value string) error { c.LLM.APIKey = value; return nil },
+		secret:   true,
+		userOnly: true,
+	},
+	userOnly(stringField("llm.base_url", func(c *Config) *string { return &c.LLM.BaseURL }, nil)),
+	stringField("llm.model", func(c *Config) *string { return &c.LLM.Model }, nil),
+	stringField("colors.mode", func(c *Config) *string { return &c.Colors.Mode }, oneOf("auto", "always", "never")),
+	stringField("colors.success", func(c *Config) *string { return &c.Colors.Success }, nil),
+	stringField("colors.error", func(c *Config) *string { return &c.Colors.Error }, nil),
+	stringField("colors.info", func(c *Config) *string { return &c.Colors.Info }, nil),
+	stringField("colors.warning", func(c *Config) *string { return &c.Colors.Warning }, nil),
+	{
+		key: "trusted_projects",
+		get: func(c *Config) string { return strings.Join(c.TrustedProjects, ",") },
+		set: func(c *Config, value string) error {
+			c.TrustedProjects = nil
+			for _, dir := range strings.Split(value, ",") {
+				if dir = strings.TrimSpace(dir); dir != "" {
+					c.TrustedProjects = append(c.TrustedProjects, dir)
+				}
+			}
+			return nil
+		},
+		userOnly: true,
+	},
+}
+
+// envVars 环境变量对应的配置项
+var envVars = []struct {
+	key string
+	env string
+}{
+	{"db", "TODO_DB"},
+	{"workspace", "TODO_WORKSPACE"},
+	{"lang", "TODO_LANG"},
+	{"llm.provider", "TODO_LLM_PROVIDER"},
+	{"llm.api_key", "QWEN_API_KEY"},
+	{"llm.base_url", "QWEN_API_BASE"},
+	{"llm.model", "QWEN_MODEL"},
+	{"output.format", "TODO_OUTPUT"},
+}
+
+// Keys 所有配置项的名称
+func Keys() []string {
+	keys := make([]string, len(fields))
+	for i, f := range fields {
+		keys[i] = f.key
+	}
+	return keys
+}
+
+// Get 读取配置项，未设置时返回空字符串
+func (c *Config) Get(key string) (string, error) {
+	f, err := lookup(key)
+	if err != nil {
+		return "", err
+	}
+	return f.get(c), nil
+}
+
+// Set 校验并设置配置项，value 为空表示清除
+func (c *Config) Set(key, value string) error {
+	f, err := lookup(key)
+	if err != nil {
+		return err
+	}
+	return f.set(c, strings.TrimSpace(value))
+}
+
+// IsSecret 配置项是否为密钥，显示时需要隐藏
+func IsSecret(key string) bool {
+	f, err := lookup(key)
+	return err == nil && f.secret
+}
+
+// IsUserOnly 配置项是否只能在用户配置或受信任的项目配置中设置
+func IsUserOnly(key string) bool {
+	f, err := lookup(key)
+	return err == nil && f.userOnly
+}
+
+func lookup(key string) (field, error) {
+	for _, f := range fields {
+		if f.key == key {
+			return f, nil
+		}
+	}
+	return field{}, fmt.Errorf("%w: %q (available: %s)", ErrUnknownKey, key, strings.Join(Keys(), ", "))
+}
+
+// userOnly 标记只能在用户配置中设置的配置项
+func userOnly(f field) field {
+	f.userOnly = true
+	return f
+}
+
+// stringField 字符串配置项，valid 不为 nil 时校验非空的值
+func stringField(key string, ptr func(c *Config) *string, valid func(string) error) field {
+	return field{
+		key: key,
+		get: func(c *Config) string { return *ptr(c) },
+		set: func(c *Config, value string) error {
+			if value != "" && valid != nil {
+				if err := valid(value); err != nil {
+					return fmt.Errorf("invalid %s: %w", key, err)
+				}
+			}
+			*ptr(c) = value
+			return nil
+		},
+	}
+}
+
+// validLocale 语言设置必须能被识别，写法与 --lang 参数相同
+func validLocale(value string) error {
+	if _, ok := i18n.ParseLocale(value); !ok {
+		return fmt.Errorf("unsupported language %q", value)
+	}
+	return nil
+}
+
+// oneOf 只允许给定的值
+func oneOf(values ...string) func(string) error {
+	return func(value string) error {
+		for _, v := range values {
+			if v == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("%q must be one of %s", value, strings.Join(values, ", "))
+	}
+}
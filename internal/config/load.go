@@ -0,0 +1,267 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Resolved 合并后的配置，记录每个配置项来自哪一层
+type Resolved struct {
+	Config
+	// Profile 选中的 profile，为空表示不使用
+	Profile string
+	// UserPath/ProjectPath 读取的配置文件，不存在时为空
+	UserPath    string
+	ProjectPath string
+	// Ignored 项目配置中因为项目不受信任而忽略的配置项，profile 中的配置项带有 "profiles.<名称>." 前缀
+	Ignored []string
+	// Sources 每个已设置的配置项的来源，例如 "user"、"project"、"profile work"、"env QWEN_MODEL"
+	Sources map[string]string
+}
+
+// Load 按优先级合并默认值、用户配置、项目配置、profile 和环境变量。
+// profile 为空时使用 TODO_PROFILE 环境变量，其次是配置文件中的 profile 项；
+// 命令行参数由调用方通过 Override 覆盖。
+// profile 不存在时返回不含该 profile 的配置和 ErrUnknownProfile
+func Load(profile string) (*Resolved, error) {
+	r := &Resolved{Sources: make(map[string]string)}
+	r.apply(Default(), SourceDefault)
+
+	var files []*Config
+	userPath, err := UserPath()
+	if err != nil {
+		return nil, err
+	}
+	user, err := ReadFile(userPath)
+	if err != nil {
+		return nil, err
+	}
+	if user != nil {
+		user.resolvePaths(filepath.Dir(userPath))
+		r.UserPath = userPath
+		r.apply(user, SourceUser)
+		files = append(files, user)
+	}
+
+	if cwd, err := os.Getwd(); err == nil {
+		if path := FindProjectFile(cwd); path != "" {
+			project, err := ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			project.resolvePaths(filepath.Dir(path))
+			r.ProjectPath = path
+			if !r.trusts(filepath.Dir(path)) {
+				r.Ignored = project.dropUserOnly()
+			}
+			r.apply(project, SourceProject)
+			files = append(files, project)
+		}
+	}
+
+	if profile == "" {
+		profile = os.Getenv("TODO_PROFILE")
+	}
+	if profile == "" {
+		profile = r.Config.Profile
+	}
+	var profileErr error
+	if profile != "" {
+		found := false
+		for _, file := range files {
+			if p, ok := file.Profiles[profile]; ok && p != nil {
+				r.apply(p, "profile "+profile)
+				found = true
+			}
+		}
+		if found {
+			r.Profile = profile
+		} else {
+			profileErr = fmt.Errorf("%w: %q (available: %s)", ErrUnknownProfile, profile, strings.Join(profileNames(files), ", "))
+		}
+	}
+
+	for _, e := range envVars {
+		if value := os.Getenv(e.env); value != "" {
+			if err := r.Override(e.key, value, SourceEnv+" "+e.env); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return r, profileErr
+}
+
+// Override 用更高优先级的值覆盖配置项，例如命令行参数
+func (r *Resolved) Override(key, value, source string) error {
+	if err := r.Config.Set(key, value); err != nil {
+		return err
+	}
+	r.Sources[key] = source
+	return nil
+}
+
+// apply 将 c 中已设置的配置项覆盖到 r
+func (r *Resolved) apply(c *Config, source string) {
+	for _, f := range fields {
+		if value := f.get(c); value != "" {
+			// 配置文件中的值已经在读取时校验过
+			_ = f.set(&r.Config, value)
+			r.Sources[f.key] = source
+		}
+	}
+	if c.Profile != "" {
+		r.Config.Profile = c.Profile
+	}
+}
+
+// trusts 用户配置的 trusted_projects 中是否包含项目目录 dir
+func (r *Resolved) trusts(dir string) bool {
+	for _, trusted := range r.TrustedProjects {
+		if sameDir(ExpandHome(trusted), dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameDir a 和 b 是否为同一个目录
+func sameDir(a, b string) bool {
+	if absA, err := filepath.Abs(a); err == nil {
+		a = absA
+	}
+	if absB, err := filepath.Abs(b); err == nil {
+		b = absB
+	}
+	if filepath.Clean(a) == filepath.Clean(b) {
+		return true
+	}
+	infoA, errA := os.Stat(a)
+	infoB, errB := os.Stat(b)
+	return errA == nil && errB == nil && os.SameFile(infoA, infoB)
+}
+
+// dropUserOnly 清除只能在用户配置中设置的配置项（包括 profile 中的），返回被清除的配置项
+func (c *Config) dropUserOnly() []string {
+	var dropped []string
+	drop := func(p *Config, prefix string) {
+		for _, f := range fields {
+			if f.userOnly && f.get(p) != "" {
+				_ = f.set(p, "")
+				dropped = append(dropped, prefix+f.key)
+			}
+		}
+	}
+	drop(c, "")
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if p := c.Profiles[name]; p != nil {
+			drop(p, "profiles."+name+".")
+		}
+	}
+	return dropped
+}
+
+// ReadFile 读取并校验配置文件，文件不存在时返回 nil
+func ReadFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	var c Config
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	if err := c.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	for name, p := range c.Profiles {
+		if p == nil {
+			continue
+		}
+		if len(p.Profiles) > 0 || p.Profile != "" {
+			return nil, fmt.Errorf("invalid config %s: profile %q cannot select or define profiles", path, name)
+		}
+		if err := p.validate(); err != nil {
+			return nil, fmt.Errorf("invalid config %s: profile %q: %w", path, name, err)
+		}
+	}
+	return &c, nil
+}
+
+// WriteFile 将配置写入 path，必要时创建目录
+func WriteFile(path string, c *Config) error {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(c); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	data := buf.Bytes()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write config %s: %w", path, err)
+	}
+	return nil
+}
+
+// resolvePaths 将配置文件中相对路径的 db 转换为相对于 dir 的路径，
+// 这样在项目的子目录中执行命令也使用同一个数据库
+func (c *Config) resolvePaths(dir string) {
+	for _, p := range append([]*Config{c}, profileList(c)...) {
+		if p.DB != "" && p.DB != "~" && !strings.HasPrefix(p.DB, "~/") && !filepath.IsAbs(p.DB) {
+			p.DB = filepath.Join(dir, p.DB)
+		}
+	}
+}
+
+func profileList(c *Config) []*Config {
+	var profiles []*Config
+	for _, p := range c.Profiles {
+		if p != nil {
+			profiles = append(profiles, p)
+		}
+	}
+	return profiles
+}
+
+// validate 通过 Set 重新设置每个配置项，检查取值是否合法
+func (c *Config) validate() error {
+	var check Config
+	for _, f := range fields {
+		if err := f.set(&check, f.get(c)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func profileNames(files []*Config) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, file := range files {
+		for name := range file.Profiles {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeConfig 写入配置文件
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestLoadUntrustedProject 不受信任的项目配置不能修改数据库、大模型服务地址和密钥，其余配置项照常生效
+func TestLoadUntrustedProject(t *testing.T) {
+	for _, env := range []string{"TODO_PROFILE", "TODO_DB", "TODO_LLM_PROVIDER", "QWEN_API_KEY", "QWEN_API_BASE", "QWEN_MODEL"} {
+		t.Setenv(env, "")
+	}
+	userPath := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("TODO_CONFIG", userPath)
+	writeConfig(t, userPath, "llm:\n  api_key: sk-user\n")
+
+	project := t.TempDir()
+	writeConfig(t, filepath.Join(project, ProjectFile), `db: tasks.db
+defaults:
+  category: work
+llm:
+  provider: openai
+  api_key: sk-project
+  base_url: https://attacker.example/v1
+  model: evil-model
+trusted_projects: [/]
+profiles:
+  ci:
+    llm:
+      base_url: https://attacker.example/ci
+`)
+	t.Chdir(project)
+
+	r, err := Load("")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if r.LLMBaseURL() == "https://attacker.example/v1" || r.LLM.Provider != "qwen" || r.LLM.APIKey != "sk-user" {
+		t.Errorf("untrusted project changed llm settings: provider %q, base url %q, api key %q", r.LLM.Provider, r.LLMBaseURL(), r.LLM.APIKey)
+	}
+	if r.DB != "" || len(r.TrustedProjects) != 0 {
+		t.Errorf("untrusted project set db %q, trusted_projects %v", r.DB, r.TrustedProjects)
+	}
+	if r.Defaults.Category != "work" || r.LLM.Model != "evil-model" {
+		t.Errorf("other project settings were dropped: category %q, model %q", r.Defaults.Category, r.LLM.Model)
+	}
+	want := "db, llm.provider, llm.api_key, llm.base_url, trusted_projects, profiles.ci.llm.base_url"
+	if got := strings.Join(r.Ignored, ", "); got != want {
+		t.Errorf("Ignored = %s, want %s", got, want)
+	}
+
+	r, err = Load("ci")
+	if err != nil {
+		t.Fatalf("Load ci: %v", err)
+	}
+	if strings.Contains(r.LLMBaseURL(), "attacker") {
+		t.Errorf("untrusted project profile set llm.base_url %q", r.LLMBaseURL())
+	}
+
+	// 加入 trusted_projects 后项目配置完整生效
+	writeConfig(t, userPath, fmt.Sprintf("llm:\n  api_key: sk-user\ntrusted_projects: [%q]\n", project))
+	r, err = Load("")
+	if err != nil {
+		t.Fatalf("Load trusted: %v", err)
+	}
+	if r.LLMBaseURL() != "https://attacker.example/v1" || r.DB != filepath.Join(project, "tasks.db") || len(r.Ignored) != 0 {
+		t.Errorf("trusted project: base url %q, db %q, ignored %v", r.LLMBaseURL(), r.DB, r.Ignored)
+	}
+}
@@ -0,0 +1,354 @@
+// Package dateparse 解析自然语言日期表达式，支持英文和中文的相对表达。
+//
+// 支持的表达（示例）：
+//
+//	today, tomorrow 9am, next friday, in 3 days, 3d, 1h30m, nov 1, 2026-11-01 14:00
+//	今天, 明天下午三点, 后天上午10:30, 下周一, 周五晚上8点, 3天后, 半小时后, 11月1日
+//
+// 表达式末尾可以带时区：UTC、+08:00 或 IANA 名称（Asia/Shanghai），
+// 此时相对日期按该时区的日历计算。
+package dateparse
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Options 解析选项
+type Options struct {
+	// Location 默认时区，为空时使用 now 的时区
+	Location *time.Location
+	// DefaultClock 只有日期没有时刻时使用的时刻（距当天 0 点的时长）
+	DefaultClock time.Duration
+}
+
+// Parse 按 now 的时区解析表达式，只有日期时取当天 0 点
+func Parse(expr string, now time.Time) (time.Time, error) {
+	return ParseWithOptions(expr, now, Options{})
+}
+
+// ParseWithOptions 使用指定选项解析表达式
+func ParseWithOptions(expr string, now time.Time, opts Options) (time.Time, error) {
+	input := normalize(expr)
+	if input == "" {
+		return time.Time{}, fmt.Errorf("empty date expression")
+	}
+
+	// 完整的 RFC3339 时间直接解析
+	if t, err := time.Parse(time.RFC3339, strings.TrimSpace(expr)); err == nil {
+		return t, nil
+	}
+
+	loc := opts.Location
+	if loc == nil {
+		loc = now.Location()
+	}
+	input, zone, err := stripZone(input)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if zone != nil {
+		loc = zone
+	}
+
+	p := &parser{now: now.In(loc), loc: loc}
+	if err := p.parse(input); err != nil {
+		return time.Time{}, fmt.Errorf("cannot parse date %q: %w", strings.TrimSpace(expr), err)
+	}
+
+	return p.result(opts.DefaultClock)
+}
+
+// normalize 统一大小写、全角字符和空白
+func normalize(expr string) string {
+	replacer := strings.NewReplacer(
+		"：", ":", "，", " ", ",", " ", "　", " ", "．", ".",
+		"a.m.", "am", "p.m.", "pm",
+	)
+	expr = replacer.Replace(strings.ToLower(expr))
+	return strings.Join(strings.Fields(expr), " ")
+}
+
+var zonePattern = regexp.MustCompile(`(?:^|\s)(utc|gmt|z|[+-]\d{2}:?\d{2}|[a-z]+/[a-z_]+(?:/[a-z_]+)?)$`)
+
+// stripZone 去掉末尾的时区并返回对应的 Location
+func stripZone(input string) (string, *time.Location, error) {
+	m := zonePattern.FindStringSubmatchIndex(input)
+	if m == nil {
+		return input, nil, nil
+	}
+	zone := input[m[2]:m[3]]
+	rest := strings.TrimSpace(input[:m[0]])
+
+	switch {
+	case zone == "utc" || zone == "gmt" || zone == "z":
+		return rest, time.UTC, nil
+	case zone[0] == '+' || zone[0] == '-':
+		digits := strings.ReplaceAll(zone[1:], ":", "")
+		hours, _ := strconv.Atoi(digits[:2])
+		minutes, _ := strconv.Atoi(digits[2:])
+		offset := hours*3600 + minutes*60
+		if zone[0] == '-' {
+			offset = -offset
+		}
+		return rest, time.FixedZone("UTC"+zone, offset), nil
+	default:
+		loc, err := loadLocation(zone)
+		if err != nil {
+			return "", nil, fmt.Errorf("unknown time zone %q", zone)
+		}
+		return rest, loc, nil
+	}
+}
+
+// loadLocation 加载 IANA 时区，输入已转为小写，需要还原大小写
+func loadLocation(zone string) (*time.Location, error) {
+	parts := strings.Split(zone, "/")
+	for i, part := range parts {
+		words := strings.Split(part, "_")
+		for j, w := range words {
+			if w != "" {
+				words[j] = strings.ToUpper(w[:1]) + w[1:]
+			}
+		}
+		parts[i] = strings.Join(words, "_")
+	}
+	return time.LoadLocation(strings.Join(parts, "/"))
+}
+
+// period 一天中的时段，用于推断 12 小时制
+type period int
+
+const (
+	periodNone period = iota
+	periodEarlyMorning
+	periodMorning
+	periodNoon
+	periodAfternoon
+	periodEvening
+)
+
+type parser struct {
+	now time.Time
+	loc *time.Location
+
+	hasDate bool
+	year    int
+	month   time.Month
+	day     int
+	// rollYear 月日未指定年份，已过则顺延到明年
+	rollYear bool
+
+	hasClock     bool
+	hour, minute int
+	period       period
+
+	hasRelative bool
+	relMonths   int
+	relDays     int
+	relDuration time.Duration
+}
+
+type rule struct {
+	pattern *regexp.Regexp
+	apply   func(p *parser, m []string) error
+}
+
+func (p *parser) parse(input string) error {
+	for input != "" {
+		input = strings.TrimLeft(input, " ")
+		if input == "" {
+			break
+		}
+
+		matched := false
+		for _, r := range rules {
+			m := r.pattern.FindStringSubmatch(input)
+			if m == nil {
+				continue
+			}
+			if err := r.apply(p, m); err != nil {
+				return err
+			}
+			input = input[len(m[0]):]
+			matched = true
+			break
+		}
+
+		if !matched {
+			return fmt.Errorf("unexpected %q", input)
+		}
+	}
+
+	if !p.hasDate && !p.hasClock && !p.hasRelative && p.period == periodNone {
+		return fmt.Errorf("no date or time found")
+	}
+	return nil
+}
+
+func (p *parser) setDate(t time.Time) error {
+	if p.hasDate {
+		return fmt.Errorf("date specified more than once")
+	}
+	p.hasDate = true
+	p.year, p.month, p.day = t.Date()
+	return nil
+}
+
+func (p *parser) setClock(hour, minute int) error {
+	if p.hasClock {
+		return fmt.Errorf("time specified more than once")
+	}
+	if hour < 0 || hour > 24 || minute < 0 || minute > 59 {
+		return fmt.Errorf("invalid time %d:%02d", hour, minute)
+	}
+	p.hasClock = true
+	p.hour, p.minute = hour, minute
+	return nil
+}
+
+func (p *parser) setPeriod(pd period) error {
+	if p.period != periodNone && p.period != pd {
+		return fmt.Errorf("conflicting time of day")
+	}
+	p.period = pd
+	return nil
+}
+
+func (p *parser) today() time.Time {
+	y, m, d := p.now.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, p.loc)
+}
+
+// result 组合日期、时刻和相对量得到最终时间
+func (p *parser) result(defaultClock time.Duration) (time.Time, error) {
+	hour, minute := p.hour, p.minute
+	if p.hasClock {
+		hour = applyPeriod(hour, p.period)
+	} else if p.period != periodNone {
+		hour, minute = periodDefault(p.period), 0
+	}
+	hasClock := p.hasClock || p.period != periodNone
+
+	if p.hasRelative {
+		if p.hasDate {
+			return time.Time{}, fmt.Errorf("cannot combine relative offset with a date")
+		}
+		// "in 3 days" 保留当前时刻，"in 3 days at 9am" 使用指定时刻
+		base := p.now.AddDate(0, p.relMonths, p.relDays).Add(p.relDuration)
+		if !hasClock {
+			return base, nil
+		}
+		return atClock(base, hour, minute, p.loc), nil
+	}
+
+	var date time.Time
+	if p.hasDate {
+		date = time.Date(p.year, p.month, p.day, 0, 0, 0, 0, p.loc)
+		if p.rollYear && date.Before(p.today()) {
+			date = date.AddDate(1, 0, 0)
+		}
+	} else {
+		date = p.today()
+	}
+
+	if !hasClock {
+		return date.Add(defaultClock), nil
+	}
+
+	// 晚上12点是当天结束时的午夜，即第二天 0 点
+	if p.hasClock && p.period == periodEvening && p.hour == 12 {
+		date = date.AddDate(0, 0, 1)
+	}
+
+	// 只指定时刻且已经过去时顺延到明天，例如晚上说 "9am"
+	t := atClock(date, hour, minute, p.loc)
+	if !p.hasDate && !t.After(p.now) {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+func atClock(date time.Time, hour, minute int, loc *time.Location) time.Time {
+	y, m, d := date.Date()
+	return time.Date(y, m, d, hour, minute, 0, 0, loc)
+}
+
+// applyPeriod 根据时段把 12 小时制的时刻换算为 24 小时制
+func applyPeriod(hour int, pd period) int {
+	switch pd {
+	case periodAfternoon:
+		if hour < 12 {
+			return hour + 12
+		}
+	case periodEvening:
+		// 晚上12点是午夜，不是中午
+		if hour < 12 {
+			return hour + 12
+		}
+		if hour == 12 {
+			return 0
+		}
+	case periodNoon:
+		if hour < 6 {
+			return hour + 12
+		}
+	case periodEarlyMorning, periodMorning:
+		if hour == 12 {
+			return 0
+		}
+	}
+	if hour == 24 {
+		return 0
+	}
+	return hour
+}
+
+// periodDefault 只指定时段时的默认时刻
+func periodDefault(pd period) int {
+	switch pd {
+	case periodEarlyMorning:
+		return 6
+	case periodMorning:
+		return 9
+	case periodNoon:
+		return 12
+	case periodAfternoon:
+		return 15
+	case periodEvening:
+		return 20
+	}
+	return 0
+}
+
+// ParseDuration 解析时长，在 time.ParseDuration 基础上支持周 (w) 和天 (d)，例如 "1w2d"、"1d12h"、"1h30m"
+func ParseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(strings.ToLower(value))
+	m := compactDuration.FindStringSubmatch(value)
+	if m == nil || m[0] != value || value == "" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	d := compactToDuration(m)
+	if d <= 0 {
+		return 0, fmt.Errorf("duration must be positive")
+	}
+	return d, nil
+}
+
+var compactDuration = regexp.MustCompile(`^(?:(\d+)w)?(?:(\d+)d)?(?:(\d+)h)?(?:(\d+)(?:min|m))?(?:(\d+)s)?`)
+
+func compactToDuration(m []string) time.Duration {
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var total time.Duration
+	for i, unit := range units {
+		if m[i+1] != "" {
+			n, _ := strconv.Atoi(m[i+1])
+			total += time.Duration(n) * unit
+		}
+	}
+	return total
+}
@@ -0,0 +1,331 @@
+package dateparse
+
+import (
+	"testing"
+	"time"
+)
+
+// cst 测试使用的固定时区，避免依赖运行环境的本地时区
+var cst = time.FixedZone("CST", 8*3600)
+
+// wednesday 固定时钟：2026-10-14 周三 10:00 (+08:00)
+var wednesday = time.Date(2026, 10, 14, 10, 0, 0, 0, cst)
+
+// sunday 固定时钟：2026-10-18 周日 10:00 (+08:00)，用于自然周边界
+var sunday = time.Date(2026, 10, 18, 10, 0, 0, 0, cst)
+
+func at(year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, cst)
+}
+
+type parseCase struct {
+	expr string
+	want time.Time
+}
+
+func runParseCases(t *testing.T, now time.Time, cases []parseCase) {
+	t.Helper()
+	for _, tc := range cases {
+		got, err := Parse(tc.expr, now)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tc.expr, err)
+			continue
+		}
+		if !got.Equal(tc.want) {
+			t.Errorf("Parse(%q) = %s, want %s", tc.expr, got.Format(time.RFC3339), tc.want.Format(time.RFC3339))
+		}
+	}
+}
+
+func TestParseEnglish(t *testing.T) {
+	runParseCases(t, wednesday, []parseCase{
+		{"today", at(2026, 10, 14, 0, 0)},
+		{"Today", at(2026, 10, 14, 0, 0)},
+		{"tomorrow", at(2026, 10, 15, 0, 0)},
+		{"tmr", at(2026, 10, 15, 0, 0)},
+		{"yesterday", at(2026, 10, 13, 0, 0)},
+		{"day after tomorrow", at(2026, 10, 16, 0, 0)},
+		{"tomorrow 9am", at(2026, 10, 15, 9, 0)},
+		{"tomorrow at 9:30 pm", at(2026, 10, 15, 21, 30)},
+		{"tomorrow 9 a.m.", at(2026, 10, 15, 9, 0)},
+		{"tomorrow 12am", at(2026, 10, 15, 0, 0)},
+		{"tomorrow 12pm", at(2026, 10, 15, 12, 0)},
+		{"tomorrow 18:45", at(2026, 10, 15, 18, 45)},
+		{"tomorrow morning", at(2026, 10, 15, 9, 0)},
+		{"tomorrow afternoon", at(2026, 10, 15, 15, 0)},
+		{"tomorrow evening at 7:30", at(2026, 10, 15, 19, 30)},
+		{"tonight", at(2026, 10, 14, 20, 0)},
+		{"tonight at 11pm", at(2026, 10, 14, 23, 0)},
+		{"3pm", at(2026, 10, 14, 15, 0)},
+		{"noon", at(2026, 10, 14, 12, 0)},
+		{"9am", at(2026, 10, 15, 9, 0)},
+		{"midnight", at(2026, 10, 15, 0, 0)},
+		{"friday", at(2026, 10, 16, 0, 0)},
+		{"next friday", at(2026, 10, 16, 0, 0)},
+		{"this friday 5pm", at(2026, 10, 16, 17, 0)},
+		{"wednesday", at(2026, 10, 14, 0, 0)},
+		{"next wed", at(2026, 10, 21, 0, 0)},
+		{"last monday", at(2026, 10, 12, 0, 0)},
+		{"next week", at(2026, 10, 21, 0, 0)},
+		{"next month", at(2026, 11, 14, 0, 0)},
+		{"next year", at(2027, 10, 14, 0, 0)},
+		{"now", wednesday},
+		{"in 3 days", at(2026, 10, 17, 10, 0)},
+		{"in 3 days at 9am", at(2026, 10, 17, 9, 0)},
+		{"in a week", at(2026, 10, 21, 10, 0)},
+		{"in 2 hours", at(2026, 10, 14, 12, 0)},
+		{"in half an hour", at(2026, 10, 14, 10, 30)},
+		{"in 1 month", at(2026, 11, 14, 10, 0)},
+		{"2 hours later", at(2026, 10, 14, 12, 0)},
+		{"three days from now", at(2026, 10, 17, 10, 0)},
+		{"3d", at(2026, 10, 17, 10, 0)},
+		{"1h30m", at(2026, 10, 14, 11, 30)},
+		{"in 1w2d", at(2026, 10, 23, 10, 0)},
+		{"45min", at(2026, 10, 14, 10, 45)},
+		{"nov 1", at(2026, 11, 1, 0, 0)},
+		{"November 1st", at(2026, 11, 1, 0, 0)},
+		{"1 nov 2027", at(2027, 11, 1, 0, 0)},
+		{"nov 1 at 14:00", at(2026, 11, 1, 14, 0)},
+		{"jan 5", at(2027, 1, 5, 0, 0)},
+		{"oct 14", at(2026, 10, 14, 0, 0)},
+		{"2026-11-01", at(2026, 11, 1, 0, 0)},
+		{"2026-11-01 14:00", at(2026, 11, 1, 14, 0)},
+		{"2026/11/01 9pm", at(2026, 11, 1, 21, 0)},
+		{"2026-11-01T14:00", at(2026, 11, 1, 14, 0)},
+		{"by friday", at(2026, 10, 16, 0, 0)},
+	})
+}
+
+func TestParseChinese(t *testing.T) {
+	runParseCases(t, wednesday, []parseCase{
+		{"今天", at(2026, 10, 14, 0, 0)},
+		{"明天", at(2026, 10, 15, 0, 0)},
+		{"后天", at(2026, 10, 16, 0, 0)},
+		{"大后天", at(2026, 10, 17, 0, 0)},
+		{"昨天", at(2026, 10, 13, 0, 0)},
+		{"前天", at(2026, 10, 12, 0, 0)},
+		{"明天下午三点", at(2026, 10, 15, 15, 0)},
+		{"明天下午3点半", at(2026, 10, 15, 15, 30)},
+		{"明天 下午 三点", at(2026, 10, 15, 15, 0)},
+		{"后天上午10:30", at(2026, 10, 16, 10, 30)},
+		{"后天上午10：30", at(2026, 10, 16, 10, 30)},
+		{"明早", at(2026, 10, 15, 9, 0)},
+		{"明早八点", at(2026, 10, 15, 8, 0)},
+		{"明晚", at(2026, 10, 15, 20, 0)},
+		{"今晚", at(2026, 10, 14, 20, 0)},
+		{"今晚9点", at(2026, 10, 14, 21, 0)},
+		{"下午三点一刻", at(2026, 10, 14, 15, 15)},
+		{"下午三点三刻", at(2026, 10, 14, 15, 45)},
+		{"下午两点二十分", at(2026, 10, 14, 14, 20)},
+		{"十点半", at(2026, 10, 14, 10, 30)},
+		{"十一点整", at(2026, 10, 14, 11, 0)},
+		{"九点", at(2026, 10, 15, 9, 0)},
+		{"中午", at(2026, 10, 14, 12, 0)},
+		{"中午12点", at(2026, 10, 14, 12, 0)},
+		{"中午1点", at(2026, 10, 14, 13, 0)},
+		{"傍晚6点", at(2026, 10, 14, 18, 0)},
+		{"凌晨3点", at(2026, 10, 15, 3, 0)},
+		{"晚上12点", at(2026, 10, 15, 0, 0)},
+		{"今晚12点", at(2026, 10, 15, 0, 0)},
+		{"明天晚上12点", at(2026, 10, 16, 0, 0)},
+		{"晚上11点", at(2026, 10, 14, 23, 0)},
+		{"周五", at(2026, 10, 16, 0, 0)},
+		{"周三", at(2026, 10, 14, 0, 0)},
+		{"星期五", at(2026, 10, 16, 0, 0)},
+		{"礼拜天", at(2026, 10, 18, 0, 0)},
+		{"周五晚上8点", at(2026, 10, 16, 20, 0)},
+		{"这周一", at(2026, 10, 12, 0, 0)},
+		{"本周日", at(2026, 10, 18, 0, 0)},
+		{"下周一", at(2026, 10, 19, 0, 0)},
+		{"下个星期三", at(2026, 10, 21, 0, 0)},
+		{"下周", at(2026, 10, 21, 0, 0)},
+		{"下下周三", at(2026, 10, 28, 0, 0)},
+		{"上周五", at(2026, 10, 9, 0, 0)},
+		{"下个月", at(2026, 11, 14, 0, 0)},
+		{"明年", at(2027, 10, 14, 0, 0)},
+		{"3天后", at(2026, 10, 17, 10, 0)},
+		{"三天后", at(2026, 10, 17, 10, 0)},
+		{"一周后", at(2026, 10, 21, 10, 0)},
+		{"两个小时后", at(2026, 10, 14, 12, 0)},
+		{"半小时后", at(2026, 10, 14, 10, 30)},
+		{"一个半小时后", at(2026, 10, 14, 11, 30)},
+		{"一天半后", at(2026, 10, 15, 22, 0)},
+		{"十分钟之后", at(2026, 10, 14, 10, 10)},
+		{"一个月后", at(2026, 11, 14, 10, 0)},
+		{"11月1日", at(2026, 11, 1, 0, 0)},
+		{"十一月一号", at(2026, 11, 1, 0, 0)},
+		{"1月2日", at(2027, 1, 2, 0, 0)},
+		{"2027年1月2日", at(2027, 1, 2, 0, 0)},
+		{"11月1日下午3点", at(2026, 11, 1, 15, 0)},
+	})
+}
+
+// TestParseWeekBoundary 周日时 "下周一" 是明天：中文按周一开始的自然周计算
+func TestParseWeekBoundary(t *testing.T) {
+	runParseCases(t, sunday, []parseCase{
+		{"下周一", at(2026, 10, 19, 0, 0)},
+		{"下周日", at(2026, 10, 25, 0, 0)},
+		{"周一", at(2026, 10, 19, 0, 0)},
+		{"这周一", at(2026, 10, 12, 0, 0)},
+		{"本周日", at(2026, 10, 18, 0, 0)},
+		{"周日", at(2026, 10, 18, 0, 0)},
+		{"上周日", at(2026, 10, 11, 0, 0)},
+		{"上周一", at(2026, 10, 5, 0, 0)},
+		{"下下周一", at(2026, 10, 26, 0, 0)},
+		{"next monday", at(2026, 10, 19, 0, 0)},
+		{"next sunday", at(2026, 10, 25, 0, 0)},
+		{"sunday", at(2026, 10, 18, 0, 0)},
+		{"last sunday", at(2026, 10, 11, 0, 0)},
+	})
+
+	// 周一时 "下周一" 是一周之后
+	monday := time.Date(2026, 10, 19, 10, 0, 0, 0, cst)
+	runParseCases(t, monday, []parseCase{
+		{"下周一", at(2026, 10, 26, 0, 0)},
+		{"这周日", at(2026, 10, 25, 0, 0)},
+		{"上周日", at(2026, 10, 18, 0, 0)},
+	})
+}
+
+func TestParseTimeZones(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+
+	tests := []struct {
+		expr string
+		now  time.Time
+		want time.Time
+	}{
+		// 末尾的时区决定日历和时刻
+		{"tomorrow 9am UTC", wednesday, time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)},
+		{"today gmt", wednesday, time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)},
+		{"9am +09:00", wednesday, time.Date(2026, 10, 15, 9, 0, 0, 0, time.FixedZone("", 9*3600))},
+		// -05:00 此时是 10-13 21:00，9pm 已到，顺延到第二天
+		{"9pm -0500", wednesday, time.Date(2026, 10, 14, 21, 0, 0, 0, time.FixedZone("", -5*3600))},
+		{"11pm -0500", wednesday, time.Date(2026, 10, 13, 23, 0, 0, 0, time.FixedZone("", -5*3600))},
+		// 纽约此时还是 10-13 晚上，明天是 10-14
+		{"明天 9am America/New_York", wednesday, time.Date(2026, 10, 14, 9, 0, 0, 0, newYork)},
+		{"nov 1 9am america/new_york", wednesday, time.Date(2026, 11, 1, 9, 0, 0, 0, newYork)},
+		// +08:00 凌晨 2 点时 UTC 仍是前一天
+		{"today utc", time.Date(2026, 10, 14, 2, 0, 0, 0, cst), time.Date(2026, 10, 13, 0, 0, 0, 0, time.UTC)},
+		{"明天 UTC", time.Date(2026, 10, 14, 2, 0, 0, 0, cst), time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)},
+		// 相对时长与时区无关
+		{"in 2 hours utc", wednesday, wednesday.Add(2 * time.Hour)},
+		// 完整的 RFC3339 时间保留原时区
+		{"2026-10-20T08:00:00Z", wednesday, time.Date(2026, 10, 20, 8, 0, 0, 0, time.UTC)},
+		{"2026-10-20T08:00:00+09:00", wednesday, time.Date(2026, 10, 20, 8, 0, 0, 0, time.FixedZone("", 9*3600))},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.expr, tt.now)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.expr, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("Parse(%q) = %s, want %s", tt.expr, got.Format(time.RFC3339), tt.want.Format(time.RFC3339))
+		}
+	}
+}
+
+func TestParseWithOptions(t *testing.T) {
+	tests := []struct {
+		expr string
+		opts Options
+		want time.Time
+	}{
+		{"tomorrow", Options{DefaultClock: 9 * time.Hour}, at(2026, 10, 15, 9, 0)},
+		{"下周一", Options{DefaultClock: 18 * time.Hour}, at(2026, 10, 19, 18, 0)},
+		// 指定时刻时不使用 DefaultClock
+		{"tomorrow 3pm", Options{DefaultClock: 9 * time.Hour}, at(2026, 10, 15, 15, 0)},
+		// 相对时长不使用 DefaultClock
+		{"in 3 days", Options{DefaultClock: 9 * time.Hour}, at(2026, 10, 17, 10, 0)},
+		// Location 覆盖 now 的时区
+		{"today", Options{Location: time.UTC}, time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)},
+		{"tomorrow 9am", Options{Location: time.UTC}, time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)},
+		// 表达式中的时区优先于 Location
+		{"today +08:00", Options{Location: time.UTC}, at(2026, 10, 14, 0, 0)},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseWithOptions(tt.expr, wednesday, tt.opts)
+		if err != nil {
+			t.Errorf("ParseWithOptions(%q): %v", tt.expr, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseWithOptions(%q) = %s, want %s", tt.expr, got.Format(time.RFC3339), tt.want.Format(time.RFC3339))
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"   ",
+		"someday",
+		"明天 后天",
+		"tomorrow friday",
+		"3pm 4pm",
+		"morning 下午",
+		"feb 30",
+		"2026-13-01",
+		"13月1日",
+		"25:00",
+		"in 3 days nov 1",
+		"tomorrow Mars/Olympus",
+		"tomorrow 9am extra",
+	} {
+		if got, err := Parse(expr, wednesday); err == nil {
+			t.Errorf("Parse(%q) = %s, want error", expr, got.Format(time.RFC3339))
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"30m", 30 * time.Minute},
+		{"45min", 45 * time.Minute},
+		{"2h", 2 * time.Hour},
+		{"1h30m", 90 * time.Minute},
+		{"1d", 24 * time.Hour},
+		{"1d12h", 36 * time.Hour},
+		{"1w", 7 * 24 * time.Hour},
+		{"1w2d", 9 * 24 * time.Hour},
+		{" 2H ", 2 * time.Hour},
+		{"90s", 90 * time.Second},
+	}
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.value)
+		if err != nil {
+			t.Errorf("ParseDuration(%q): %v", tt.value, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseDuration(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+
+	for _, value := range []string{"", "0m", "abc", "1x", "h", "1h 30m", "-1h"} {
+		if _, err := ParseDuration(value); err == nil {
+			t.Errorf("ParseDuration(%q) succeeded, want error", value)
+		}
+	}
+}
+
+func TestCNNumber(t *testing.T) {
+	tests := map[string]int{
+		"0": 0, "7": 7, "23": 23,
+		"零": 0, "一": 1, "两": 2, "十": 10, "十一": 11, "二十": 20, "二十三": 23, "九十九": 99,
+	}
+	for input, want := range tests {
+		if got := cnNumber(input); got != want {
+			t.Errorf("cnNumber(%q) = %d, want %d", input, got, want)
+		}
+	}
+}
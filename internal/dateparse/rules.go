@@ -0,0 +1,365 @@
+package dateparse
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// cn 匹配阿拉伯数字或中文数字
+const cn = `([0-9零〇一二两三四五六七八九十]+)`
+
+var rules = []rule{
+	// ---- 绝对日期 ----
+	{re(`^(\d{4})[-/.](\d{1,2})[-/.](\d{1,2})t?`), func(p *parser, m []string) error {
+		return p.setYMD(atoi(m[1]), atoi(m[2]), atoi(m[3]), false)
+	}},
+	{re(`^(\d{4})年` + cn + `月` + cn + `[日号]`), func(p *parser, m []string) error {
+		return p.setYMD(atoi(m[1]), cnNumber(m[2]), cnNumber(m[3]), false)
+	}},
+	{re(`^` + cn + `月` + cn + `[日号]`), func(p *parser, m []string) error {
+		return p.setYMD(p.now.Year(), cnNumber(m[1]), cnNumber(m[2]), true)
+	}},
+	{re(`^(` + monthPattern + `)[a-z]*\.? (\d{1,2})(?:st|nd|rd|th)?(?: (\d{4}))?\b`), func(p *parser, m []string) error {
+		return p.setMonthDay(m[1], atoi(m[2]), m[3])
+	}},
+	{re(`^(\d{1,2})(?:st|nd|rd|th)? (` + monthPattern + `)[a-z]*\.?(?: (\d{4}))?\b`), func(p *parser, m []string) error {
+		return p.setMonthDay(m[2], atoi(m[1]), m[3])
+	}},
+
+	// ---- 时刻 ----
+	{re(`^(?:at )?(\d{1,2}):(\d{2})(?::\d{2})? ?(am|pm)?\b`), func(p *parser, m []string) error {
+		return p.setClock(meridiem(atoi(m[1]), m[3]), atoi(m[2]))
+	}},
+	{re(`^(?:at )?(\d{1,2}) ?(am|pm)\b`), func(p *parser, m []string) error {
+		return p.setClock(meridiem(atoi(m[1]), m[2]), 0)
+	}},
+	{re(`^(?:at )?noon\b`), func(p *parser, m []string) error {
+		return p.setClock(12, 0)
+	}},
+	{re(`^(?:at )?midnight\b`), func(p *parser, m []string) error {
+		return p.setClock(0, 0)
+	}},
+	{re(`^` + cn + `[点點时](半|一刻|三刻|整|钟|` + cn + `分?)?`), func(p *parser, m []string) error {
+		minute := 0
+		switch m[2] {
+		case "半":
+			minute = 30
+		case "一刻":
+			minute = 15
+		case "三刻":
+			minute = 45
+		case "", "整", "钟":
+		default:
+			minute = cnNumber(m[3])
+		}
+		return p.setClock(cnNumber(m[1]), minute)
+	}},
+
+	// ---- 时段 ----
+	{re(`^(?:this |in the )?(morning|afternoon|evening|night)\b`), func(p *parser, m []string) error {
+		return p.setPeriod(englishPeriods[m[1]])
+	}},
+	{re(`^(凌晨|清晨|早上|早晨|上午|中午|下午|傍晚|晚上|夜里|夜晚)`), func(p *parser, m []string) error {
+		return p.setPeriod(chinesePeriods[m[1]])
+	}},
+
+	// ---- 相对日期 ----
+	{re(`^now\b`), func(p *parser, m []string) error {
+		p.hasRelative = true
+		return nil
+	}},
+	{re(`^(?:the )?day after tomorrow\b`), func(p *parser, m []string) error {
+		return p.setDate(p.today().AddDate(0, 0, 2))
+	}},
+	{re(`^(today|tonight|tomorrow|tmrw|tmr|yesterday)\b`), func(p *parser, m []string) error {
+		offsets := map[string]int{"today": 0, "tonight": 0, "tomorrow": 1, "tmrw": 1, "tmr": 1, "yesterday": -1}
+		if m[1] == "tonight" {
+			if err := p.setPeriod(periodEvening); err != nil {
+				return err
+			}
+		}
+		return p.setDate(p.today().AddDate(0, 0, offsets[m[1]]))
+	}},
+	{re(`^(今天|今日|今晚|明天|明日|明早|明晚|大后天|后天|昨天|前天)`), func(p *parser, m []string) error {
+		offsets := map[string]int{
+			"今天": 0, "今日": 0, "今晚": 0, "明天": 1, "明日": 1, "明早": 1, "明晚": 1,
+			"后天": 2, "大后天": 3, "昨天": -1, "前天": -2,
+		}
+		switch m[1] {
+		case "今晚", "明晚":
+			if err := p.setPeriod(periodEvening); err != nil {
+				return err
+			}
+		case "明早":
+			if err := p.setPeriod(periodMorning); err != nil {
+				return err
+			}
+		}
+		return p.setDate(p.today().AddDate(0, 0, offsets[m[1]]))
+	}},
+	{re(`^(?:(next|this|last) )?(` + weekdayPattern + `)\b`), func(p *parser, m []string) error {
+		return p.setDate(englishWeekday(p.today(), weekdayNames[m[2]], m[1]))
+	}},
+	{re(`^next (week|month|year)\b`), func(p *parser, m []string) error {
+		return p.setDate(addUnit(p.today(), m[1], 1))
+	}},
+	{re(`^(下下|下个|下|这个|这|本|上个|上)?(?:周|星期|礼拜)([一二三四五六日天1-7])`), func(p *parser, m []string) error {
+		return p.setDate(chineseWeekday(p.today(), chineseWeekdays[m[2]], m[1]))
+	}},
+	{re(`^(下个|下)(?:周|星期|礼拜)`), func(p *parser, m []string) error {
+		return p.setDate(p.today().AddDate(0, 0, 7))
+	}},
+	{re(`^(下个月|下月|明年)`), func(p *parser, m []string) error {
+		if m[1] == "明年" {
+			return p.setDate(p.today().AddDate(1, 0, 0))
+		}
+		return p.setDate(p.today().AddDate(0, 1, 0))
+	}},
+
+	// ---- 相对时长 ----
+	{re(`^in (\d+|an?|one|two|three|four|five|six|seven|eight|nine|ten|half an) (` + unitPattern + `)s?\b`), func(p *parser, m []string) error {
+		return p.addRelative(englishAmount(m[1]), m[2])
+	}},
+	{re(`^(\d+|an?|one|two|three|four|five|six|seven|eight|nine|ten) (` + unitPattern + `)s? (?:from now|later)\b`), func(p *parser, m []string) error {
+		return p.addRelative(englishAmount(m[1]), m[2])
+	}},
+	{re(`^(?:in )?(\d+(?:w|d|h|min|m)(?:\d+(?:d|h|min|m))*)\b`), func(p *parser, m []string) error {
+		d, err := ParseDuration(m[1])
+		if err != nil {
+			return err
+		}
+		p.hasRelative = true
+		p.relDuration += d
+		return nil
+	}},
+	{re(`^(半|` + cn + `)(?:个)?(半)?(分钟|分|小时|钟头|天|日|周|星期|礼拜|月|年)(半)?(?:后|之后|以后)`), func(p *parser, m []string) error {
+		// 半小时后、一个半小时后、一天半后
+		amount := 0.5
+		if m[1] != "半" {
+			amount = float64(cnNumber(m[2]))
+		}
+		if m[3] == "半" || m[5] == "半" {
+			amount += 0.5
+		}
+		unit := map[string]string{
+			"分钟": "minute", "分": "minute", "小时": "hour", "钟头": "hour",
+			"天": "day", "日": "day", "周": "week", "星期": "week", "礼拜": "week",
+			"月": "month", "年": "year",
+		}[m[4]]
+		return p.addRelative(amount, unit)
+	}},
+
+	// ---- 连接词 ----
+	{re(`^(?:at|on|by|the|of)\b`), func(p *parser, m []string) error { return nil }},
+	{re(`^(?:的|在|于)`), func(p *parser, m []string) error { return nil }},
+}
+
+// re 编译规则。输入已经过 normalize，空白统一为单个空格
+func re(pattern string) *regexp.Regexp {
+	return regexp.MustCompile(pattern)
+}
+
+const monthPattern = `jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec`
+
+var monthNames = map[string]time.Month{
+	"jan": time.January, "feb": time.February, "mar": time.March, "apr": time.April,
+	"may": time.May, "jun": time.June, "jul": time.July, "aug": time.August,
+	"sep": time.September, "oct": time.October, "nov": time.November, "dec": time.December,
+}
+
+const weekdayPattern = `monday|mon|tuesday|tues|tue|wednesday|wed|thursday|thurs|thur|thu|friday|fri|saturday|sat|sunday|sun`
+
+var weekdayNames = map[string]time.Weekday{
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tues": time.Tuesday, "tue": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thurs": time.Thursday, "thur": time.Thursday, "thu": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+	"sunday": time.Sunday, "sun": time.Sunday,
+}
+
+var chineseWeekdays = map[string]time.Weekday{
+	"一": time.Monday, "二": time.Tuesday, "三": time.Wednesday, "四": time.Thursday,
+	"五": time.Friday, "六": time.Saturday, "日": time.Sunday, "天": time.Sunday,
+	"1": time.Monday, "2": time.Tuesday, "3": time.Wednesday, "4": time.Thursday,
+	"5": time.Friday, "6": time.Saturday, "7": time.Sunday,
+}
+
+const unitPattern = `minute|min|hour|hr|day|week|month|year`
+
+var englishPeriods = map[string]period{
+	"morning": periodMorning, "afternoon": periodAfternoon,
+	"evening": periodEvening, "night": periodEvening,
+}
+
+var chinesePeriods = map[string]period{
+	"凌晨": periodEarlyMorning, "清晨": periodEarlyMorning,
+	"早上": periodMorning, "早晨": periodMorning, "上午": periodMorning,
+	"中午": periodNoon, "下午": periodAfternoon,
+	"傍晚": periodEvening, "晚上": periodEvening, "夜里": periodEvening, "夜晚": periodEvening,
+}
+
+func (p *parser) setYMD(year, month, day int, rollYear bool) error {
+	if month < 1 || month > 12 {
+		return fmt.Errorf("invalid month %d", month)
+	}
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, p.loc)
+	if date.Day() != day {
+		return fmt.Errorf("invalid day %d", day)
+	}
+	if err := p.setDate(date); err != nil {
+		return err
+	}
+	p.rollYear = rollYear
+	return nil
+}
+
+func (p *parser) setMonthDay(monthName string, day int, year string) error {
+	month := int(monthNames[monthName[:3]])
+	if year != "" {
+		return p.setYMD(atoi(year), month, day, false)
+	}
+	return p.setYMD(p.now.Year(), month, day, true)
+}
+
+func (p *parser) addRelative(amount float64, unit string) error {
+	p.hasRelative = true
+	switch unit {
+	case "minute", "min":
+		p.relDuration += time.Duration(amount * float64(time.Minute))
+	case "hour", "hr":
+		p.relDuration += time.Duration(amount * float64(time.Hour))
+	case "day":
+		p.relDays += int(amount)
+		p.relDuration += time.Duration((amount - float64(int(amount))) * float64(24*time.Hour))
+	case "week":
+		p.relDays += int(amount * 7)
+	case "month":
+		p.relMonths += int(amount)
+	case "year":
+		p.relMonths += int(amount * 12)
+	default:
+		return fmt.Errorf("unknown unit %q", unit)
+	}
+	return nil
+}
+
+// englishWeekday next: 今天之后的下一个；this/无修饰: 今天或之后最近的一个；last: 今天之前最近的一个
+func englishWeekday(today time.Time, weekday time.Weekday, modifier string) time.Time {
+	switch modifier {
+	case "last":
+		days := (int(today.Weekday()) - int(weekday) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return today.AddDate(0, 0, -days)
+	case "next":
+		days := (int(weekday) - int(today.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return today.AddDate(0, 0, days)
+	default:
+		days := (int(weekday) - int(today.Weekday()) + 7) % 7
+		return today.AddDate(0, 0, days)
+	}
+}
+
+// chineseWeekday 按周一开始的自然周计算：下周一是下一个自然周的周一，
+// 没有修饰的 "周五" 取今天或之后最近的一个
+func chineseWeekday(today time.Time, weekday time.Weekday, modifier string) time.Time {
+	if modifier == "" {
+		return englishWeekday(today, weekday, "")
+	}
+
+	monday := today.AddDate(0, 0, -mondayIndex(today.Weekday()))
+	target := monday.AddDate(0, 0, mondayIndex(weekday))
+	switch modifier {
+	case "下", "下个":
+		return target.AddDate(0, 0, 7)
+	case "下下":
+		return target.AddDate(0, 0, 14)
+	case "上", "上个":
+		return target.AddDate(0, 0, -7)
+	default: // 这、这个、本
+		return target
+	}
+}
+
+func mondayIndex(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
+
+func addUnit(t time.Time, unit string, n int) time.Time {
+	switch unit {
+	case "week":
+		return t.AddDate(0, 0, 7*n)
+	case "month":
+		return t.AddDate(0, n, 0)
+	default:
+		return t.AddDate(n, 0, 0)
+	}
+}
+
+func meridiem(hour int, suffix string) int {
+	switch suffix {
+	case "am":
+		if hour == 12 {
+			return 0
+		}
+	case "pm":
+		if hour < 12 {
+			return hour + 12
+		}
+	}
+	return hour
+}
+
+func englishAmount(word string) float64 {
+	words := map[string]float64{
+		"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+		"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10, "half an": 0.5,
+	}
+	if n, ok := words[word]; ok {
+		return n
+	}
+	return float64(atoi(word))
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// cnNumber 解析 0-99 的中文数字或阿拉伯数字，例如 "三"、"十五"、"二十三"、"两"
+func cnNumber(s string) int {
+	if n, err := strconv.Atoi(s); err == nil {
+		return n
+	}
+
+	digits := map[rune]int{
+		'零': 0, '〇': 0, '一': 1, '二': 2, '两': 2, '三': 3, '四': 4,
+		'五': 5, '六': 6, '七': 7, '八': 8, '九': 9,
+	}
+
+	total, current := 0, 0
+	for _, r := range s {
+		if r == '十' {
+			if current == 0 {
+				current = 1
+			}
+			total += current * 10
+			current = 0
+			continue
+		}
+		if d, ok := digits[r]; ok {
+			current = current*10 + d
+		} else if r >= '0' && r <= '9' {
+			current = current*10 + int(r-'0')
+		}
+	}
+	return total + current
+}
@@ -0,0 +1,187 @@
+// Package encryption 任务数据库的静态加密。
+//
+// 密钥由口令通过 Argon2id 派生，数据使用 AES-256-GCM 加密。支持两种模式：
+//
+//   - fields：只加密任务的标题和描述，其余字段仍为明文，数据库可以被多个进程同时使用
+//   - store：加密整个数据库文件，使用时解密到运行时目录中的工作副本，关闭时重新加密
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	ModeFields = "fields"
+	ModeStore  = "store"
+
+	// KDFArgon2id 目前唯一支持的密钥派生算法
+	KDFArgon2id = "argon2id"
+
+	// keySize AES-256
+	keySize = 32
+	// stringPrefix 加密字段的前缀，后面是 base64 编码的 nonce 和密文
+	stringPrefix = "enc:v1:"
+	// checkPlaintext 用于校验口令的固定内容
+	checkPlaintext = "todolist"
+)
+
+var (
+	// ErrWrongPassphrase 口令错误
+	ErrWrongPassphrase = errors.New("wrong passphrase")
+	// ErrLocked 数据库已加密，但没有可用的密钥
+	ErrLocked = errors.New("database is encrypted")
+)
+
+// Header 加密参数，fields 模式保存在数据库的 settings 表中，store 模式保存在加密文件的开头
+type Header struct {
+	Mode    string `json:"mode"`
+	KDF     string `json:"kdf"`
+	Salt    []byte `json:"salt"`
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"` // KiB
+	Threads uint8  `json:"threads"`
+	// Check 用密钥加密的固定内容，解密成功说明口令正确
+	Check string `json:"check"`
+}
+
+// NewHeader 为新的加密数据库生成随机盐并派生密钥
+func NewHeader(mode, passphrase string) (*Header, []byte, error) {
+	if mode != ModeFields && mode != ModeStore {
+		return nil, nil, fmt.Errorf("invalid encryption mode %q: must be %s or %s", mode, ModeFields, ModeStore)
+	}
+	if passphrase == "" {
+		return nil, nil, errors.New("passphrase must not be empty")
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	h := &Header{Mode: mode, KDF: KDFArgon2id, Salt: salt, Time: 3, Memory: 64 * 1024, Threads: 4}
+
+	key, err := h.DeriveKey(passphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := NewCipher(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if h.Check, err = c.EncryptString(checkPlaintext); err != nil {
+		return nil, nil, err
+	}
+	return h, key, nil
+}
+
+// ID 唯一标识使用该密钥的数据库，用于在密钥代理中缓存密钥
+func (h *Header) ID() string {
+	return hex.EncodeToString(h.Salt)
+}
+
+// DeriveKey 由口令派生密钥
+func (h *Header) DeriveKey(passphrase string) ([]byte, error) {
+	if h.KDF != KDFArgon2id {
+		return nil, fmt.Errorf("unsupported key derivation %q", h.KDF)
+	}
+	return argon2.IDKey([]byte(passphrase), h.Salt, h.Time, h.Memory, h.Threads, keySize), nil
+}
+
+// Verify 检查密钥是否正确
+func (h *Header) Verify(key []byte) error {
+	c, err := NewCipher(key)
+	if err != nil {
+		return err
+	}
+	plain, err := c.DecryptString(h.Check)
+	if err != nil || subtle.ConstantTimeCompare([]byte(plain), []byte(checkPlaintext)) != 1 {
+		return ErrWrongPassphrase
+	}
+	return nil
+}
+
+// Cipher AES-256-GCM 加解密
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// NewCipher 使用 32 字节的密钥创建 Cipher
+func NewCipher(key []byte) (*Cipher, error) {
+	if len(key) != keySize {
+		return nil, fmt.Errorf("invalid key size %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Cipher{aead: aead}, nil
+}
+
+// Seal 加密 plain，返回随机 nonce 和密文。aad 为需要认证但不加密的附加数据
+func (c *Cipher) Seal(plain, aad []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return c.aead.Seal(nonce, nonce, plain, aad), nil
+}
+
+// Open 解密 Seal 的结果
+func (c *Cipher) Open(data, aad []byte) ([]byte, error) {
+	if len(data) < c.aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := data[:c.aead.NonceSize()], data[c.aead.NonceSize():]
+	plain, err := c.aead.Open(nil, nonce, ciphertext, aad)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return plain, nil
+}
+
+// EncryptString 加密一个字段，结果为 "enc:v1:" 加 base64。空字符串保持为空
+func (c *Cipher) EncryptString(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	sealed, err := c.Seal([]byte(s), nil)
+	if err != nil {
+		return "", err
+	}
+	return stringPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptString 解密 EncryptString 的结果，没有加密前缀的值原样返回
+func (c *Cipher) DecryptString(s string) (string, error) {
+	encoded, ok := strings.CutPrefix(s, stringPrefix)
+	if !ok {
+		return s, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode encrypted field: %w", err)
+	}
+	plain, err := c.Open(sealed, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// IsEncryptedString 字段是否为 EncryptString 的结果
+func IsEncryptedString(s string) bool {
+	return strings.HasPrefix(s, stringPrefix)
+}
@@ -0,0 +1,138 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// fileMagic 整库加密文件的开头。文件格式：
+//
+//	magic (8) | header 长度 (4, 大端) | header (JSON) | nonce + 密文
+//
+// magic、长度和 header 作为附加数据参与认证，不能被篡改
+var fileMagic = []byte("TODOENC1")
+
+// maxHeaderSize header 的最大长度，防止读取损坏的文件时分配过多内存
+const maxHeaderSize = 64 * 1024
+
+// IsSealedFile 文件是否为整库加密文件，文件不存在时返回 false
+func IsSealedFile(path string) (bool, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	magic := make([]byte, len(fileMagic))
+	if _, err := io.ReadFull(f, magic); err != nil {
+		// 比 magic 还短的文件不是加密文件，例如空数据库
+		return false, nil
+	}
+	return bytes.Equal(magic, fileMagic), nil
+}
+
+// ReadFileHeader 读取整库加密文件的 header
+func ReadFileHeader(path string) (*Header, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	h, _, _, err := parseSealed(data)
+	return h, err
+}
+
+// SealFile 加密 plain 并写入 path。先写入临时文件再重命名，写入中断不会损坏原文件
+func SealFile(path string, h *Header, key, plain []byte) error {
+	headerJSON, err := json.Marshal(h)
+	if err != nil {
+		return fmt.Errorf("failed to encode header: %w", err)
+	}
+
+	var prefix bytes.Buffer
+	prefix.Write(fileMagic)
+	binary.Write(&prefix, binary.BigEndian, uint32(len(headerJSON)))
+	prefix.Write(headerJSON)
+
+	c, err := NewCipher(key)
+	if err != nil {
+		return err
+	}
+	sealed, err := c.Seal(plain, prefix.Bytes())
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write encrypted database: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(prefix.Bytes(), sealed...)); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write encrypted database: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write encrypted database: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write encrypted database: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o600); err != nil {
+		return fmt.Errorf("failed to write encrypted database: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write encrypted database: %w", err)
+	}
+	return nil
+}
+
+// OpenFile 解密整库加密文件，返回 header 和明文
+func OpenFile(path string, key []byte) (*Header, []byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	h, prefix, sealed, err := parseSealed(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c, err := NewCipher(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	plain, err := c.Open(sealed, prefix)
+	if err != nil {
+		return nil, nil, err
+	}
+	return h, plain, nil
+}
+
+// parseSealed 拆分加密文件，返回 header、参与认证的前缀和密文
+func parseSealed(data []byte) (*Header, []byte, []byte, error) {
+	if !bytes.HasPrefix(data, fileMagic) || len(data) < len(fileMagic)+4 {
+		return nil, nil, nil, errors.New("not an encrypted database")
+	}
+	size := binary.BigEndian.Uint32(data[len(fileMagic):])
+	end := len(fileMagic) + 4 + int(size)
+	if size > maxHeaderSize || end > len(data) {
+		return nil, nil, nil, errors.New("corrupted encrypted database header")
+	}
+
+	var h Header
+	if err := json.Unmarshal(data[len(fileMagic)+4:end], &h); err != nil {
+		return nil, nil, nil, fmt.Errorf("corrupted encrypted database header: %w", err)
+	}
+	return &h, data[:end], data[end:], nil
+}
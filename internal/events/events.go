@@ -0,0 +1,108 @@
+package events
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+// Type 事件类型
+type Type string
+
+const (
+	TaskCreated   Type = "task.created"
+	TaskUpdated   Type = "task.updated"
+	TaskCompleted Type = "task.completed"
+	TaskReopened  Type = "task.reopened"
+	TaskDeleted   Type = "task.deleted"
+)
+
+// AllTypes 返回所有任务生命周期事件类型
+func AllTypes() []Type {
+	return []Type{TaskCreated, TaskUpdated, TaskCompleted, TaskReopened, TaskDeleted}
+}
+
+// IsValid 是否为已知的事件类型
+func (t Type) IsValid() bool {
+	for _, known := range AllTypes() {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Event 任务生命周期事件
+type Event struct {
+	Type       Type         `json:"event"`
+	Task       *models.Task `json:"task"`
+	OccurredAt time.Time    `json:"occurred_at"`
+}
+
+// New 创建事件，任务会被复制一份以免订阅者修改原对象
+func New(eventType Type, task *models.Task) Event {
+	var snapshot *models.Task
+	if task != nil {
+		copied := *task
+		snapshot = &copied
+	}
+	return Event{
+		Type:       eventType,
+		Task:       snapshot,
+		OccurredAt: time.Now(),
+	}
+}
+
+// Handler 事件处理函数
+type Handler func(Event)
+
+// Bus 进程内事件总线
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[Type][]Handler
+	wildcard []Handler
+}
+
+// NewBus 创建事件总线
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[Type][]Handler)}
+}
+
+// Subscribe 订阅指定类型的事件
+func (b *Bus) Subscribe(eventType Type, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// SubscribeAll 订阅所有事件
+func (b *Bus) SubscribeAll(handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.wildcard = append(b.wildcard, handler)
+}
+
+// Publish 同步分发事件。单个处理函数 panic 不会影响其他处理函数和调用方。
+func (b *Bus) Publish(event Event) {
+	b.mu.RLock()
+	handlers := make([]Handler, 0, len(b.handlers[event.Type])+len(b.wildcard))
+	handlers = append(handlers, b.handlers[event.Type]...)
+	handlers = append(handlers, b.wildcard...)
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		dispatch(handler, event)
+	}
+}
+
+func dispatch(handler Handler, event Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "event handler for %s panicked: %v\n", event.Type, r)
+		}
+	}()
+	handler(event)
+}
@@ -0,0 +1,160 @@
+// Package focus 实现番茄工作法的专注计时：若干轮专注，每轮之间休息。
+package focus
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/i18n"
+)
+
+// Config 番茄钟配置
+type Config struct {
+	Work   time.Duration
+	Break  time.Duration
+	Rounds int
+}
+
+// DefaultConfig 默认配置：专注 25 分钟，休息 5 分钟，共 4 轮
+func DefaultConfig() Config {
+	return Config{Work: 25 * time.Minute, Break: 5 * time.Minute, Rounds: 4}
+}
+
+// Validate 检查配置是否合法
+func (c Config) Validate() error {
+	if c.Work <= 0 {
+		return fmt.Errorf("work duration must be positive")
+	}
+	if c.Break < 0 {
+		return fmt.Errorf("break duration cannot be negative")
+	}
+	if c.Rounds <= 0 {
+		return fmt.Errorf("rounds must be positive")
+	}
+	return nil
+}
+
+// Round 一轮专注的结果
+type Round struct {
+	Number    int
+	StartedAt time.Time
+	EndedAt   time.Time
+	// Completed 为 false 表示本轮被放弃（Ctrl+C）
+	Completed     bool
+	Interruptions []string
+}
+
+// Runner 运行番茄钟，在 Out 上显示倒计时。
+//
+// Input 每收到一行视为一次中断（内容为中断原因，可以为空），中断只记录不暂停计时；
+// ctx 取消时放弃当前轮次并返回。
+type Runner struct {
+	Config Config
+	Out    io.Writer
+	Input  <-chan string
+	// Label 显示在倒计时前面，通常是任务标题
+	Label string
+	// OnRound 每轮专注结束（完成或放弃）后调用
+	OnRound func(Round) error
+	// OnPhaseEnd 专注或休息结束时调用，用于响铃或桌面通知
+	OnPhaseEnd func(message string)
+}
+
+// Run 依次运行所有轮次，返回完成的轮数。最后一轮结束后不再休息。
+func (r *Runner) Run(ctx context.Context) (int, error) {
+	if err := r.Config.Validate(); err != nil {
+		return 0, err
+	}
+
+	completed := 0
+	for n := 1; n <= r.Config.Rounds; n++ {
+		round := Round{Number: n, StartedAt: time.Now()}
+		title := i18n.T("focus.round", n, r.Config.Rounds)
+		round.Interruptions, round.Completed = r.countdown(ctx, title, r.Config.Work, true)
+		round.EndedAt = time.Now()
+
+		if r.OnRound != nil {
+			if err := r.OnRound(round); err != nil {
+				return completed, err
+			}
+		}
+		if !round.Completed {
+			return completed, ctx.Err()
+		}
+		completed++
+
+		if n == r.Config.Rounds || r.Config.Break == 0 {
+			r.phaseEnd(i18n.T("focus.round_done", n))
+			continue
+		}
+
+		r.phaseEnd(i18n.T("focus.round_break", n, r.Config.Break))
+		if _, ok := r.countdown(ctx, i18n.T("focus.break"), r.Config.Break, false); !ok {
+			return completed, ctx.Err()
+		}
+		r.phaseEnd(i18n.T("focus.break_done"))
+	}
+
+	return completed, nil
+}
+
+// countdown 倒计时 d，返回期间记录的中断以及是否完整走完
+func (r *Runner) countdown(ctx context.Context, title string, d time.Duration, trackInterruptions bool) ([]string, bool) {
+	deadline := time.Now().Add(d)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	var interruptions []string
+	render := func() {
+		remaining := time.Until(deadline).Round(time.Second)
+		if remaining < 0 {
+			remaining = 0
+		}
+		line := fmt.Sprintf("\r%s %s  %s", title, formatClock(remaining), r.Label)
+		if trackInterruptions && len(interruptions) > 0 {
+			line += i18n.T("focus.interruptions", len(interruptions))
+		}
+		// 清除行尾残留字符
+		fmt.Fprint(r.Out, line+"\033[K")
+	}
+
+	render()
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Fprintln(r.Out)
+			return interruptions, false
+		case reason, ok := <-r.Input:
+			if !ok {
+				// 输入已关闭（例如非交互式运行），之后不再记录中断
+				r.Input = nil
+				continue
+			}
+			if trackInterruptions {
+				interruptions = append(interruptions, reason)
+			}
+			render()
+		case <-ticker.C:
+			if !time.Now().Before(deadline) {
+				render()
+				fmt.Fprintln(r.Out)
+				return interruptions, true
+			}
+			render()
+		}
+	}
+}
+
+func (r *Runner) phaseEnd(message string) {
+	if r.OnPhaseEnd != nil {
+		r.OnPhaseEnd(message)
+	}
+}
+
+// formatClock 将剩余时间格式化为 mm:ss
+func formatClock(d time.Duration) string {
+	seconds := int(d / time.Second)
+	return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+}
@@ -0,0 +1,212 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/events"
+)
+
+// DefaultTimeout 单个钩子的默认超时时间
+const DefaultTimeout = 10 * time.Second
+
+// ExitGrace 命令结束时等待钩子的最长时间。超过后命令直接退出，
+// 仍在运行的钩子进程留在后台继续执行，不再受超时限制
+const ExitGrace = time.Second
+
+// Hook 单个钩子配置
+type Hook struct {
+	Event   events.Type `json:"event"`
+	Command string      `json:"command"`
+	Timeout string      `json:"timeout,omitempty"`
+}
+
+// Config 钩子配置文件
+type Config struct {
+	Timeout string `json:"timeout,omitempty"`
+	Hooks   []Hook `json:"hooks"`
+}
+
+// DefaultPath 返回钩子配置文件的默认路径，可通过 TODO_HOOKS 环境变量覆盖
+func DefaultPath() (string, error) {
+	if path := os.Getenv("TODO_HOOKS"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get config directory: %w", err)
+	}
+	return filepath.Join(dir, "todo", "hooks.json"), nil
+}
+
+// LoadConfig 读取钩子配置，文件不存在时返回空配置
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hooks config: %w", err)
+	}
+
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse hooks config %s: %w", path, err)
+	}
+
+	for i, hook := range config.Hooks {
+		if hook.Command == "" {
+			return nil, fmt.Errorf("hook #%d has no command", i+1)
+		}
+		if hook.Event == "" {
+			return nil, fmt.Errorf("hook #%d has no event", i+1)
+		}
+		// 拼错的事件名不会匹配任何事件，钩子永远不会执行
+		if hook.Event != "*" && !hook.Event.IsValid() {
+			return nil, fmt.Errorf("hook #%d has unknown event %q (available: %s, *)", i+1, hook.Event, eventNames())
+		}
+		if _, err := parseTimeout(hook.Timeout); err != nil {
+			return nil, fmt.Errorf("hook #%d: %w", i+1, err)
+		}
+	}
+	if _, err := parseTimeout(config.Timeout); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// eventNames 所有事件类型，逗号分隔
+func eventNames() string {
+	names := make([]string, 0, len(events.AllTypes()))
+	for _, t := range events.AllTypes() {
+		names = append(names, string(t))
+	}
+	return strings.Join(names, ", ")
+}
+
+func parseTimeout(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid timeout %q", value)
+	}
+	return d, nil
+}
+
+// Runner 在事件发生时执行用户脚本
+type Runner struct {
+	config *Config
+	stderr io.Writer
+	wg     sync.WaitGroup
+}
+
+// NewRunner 创建钩子执行器
+func NewRunner(config *Config) *Runner {
+	return &Runner{config: config, stderr: os.Stderr}
+}
+
+// Attach 将执行器订阅到事件总线
+func (r *Runner) Attach(bus *events.Bus) {
+	if len(r.config.Hooks) == 0 {
+		return
+	}
+	bus.SubscribeAll(r.Handle)
+}
+
+// Handle 异步执行匹配事件的钩子。钩子失败或超时只会打印警告，不会影响调用方。
+func (r *Runner) Handle(event events.Event) {
+	for _, hook := range r.config.Hooks {
+		if hook.Event != event.Type && hook.Event != "*" {
+			continue
+		}
+
+		r.wg.Add(1)
+		go func(hook Hook) {
+			defer r.wg.Done()
+			if err := r.run(hook, event); err != nil {
+				fmt.Fprintf(r.stderr, "⚠ hook %q (%s) failed: %v\n", hook.Command, event.Type, err)
+			}
+		}(hook)
+	}
+}
+
+// Wait 最多等待 timeout 让正在执行的钩子结束，全部结束时返回 true。
+// 超时后不会终止钩子进程，卡住的钩子不会拖慢调用方
+func (r *Runner) Wait(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+func (r *Runner) timeoutFor(hook Hook) time.Duration {
+	if d, _ := parseTimeout(hook.Timeout); d > 0 {
+		return d
+	}
+	if d, _ := parseTimeout(r.config.Timeout); d > 0 {
+		return d
+	}
+	return DefaultTimeout
+}
+
+func (r *Runner) run(hook Hook, event events.Event) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+
+	payload, err := json.Marshal(event.Task)
+	if err != nil {
+		return fmt.Errorf("failed to encode task: %w", err)
+	}
+
+	timeout := r.timeoutFor(hook)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := shellCommand(ctx, hook.Command)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = r.stderr
+	cmd.Stderr = r.stderr
+	cmd.WaitDelay = time.Second
+	cmd.Env = append(os.Environ(), "TODO_EVENT="+string(event.Type))
+	if event.Task != nil {
+		cmd.Env = append(cmd.Env, "TODO_TASK_ID="+strconv.FormatInt(event.Task.ID, 10))
+	}
+
+	err = cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("timed out after %s", timeout)
+	}
+	return err
+}
+
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
@@ -0,0 +1,36 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestLoadConfigRejectsUnknownEvent 拼错的事件名报错，而不是让钩子永远不执行
+func TestLoadConfigRejectsUnknownEvent(t *testing.T) {
+	tests := []struct {
+		event string
+		valid bool
+	}{
+		{"task.completed", true},
+		{"*", true},
+		{"task.complete", false},
+		{"task.Created", false},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "hooks.json")
+		config := `{"hooks": [{"event": "` + tt.event + `", "command": "true"}]}`
+		if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := LoadConfig(path)
+		switch {
+		case tt.valid && err != nil:
+			t.Errorf("event %q: LoadConfig: %v", tt.event, err)
+		case !tt.valid && (err == nil || !strings.Contains(err.Error(), tt.event)):
+			t.Errorf("event %q: err = %v, want an unknown event error", tt.event, err)
+		}
+	}
+}
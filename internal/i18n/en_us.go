@@ -0,0 +1,92 @@
+package i18n
+
+// enUS 英文消息目录。以 "cmd." 和 "flag." 开头的键用于替换命令帮助，
+// 中文帮助直接写在命令定义中，不在 zhCN 里重复
+var enUS = map[string]string{
+	"priority.1":     "Low",
+	"priority.2":     "Medium",
+	"priority.3":     "High",
+	"priority.4":     "Urgent",
+	"category.work":  "Work",
+	"category.study": "Study",
+	"category.life":  "Life",
+	"category.other": "Other",
+
+	"field.id":          "ID",
+	"field.title":       "Title",
+	"field.status":      "Status",
+	"field.category":    "Category",
+	"field.project":     "Project",
+	"field.priority":    "Priority",
+	"field.state":       "State",
+	"field.created":     "Created",
+	"field.updated":     "Updated",
+	"field.completed":   "Completed",
+	"field.deferred":    "Deferred until",
+	"field.due":         "Due",
+	"field.due_short":   "Due",
+	"field.tags":        "Tags",
+	"field.estimate":    "Estimate",
+	"field.blocked_by":  "Waiting on",
+	"field.description": "Description",
+	"field.progress":    "Progress",
+	"task.overdue":      " (overdue)",
+	"task.none":         "No tasks",
+	"task.parsed":       "Parsed:",
+	"task.total":        "Total: %d tasks",
+	"task.blocked":      ", %d waiting on prerequisites (⊘)",
+
+	"column.unknown": "unknown column %q, available columns: %s",
+	"column.empty":   "at least one column is required, available columns: %s",
+
+	"stats.title":           "📊 Statistics",
+	"stats.total":           "📋 Total tasks: %d",
+	"stats.completed":       "✓ Completed: %d",
+	"stats.pending":         "○ Pending: %d",
+	"stats.rate":            "📈 Completion rate: %.1f%%",
+	"stats.by_state":        "🗂  By workflow state:",
+	"stats.by_category":     "📁 By category:",
+	"stats.by_priority":     "⚡ Pending tasks by priority:",
+	"stats.time":            "⏱  Time tracked: %s in total",
+	"stats.remaining":       "📐 Remaining effort: %s",
+	"stats.focus":           "🍅 Pomodoros: %d completed",
+	"stats.focus_abandoned": ", %d abandoned",
+	"stats.focus_summary":   ", %d interruptions, %s focused",
+	"stats.group_category":  "By category",
+	"stats.group_project":   "By project",
+	"stats.group_recent":    "Recent days",
+
+	"agent.greeting":      "I'm your to-do assistant and can help you manage your tasks.",
+	"agent.examples":      "You can ask me:",
+	"agent.example_list":  "• 'list' or 'show all tasks'",
+	"agent.example_stats": "• 'stats' or 'give me a summary'",
+	"agent.example_add":   "• 'add a task: write the weekly report'",
+	"agent.example_done":  "• 'complete task 1'",
+	"agent.example_find":  "• 'find tasks about meetings'",
+	"agent.example_free":  "• or just describe what you want in plain language",
+	"agent.exit_hint":     "Type 'exit' or 'quit' to leave.",
+	"agent.tool_call":     "🔧 Calling tool: %s...",
+
+	"webhook.none":            "No webhook subscriptions",
+	"webhook.all_events":      "all events",
+	"webhook.no_filter":       "none",
+	"webhook.summary":         "     events: %s | filter: %s",
+	"webhook.no_deliveries":   "No deliveries",
+	"webhook.inactive":        " (disabled)",
+	"webhook.column_event":    "Event",
+	"webhook.column_status":   "Status",
+	"webhook.column_attempts": "Attempts",
+	"webhook.column_detail":   "Next retry / error",
+
+	"reminder.none":         "No reminders",
+	"reminder.task_missing": "(task not found)",
+	"reminder.no_due":       "no due date",
+	"reminder.at":           "at time",
+	"reminder.before_due":   "%s before due",
+	"reminder.snoozed":      ", snoozed",
+	"reminder.line":         "[%d] task %d %s | %s | %s",
+	"reminder.fired":        "%s | fired %s",
+
+	"project.none":     "No projects",
+	"project.archived": "archived",
+}
@@ -0,0 +1,471 @@
+package i18n
+
+// enUSHelp 英文命令帮助。键为 "cmd.<命令路径>.short/long" 和 "flag.<命令路径>.<参数名>"，
+// 命令路径以 "." 分隔，根命令为 "root"
+var enUSHelp = map[string]string{
+	"cmd.root.short": "📋 TodoList - a smart to-do manager",
+	"cmd.root.long": `TodoList is a command-line to-do manager with a built-in AI agent.
+
+Use the regular CLI commands or talk to the AI agent in chat mode.`,
+	"flag.root.db":        "database file path (defaults to the current workspace's database)",
+	"flag.root.lang":      "interface language (zh-CN/en-US), defaults to TODO_LANG or LANG",
+	"flag.root.profile":   "use a profile from the config files, defaults to TODO_PROFILE",
+	"flag.root.workspace": "workspace for this command, defaults to the workspace config key",
+
+	"cmd.add.short": "Add a task",
+	"cmd.add.long": `Add a new task. The title is required; description, category and priority are optional.
+
+The title supports quick-add syntax (disable with --raw):
+  !3 or !!!      priority
+  #work          category (work/study/life/other); other #words stay in the title
+  +release       tag, must not contain commas
+  @launch        project
+  due:fri        due date; quote multi-word values or use underscores, e.g. due:next_friday
+  snooze:3d      snooze until
+  \#1            kept in the title as is
+
+  todo add "Prepare demo !3 #work +release due:fri"
+  todo add "写周报 #工作 !!! due:周五下午"
+
+Flags take precedence over the title syntax. --due and --snooze accept natural language, e.g.:
+  todo add "Weekly report" --due friday
+  todo add "Prepare demo" --due "tomorrow 3pm"
+  todo add "Renew domain" --due 2026-11-01 --snooze "next monday"`,
+	"flag.add.category":    "task category (work/study/life/other), defaults to the defaults.category config key",
+	"flag.add.description": "task description",
+	"flag.add.dry-run":     "show the parsed task without saving it",
+	"flag.add.due":         "due date (e.g. tomorrow 5pm, next friday, 2026-11-01)",
+	"flag.add.estimate":    "effort estimate as a duration or story points (e.g. 2h, 1h30m, 3pt)",
+	"flag.add.priority":    "priority (1:low 2:medium 3:high 4:urgent), defaults to the defaults.priority config key",
+	"flag.add.project":     "project (name or ID)",
+	"flag.add.raw":         "do not parse quick-add syntax in the title",
+	"flag.add.snooze":      "hide the task until this time (e.g. 3d, next monday)",
+
+	"cmd.agenda.short": "Show overdue tasks and tasks due today and in the coming days",
+	"cmd.agenda.long": `List pending tasks by due day, with overdue tasks first.
+
+  todo agenda              # today and the next 7 days
+  todo agenda -n 14 -P launch
+  todo agenda --json       # JSON output for scripts`,
+	"flag.agenda.all":     "include snoozed tasks",
+	"flag.agenda.days":    "number of days after today to show",
+	"flag.agenda.json":    "output JSON",
+	"flag.agenda.project": "only show tasks in this project (name or ID)",
+
+	"cmd.backup.short": "Back up the database",
+	"cmd.backup.long": `Back up the database with the SQLite online backup API; other commands can keep reading and writing meanwhile.
+
+Backups go to the backups directory next to the database with a timestamp in the file name, and only the newest --keep manual backups are kept.
+Schema upgrades, restores and 'todo doctor --fix' make automatic backups first; those are not rotated.
+
+  todo backup                      # back up and keep the newest 10
+  todo backup --keep 30
+  todo backup -o ~/todo-backup.db  # back up to a specific file
+  todo backup --list               # list existing backups`,
+	"flag.backup.dir":    "backup directory, defaults to backups next to the database",
+	"flag.backup.keep":   "number of manual backups to keep",
+	"flag.backup.list":   "list existing backups",
+	"flag.backup.output": "back up to this file, not rotated",
+
+	"cmd.board.short":    "Show tasks as a kanban board",
+	"cmd.board.long":     "Show tasks in one column per workflow state. Snoozed tasks are hidden unless --all is given. The board width follows the terminal or the COLUMNS environment variable.",
+	"flag.board.all":     "include snoozed tasks",
+	"flag.board.project": "only show tasks in this project (name or ID)",
+
+	"cmd.calendar.short": "Show due tasks on a month calendar",
+	"cmd.calendar.long": `Show a month calendar with the number of tasks due each day:
+  !3  has overdue tasks
+  •2  has pending tasks
+  ✓1  all completed
+
+  todo calendar                    # this month
+  todo calendar --month 2026-11
+  todo calendar --month "next month" --json`,
+	"flag.calendar.json":    "output JSON",
+	"flag.calendar.month":   "month to show, defaults to this month",
+	"flag.calendar.project": "only show tasks in this project (name or ID)",
+
+	"cmd.chat.short": "Chat with the AI agent",
+	"cmd.chat.long": `Start the interactive AI agent.
+
+Manage your tasks by talking to the assistant in plain language.
+
+Examples:
+• "show all pending tasks"
+• "add a task: prepare the project demo"
+• "complete task 3"
+• "which tasks are work related?"
+• "give me a summary"
+
+Environment variables:
+• QWEN_API_KEY - Qwen API key (required)
+• QWEN_API_BASE - API base URL (optional, default: https://dashscope.aliyuncs.com/compatible-mode/v1)
+• QWEN_MODEL - model name (optional, default: qwen-plus)`,
+
+	"cmd.complete.short":       "Mark a task as completed or pending",
+	"cmd.complete.long":        "Mark a task as completed, or as pending again with -u. Not restricted by the board workflow's transitions; use 'todo move' to follow them.",
+	"flag.complete.force":      "ignore unfinished prerequisites",
+	"flag.complete.uncomplete": "mark as pending",
+
+	"cmd.config.short": "Show and change configuration",
+	"cmd.config.long": `Show and change configuration.
+
+Configuration is merged from lowest to highest precedence: built-in defaults < user config (~/.config/todo/config.yaml)
+< project config (.todo.yaml, searched upward from the current directory) < profile < environment variables < flags.
+db, llm.provider, llm.api_key and llm.base_url in a project config only apply to directories listed in trusted_projects.
+
+Use --profile or TODO_PROFILE to select a group of settings under profiles in the config files, e.g.:
+  todo config set db ~/todo/work.db --profile work
+  todo --profile work list`,
+	"cmd.config.get.short":  "Print the effective value of a config key",
+	"cmd.config.list.short": "List effective configuration and where each value comes from",
+	"cmd.config.set.short":  "Change a config key",
+	"cmd.config.set.long": `Change a config key, written to the user config file by default. Use --local to write ./.todo.yaml
+and --profile to write a profile, which is created if missing. An empty value removes the key.`,
+	"flag.config.set.local": "write .todo.yaml in the current directory",
+
+	"cmd.daemon.short": "Run the reminder daemon",
+	"cmd.daemon.long": `Run the reminder daemon, which sends notifications when reminders are due.
+
+Notification channels (--notify, comma separated):
+• stdout  - print to standard output
+• bell    - ring the terminal bell
+• desktop - desktop notification (notify-send / osascript)
+• webhook - POST JSON to --webhook-url
+
+Fired reminders are recorded immediately, so restarting the daemon does not notify twice.
+The daemon also delivers due retries from the webhook outbox.`,
+	"flag.daemon.interval":    "maximum polling interval",
+	"flag.daemon.notify":      "notification channels (stdout/bell/desktop/webhook)",
+	"flag.daemon.webhook-url": "URL for the webhook channel",
+
+	"cmd.decrypt.short": "Decrypt the database",
+	"cmd.decrypt.long": `Decrypt the database back into a plain SQLite database. Requires the passphrase set when encrypting.
+
+Keys cached by the key agent are no longer used afterwards; clear them with 'todo lock'.`,
+
+	"cmd.delete.short": "Delete a task",
+	"cmd.delete.long":  "Delete a task by ID or by searching for a keyword. Asks for confirmation unless -y is given.",
+	"flag.delete.yes":  "skip confirmation",
+
+	"cmd.depend.short": "Manage task dependencies",
+	"cmd.depend.long": `Set a task's prerequisites: until they are completed the task is waiting (⊘) and cannot be completed.
+
+Examples:
+  todo depend 5 --on 3          # task 5 waits for task 3
+  todo depend 5 --on 3,4        # depend on several tasks
+  todo depend 5 --on 3 -r       # remove the dependency
+  todo depend 5                 # show prerequisites and dependents of task 5
+
+Dependencies that would form a cycle are rejected.`,
+	"flag.depend.on":     "prerequisite task IDs, comma separated",
+	"flag.depend.remove": "remove the dependencies given by --on",
+
+	"cmd.doctor.short": "Check the database",
+	"cmd.doctor.long": `Check the database and suggest repairs:
+  - PRAGMA integrity_check
+  - schema version
+  - missing indexes
+  - dependencies, reminders, time entries and focus sessions that reference missing tasks, tasks that reference
+    missing projects, and webhook deliveries that reference missing subscriptions
+
+The database is opened read-only for the check: the schema is not upgraded and nothing is written, so it also works on a damaged database.
+Use --fix to upgrade the schema, rebuild indexes and clean up those rows; the database is backed up first. Exits non-zero when problems are found.`,
+	"flag.doctor.fix":  "repair what can be repaired, after backing up",
+	"flag.doctor.json": "output JSON",
+
+	"cmd.edit.short": "Edit a task",
+	"cmd.edit.long": `Change a task's title, description, category, priority, due date, estimate, project or tags. Only the given fields are changed.
+
+  todo edit 5 --estimate 3h
+  todo edit 5 --due "next friday" -p 3
+  todo edit 5 --due none --estimate none   # clear the due date and estimate
+  todo edit 5 --project none               # remove from its project`,
+	"flag.edit.category":    "task category (work/study/life/other)",
+	"flag.edit.description": "task description",
+	"flag.edit.due":         "due date, none to clear",
+	"flag.edit.estimate":    "effort estimate (e.g. 2h, 3pt), none to clear",
+	"flag.edit.priority":    "priority (1:low 2:medium 3:high 4:urgent)",
+	"flag.edit.project":     "project (name or ID), none to remove from its project",
+	"flag.edit.tags":        "comma-separated tags replacing the current ones, none to clear",
+	"flag.edit.title":       "new title",
+
+	"cmd.encrypt.short": "Encrypt the database",
+	"cmd.encrypt.long": `Encrypt the database with a passphrase. The key is derived with Argon2id and data is encrypted with AES-256-GCM.
+
+Modes (--mode):
+• fields - encrypt only task titles and descriptions (and task snapshots in the webhook outbox); other fields stay
+           in plain text and search decrypts in memory
+• store  - encrypt the whole database file; it is decrypted to an in-memory working copy while in use and
+           encrypted again when the command finishes. Only one todo process can use the database at a time, so
+           other commands fail while todo daemon or the TUI is running; use fields mode if you run the daemon.
+           Changes not yet encrypted again are lost if the process crashes
+
+The passphrase is read from the terminal; non-interactive use can set TODO_PASSPHRASE.
+Use 'todo unlock' to cache the key for a while so later commands don't ask again.
+Existing backups are not encrypted; delete them if needed.
+
+  todo encrypt                # encrypt titles and descriptions
+  todo encrypt --mode store   # encrypt the whole database`,
+	"flag.encrypt.mode": "encryption mode (fields/store)",
+
+	"cmd.export.short": "Export tasks",
+	"cmd.export.long": `Export tasks, including snoozed ones. Tasks are read from the database in batches and written out right away, so thousands of tasks don't take much memory.
+
+Formats (--format):
+• jsonl - one JSON object per line, with the same fields as 'todo list --json'
+• csv   - CSV with a header row, times in RFC 3339, tags comma separated
+
+  todo export > tasks.jsonl
+  todo export --format csv -o tasks.csv
+  todo export -s pending -P website`,
+	"flag.export.category": "filter by category (work/study/life/other)",
+	"flag.export.format":   "export format (jsonl/csv)",
+	"flag.export.output":   "output file, defaults to standard output",
+	"flag.export.project":  "filter by project (name or ID)",
+	"flag.export.status":   "filter by status (pending/completed)",
+
+	"cmd.focus.short": "Focus on a task with pomodoro sessions",
+	"cmd.focus.long": `Focus on a task with pomodoro rounds: work for --work, break for --break, --rounds times.
+
+Press Enter during a round to log an interruption (optionally type a reason first), or Ctrl+C to abandon the round.
+Every round is saved as a pomodoro session and its time is added to the task's tracked time (disable with --no-track).
+Use --complete to mark the task as completed after all rounds.`,
+	"flag.focus.break":    "break between rounds",
+	"flag.focus.complete": "mark the task as completed after all rounds",
+	"flag.focus.no-track": "do not add focus time to the task's tracked time",
+	"flag.focus.notify":   "notification channel at the end of each round (stdout/bell/desktop), none if empty",
+	"flag.focus.rounds":   "number of rounds",
+	"flag.focus.work":     "length of each focus round",
+
+	"cmd.graph.short": "Print the task dependency graph",
+	"cmd.graph.long": `Print the task dependency graph in Graphviz DOT or Mermaid format. Edges point from a prerequisite to the task that depends on it.
+Completed tasks are filled with a light color and waiting tasks have a red border.
+
+Examples:
+  todo graph | dot -Tpng -o deps.png
+  todo graph --format mermaid > deps.mmd`,
+	"flag.graph.all":    "include pending tasks without dependencies",
+	"flag.graph.format": "output format (dot/mermaid)",
+
+	"cmd.list.short":       "List tasks",
+	"cmd.list.long":        "List tasks. Filter with -s and -c, sort with -o and choose columns with -C, e.g. -C id,title,due,tags; the default columns come from the output.columns config key. Snoozed tasks are hidden unless --all is given. The table follows the terminal width and long titles are truncated. With many tasks, page with --limit and continue with --after as suggested.",
+	"flag.list.actionable": "only show tasks that can be done now (all prerequisites completed)",
+	"flag.list.after":      "start after the cursor printed for the previous page",
+	"flag.list.all":        "include snoozed tasks",
+	"flag.list.category":   "filter by category (work/study/life/other)",
+	"flag.list.columns":    "comma-separated columns (id,status,title,category,priority,state,project,tags,due,estimate,created,updated)",
+	"flag.list.json":       "output JSON",
+	"flag.list.limit":      "tasks per page, 0 for all",
+	"flag.list.due-after":  "only show tasks due after this time (e.g. today, 2026-11-01)",
+	"flag.list.due-before": "only show tasks due before this time (e.g. friday, next monday)",
+	"flag.list.project":    "filter by project (name or ID)",
+	"flag.list.sort":       "sort by (priority/created_at/updated_at/due_at)",
+	"flag.list.state":      "filter by workflow state (e.g. in_progress, blocked)",
+	"flag.list.status":     "filter by status (pending/completed)",
+	"flag.list.tag":        "filter by tag",
+
+	"cmd.lock.short": "Forget cached keys",
+	"cmd.lock.long":  "Clear all keys cached by the key agent and stop it. Encrypted databases ask for the passphrase again afterwards.",
+
+	"cmd.log.short": "Log time on a task or list its time entries",
+	"cmd.log.long": `Log time spent on a task, e.g. when you forgot to start the timer:
+
+  todo log 5 1h30m
+  todo log 5 45m -m "code review" --at "yesterday 18:00"
+
+--at is when the logged time ended and defaults to now.
+With only a task ID, list all time entries of that task.`,
+	"flag.log.at":      "end time, defaults to now",
+	"flag.log.message": "note",
+
+	"cmd.move.short": "Move a task to another workflow state",
+	"cmd.move.long": `Move a task to another workflow state. Only the transitions defined by the workflow are allowed.
+
+Default workflow:
+  todo        → in_progress, blocked, done
+  in_progress → todo, blocked, review, done
+  blocked     → todo, in_progress
+  review      → in_progress, blocked, done
+  done        → todo, in_progress
+
+Entering a done state marks the task as completed; leaving it marks the task as pending.
+Customize the workflow in ~/.config/todo/workflow.json (or the TODO_WORKFLOW environment variable).`,
+	"flag.move.force": "ignore unfinished prerequisites",
+
+	"cmd.project.short": "Manage projects",
+	"cmd.project.long": `Manage projects. A task can belong to one project; categories stay global.
+
+Use --project or the quick-add syntax @name when adding a task,
+and --project with list, search and stats to look at a single project.`,
+	"cmd.project.archive.short":       "Archive a project",
+	"cmd.project.archive.long":        "Archive a project. Archived projects are hidden from 'todo project list' and cannot take new tasks; use --undo to unarchive.",
+	"flag.project.archive.undo":       "unarchive",
+	"cmd.project.create.short":        "Create a project",
+	"flag.project.create.description": "project description",
+	"cmd.project.edit.short":          "Change a project's name, description or status",
+	"flag.project.edit.description":   "project description",
+	"flag.project.edit.name":          "new project name",
+	"flag.project.edit.status":        "project status (active/on_hold/completed)",
+	"cmd.project.list.short":          "List projects and their progress",
+	"flag.project.list.all":           "include archived projects",
+	"cmd.project.show.short":          "Show a project and its tasks",
+
+	"cmd.remind.short": "Manage task reminders",
+	"cmd.remind.long": `Manage task reminders. Reminders are fired by the 'todo daemon' process.
+
+A reminder is either an absolute time (--at) or an offset before the due date (--before).`,
+	"cmd.remind.add.short":    "Add a reminder to a task",
+	"flag.remind.add.at":      `reminder time (e.g. "tomorrow 9am", "明天下午三点", "2026-11-01 09:00")`,
+	"flag.remind.add.before":  "how long before the due date to remind (e.g. 30m, 2h, 1d)",
+	"cmd.remind.delete.short": "Delete a reminder",
+	"cmd.remind.list.short":   "List reminders",
+	"flag.remind.list.all":    "include fired reminders",
+	"cmd.remind.snooze.short": "Snooze a reminder",
+	"cmd.remind.snooze.long":  `Snooze a reminder by a duration (e.g. 10m, 2h, 1d) or until a time (e.g. "tomorrow 9am"). A fired reminder fires again at the new time.`,
+
+	"cmd.report.short": "Generate a daily or weekly report",
+	"cmd.report.long": `Generate a daily or weekly report from your tasks with four sections: completed, in progress, overdue and added, each grouped by category.
+
+  todo report                                # this week's report (Markdown)
+  todo report --period day --format html -o today.html
+  todo report --date "last friday"           # last week's report
+  todo report --ai                           # add an AI-written summary at the top
+
+Templates use Go text/template. Pass a template file with --template,
+or put report.md.tmpl / report.html.tmpl in the config directory (e.g. ~/.config/todo/report.md.tmpl).`,
+	"flag.report.ai":       "add an AI-written summary at the top of the report",
+	"flag.report.date":     "any day in the report period, defaults to today (e.g. yesterday, last friday)",
+	"flag.report.format":   "output format (md/html)",
+	"flag.report.output":   "write to a file instead of standard output",
+	"flag.report.period":   "report period (day/week)",
+	"flag.report.project":  "only include tasks in this project (name or ID)",
+	"flag.report.template": "custom template file",
+
+	"cmd.restore.short": "Restore the database from a backup",
+	"cmd.restore.long": `Replace the whole database with a backup file.
+
+The backup's integrity and schema version are checked first: backups from a newer todo need an upgrade,
+older ones are upgraded after restoring. The current database is backed up first; find it with 'todo backup --list'.
+
+The current database is not opened: the backup file replaces it directly, so a damaged database can be restored too.
+The restored database is encrypted the same way as the backup. Stop 'todo daemon' and other processes using the database first.`,
+	"flag.restore.yes": "skip confirmation",
+
+	"cmd.search.short":    "Search tasks",
+	"cmd.search.long":     "Search tasks whose title, description or tags contain the keyword. Use --limit to page through many matches and --after to continue as suggested.",
+	"flag.search.after":   "start after the cursor printed for the previous page",
+	"flag.search.limit":   "tasks per page, 0 for all",
+	"flag.search.project": "only search tasks in this project (name or ID)",
+
+	"cmd.show.short": "Show task details",
+	"cmd.show.long":  "Show the details of a task.",
+
+	"cmd.snooze.short": "Snooze a task",
+	"cmd.snooze.long": `Snooze a task. Snoozed tasks are hidden from 'todo list' and reappear automatically.
+
+Snooze until:
+• a duration: 3d, 12h, 1d12h
+• English: tomorrow, next week, next monday, "in 3 days", "friday 9am"
+• Chinese: 明天, 下周一, "3天后", "后天上午10点"
+• a date: 2026-11-01 or "2026-11-01 09:00"
+
+A date without a time snoozes until midnight.
+
+Use -u to unsnooze.`,
+	"flag.snooze.unsnooze": "unsnooze",
+
+	"cmd.start.short": "Start a timer on a task",
+	"cmd.start.long": `Start timing a task; stop with 'todo stop'.
+
+Only one timer can run at a time; use --switch to stop the running timer first.
+Without arguments, show the running timer.`,
+	"flag.start.switch": "stop the running timer before starting",
+
+	"cmd.stats.short": "Show statistics",
+	"cmd.stats.long": `Show task statistics such as totals, completed tasks and the completion rate.
+
+Use --range or --by to see trends over time: tasks created and completed per day or week,
+average lead time by category and priority, the completion streak and the age of pending tasks.
+
+  todo stats --range 30d --by week
+  todo stats --range 7d
+
+Use --chart to show bar charts, sparklines and a burn-down; ASCII is used when the output is not a terminal.
+
+  todo stats --chart
+  todo stats --chart --range 12w --by week`,
+	"flag.stats.accuracy": "compare estimates with actual time for completed tasks",
+	"flag.stats.by":       "trend granularity (day/week)",
+	"flag.stats.chart":    "show charts",
+	"flag.stats.project":  "only include tasks in this project (name or ID)",
+	"flag.stats.range":    "trend range (e.g. 7d, 30d, 12w)",
+
+	"cmd.stop.short": "Stop the running timer",
+
+	"cmd.tui.short": "Open the full-screen terminal interface",
+	"cmd.tui.long": `Open a full-screen terminal interface to browse, filter and edit tasks and chat with the AI agent.
+
+Keys:
+  j/k ↑/↓   move
+  space/x   toggle completion
+  a / e     add / edit a task
+  d / u     delete / undo (tasks are deleted on exit)
+  /         filter, e.g. "report #work +release @launch !3 is:done"
+  c         chat with the AI agent (requires QWEN_API_KEY)
+  Tab       switch between the list, filter and chat
+  ?         help
+  q         quit`,
+
+	"cmd.unlock.short": "Cache the key of an encrypted database",
+	"cmd.unlock.long": `Enter the passphrase and hand the key to the background key agent for --timeout.
+Commands using the same database don't ask for the passphrase meanwhile; running it again restarts the timer.
+
+Keys live only in the agent's memory and are served over a socket in the runtime directory that only the current user can access.
+Use 'todo lock' to clear all cached keys immediately.
+
+  todo unlock               # cache for 15 minutes
+  todo unlock --timeout 8h`,
+	"flag.unlock.timeout": "how long to cache the key",
+
+	"cmd.webhook.short": "Manage webhook subscriptions",
+	"cmd.webhook.long": `Manage webhook subscriptions for task changes.
+
+When a task event occurs, matching subscriptions are written to an outbox and delivered as a JSON HTTP POST
+with an HMAC-SHA256 signature in the X-Todo-Signature header. Failed deliveries are retried with exponential backoff.`,
+	"cmd.webhook.add.short":        "Add a webhook subscription",
+	"flag.webhook.add.events":      "comma-separated event types (default: all)",
+	"flag.webhook.add.filter":      "task filter, e.g. priority>=4",
+	"flag.webhook.add.secret":      "signing secret (random by default)",
+	"cmd.webhook.disable.short":    "Disable a webhook subscription",
+	"cmd.webhook.disable.long":     "Disable a webhook subscription. While it is disabled no new events are queued and undelivered outbox entries are marked as failed; after re-enabling it, use 'todo webhook replay --failed' to redeliver them.",
+	"cmd.webhook.enable.short":     "Re-enable a webhook subscription",
+	"cmd.webhook.flush.short":      "Deliver due entries from the outbox",
+	"cmd.webhook.list.short":       "List webhook subscriptions",
+	"flag.webhook.list.deliveries": "also show recent deliveries",
+	"cmd.webhook.remove.short":     "Remove a webhook subscription",
+	"cmd.webhook.replay.short":     "Redeliver",
+	"cmd.webhook.replay.long":      "Redeliver the given deliveries, or all failed ones with --failed.",
+	"flag.webhook.replay.failed":   "redeliver all failed deliveries",
+	"cmd.webhook.test.short":       "Send a test event",
+
+	"cmd.workspace.short": "Manage workspaces",
+	"cmd.workspace.long": `Manage workspaces. Each workspace has its own database in the XDG data directory
+($XDG_DATA_HOME/todo, default ~/.local/share/todo), so every directory sees the same workspace.
+
+The database is chosen in this order: --db > --workspace > db config key > workspace config key > default workspace.
+Set workspace or db in .todo.yaml at a repository root to give that directory and its subdirectories their own task list:
+  todo workspace use myrepo --local        # use the myrepo workspace in this directory
+  todo config set db .todolist.db --local  # use a database file inside the repository`,
+	"cmd.workspace.create.short": "Create a workspace",
+	"cmd.workspace.list.short":   "List workspaces",
+	"cmd.workspace.use.short":    "Switch the current workspace",
+	"cmd.workspace.use.long":     "Switch the current workspace, written to the user config file. With --local it only applies to the current directory and its subdirectories and is written to ./.todo.yaml.",
+	"flag.workspace.use.local":   "write .todo.yaml in the current directory so it only applies there",
+}
+
+func init() {
+	for key, message := range enUSHelp {
+		enUS[key] = message
+	}
+}
@@ -0,0 +1,464 @@
+package i18n
+
+// enUSMessages 命令输出的英文提示和错误消息，键与 zhCNMessages 相同
+var enUSMessages = map[string]string{
+	"agenda.day":           "%s %s",
+	"agenda.empty":         "Nothing is due in the coming days",
+	"agenda.negative_days": "the number of days must not be negative",
+	"agenda.overdue":       "Overdue (%d)",
+	"agenda.today":         "Today %s",
+	"agenda.tomorrow":      "Tomorrow %s",
+
+	"backup.column_file":        "File",
+	"backup.column_kind":        "Kind",
+	"backup.column_size":        "Size",
+	"backup.column_time":        "Time",
+	"backup.done":               "Backed up to %s",
+	"backup.failed":             "backup failed: %v",
+	"backup.kind_manual":        "manual",
+	"backup.kind_pre_migration": "before upgrade (schema %s)",
+	"backup.kind_pre_repair":    "before repair",
+	"backup.kind_pre_restore":   "before restore",
+	"backup.none":               "No backups",
+	"backup.prune_failed":       "failed to prune old backups: %v",
+	"backup.pruned":             "Removed %d old backups (keeping the latest %d)",
+
+	"calendar.invalid_month": "can't parse month %q, e.g. 2026-11, next month, 下个月",
+	"calendar.legend":        "  (! overdue  • open  ✓ all done)",
+	"calendar.overdue":       ", %d overdue",
+	"calendar.summary":       "%d tasks due this month",
+	"calendar.title":         "%[3]s %[1]d",
+
+	"chart.aging":       "🕰  Age of open tasks:",
+	"chart.burndown":    "🔥 Burndown (open tasks at the end of each period):",
+	"chart.by_category": "📁 By category:",
+	"chart.by_priority": "⚡ Pending tasks by priority:",
+	"chart.daily":       "📈 Created/completed per day (%d/%d in total):",
+	"chart.rate":        "Completion rate %.1f%% (%d/%d)",
+	"chart.title":       "📊 Statistics charts",
+	"chart.weekly":      "📈 Created/completed per week (%d/%d in total):",
+
+	"chat.agent_failed": "agent error: %v",
+	"chat.api_key_hint": `Set the QWEN_API_KEY environment variable or the llm.api_key config key.
+You can create a .env file containing:
+QWEN_API_KEY=your_api_key_here
+or run: todo config set llm.api_key your_api_key_here`,
+	"chat.ask_list":  "show all pending tasks",
+	"chat.ask_stats": "show statistics and a summary",
+	"chat.bye":       "Bye!",
+	"chat.cleared":   "Conversation history cleared",
+	"chat.help": `Commands:
+• list/ls - show all tasks
+• stats - show statistics
+• + <text> - quick-add a task, e.g. '+ Write report !3 #work due:fri'
+• help - show this help
+• exit - quit
+
+Or just describe what you want in plain language, e.g.:
+• 'add a task: prepare the project demo'
+• 'complete task 3'
+• 'which work tasks are still pending?'`,
+	"chat.no_api_key":  "no LLM API key found",
+	"chat.prompt":      "You: ",
+	"chat.read_failed": "failed to read input: %v",
+
+	"complete.blocked": "task %d is still waiting on %s, complete those first or use --force",
+	"complete.undone":  "Task %d marked as pending",
+
+	"config.column_key":    "Key",
+	"config.column_source": "Source",
+	"config.column_value":  "Value",
+	"config.file_missing":  " (missing)",
+	"config.project_file":  "Project config: %s",
+	"config.set":           "Set %s (%s)",
+	"config.set_profile":   "Set %s (profile %s, %s)",
+	"config.untrusted":     "Project config %s is not trusted, ignoring %s. If you trust this project, run: todo config set trusted_projects %s",
+	"config.user_file":     "User config: %s",
+	"config.user_only":     "%s only takes effect in trusted projects; if needed, run: todo config set trusted_projects %s",
+
+	"daemon.no_notifier":  "at least one notification channel is required",
+	"daemon.notify_due":   " (due %s)",
+	"daemon.notify_late":  ", %s late",
+	"daemon.notify_title": "Todo reminder",
+	"daemon.started":      "Reminder daemon started (notifiers: %s), press Ctrl+C to exit",
+	"daemon.stopped":      "Reminder daemon stopped",
+
+	"decrypt.done":   "Database decrypted (%s)",
+	"decrypt.failed": "decryption failed: %v",
+
+	"delete.cancelled":          "Cancelled",
+	"delete.choose":             "Enter the option number (1-%d) to delete, or 0 to cancel: ",
+	"delete.confirm":            "Delete task %d? (y/N): ",
+	"delete.done":               "Task %d deleted",
+	"delete.invalid_choice":     "invalid choice",
+	"delete.option":             "Option [%d] | task ID: %d | %s | %s",
+	"delete.option_category":    "         | category: %s | priority %d",
+	"delete.option_description": "         | description: %s",
+	"delete.selected":           "Selected task:",
+	"delete.status_completed":   "completed",
+	"delete.status_pending":     "pending",
+
+	"depend.add_failed":           "failed to add dependency: %v",
+	"depend.added":                "Task %d now depends on task %d",
+	"depend.cycle":                "adding this dependency would create a cycle: %s",
+	"depend.dependents":           "\nTasks depending on task %d:",
+	"depend.dependents_failed":    "failed to get dependent tasks: %v",
+	"depend.none":                 "  (none)",
+	"depend.not_found":            "Task %d doesn't depend on task %d",
+	"depend.prerequisites":        "\nPrerequisites of task %d:",
+	"depend.prerequisites_failed": "failed to get prerequisites: %v",
+	"depend.remove_failed":        "failed to remove dependency: %v",
+	"depend.removed":              "Task %d no longer depends on task %d",
+	"depend.self":                 "a task can't depend on itself",
+
+	"doctor.advice.indexes":               "Run 'todo doctor --fix' to rebuild the missing indexes",
+	"doctor.advice.integrity":             "Damaged indexes can be rebuilt with 'todo doctor --fix'; for damaged table data, restore a backup with 'todo restore'",
+	"doctor.advice.orphan_deliveries":     "Run 'todo doctor --fix' to delete these deliveries",
+	"doctor.advice.orphan_dependencies":   "Run 'todo doctor --fix' to delete these dependencies",
+	"doctor.advice.orphan_focus_sessions": "Run 'todo doctor --fix' to delete these focus sessions",
+	"doctor.advice.orphan_projects":       "Run 'todo doctor --fix' to move these tasks out of the project",
+	"doctor.advice.orphan_reminders":      "Run 'todo doctor --fix' to delete these reminders",
+	"doctor.advice.orphan_time_entries":   "Run 'todo doctor --fix' to delete these time entries",
+	"doctor.advice.schema":                "'todo doctor --fix' or any other command upgrades the schema; if the version is newer than this program, upgrade todo",
+	"doctor.backed_up":                    "Database backed up to %s",
+	"doctor.backup_failed":                "failed to back up the database, nothing repaired: %v",
+	"doctor.check.indexes":                "Indexes",
+	"doctor.check.integrity":              "Integrity (PRAGMA integrity_check)",
+	"doctor.check.orphan_deliveries":      "Webhooks referenced by deliveries",
+	"doctor.check.orphan_dependencies":    "Tasks referenced by dependencies",
+	"doctor.check.orphan_focus_sessions":  "Tasks referenced by focus sessions",
+	"doctor.check.orphan_projects":        "Projects referenced by tasks",
+	"doctor.check.orphan_reminders":       "Tasks referenced by reminders",
+	"doctor.check.orphan_time_entries":    "Tasks referenced by time entries",
+	"doctor.check.schema":                 "Schema version",
+	"doctor.database":                     "Database: %s (schema %d)",
+	"doctor.healthy":                      "No problems found",
+	"doctor.more":                         "… %d more",
+	"doctor.problems":                     "%s: %d problems",
+	"doctor.repair_failed":                "repair failed: %v",
+	"doctor.repaired":                     "Repaired",
+	"doctor.title":                        "Database check",
+	"doctor.unhealthy":                    "%d checks found problems",
+
+	"edit.done":        "Task %d updated",
+	"edit.empty_title": "the title must not be empty",
+	"edit.nothing":     "specify at least one field to change, see 'todo edit --help'",
+
+	"encrypt.already":             "the database is already encrypted (%s)",
+	"encrypt.confirm_passphrase":  "Repeat the passphrase: ",
+	"encrypt.done":                "Database encrypted (%s)",
+	"encrypt.empty_passphrase":    "the passphrase must not be empty",
+	"encrypt.enter_passphrase":    "The database is encrypted, enter the passphrase: ",
+	"encrypt.failed":              "encryption failed: %v",
+	"encrypt.new_passphrase":      "New passphrase: ",
+	"encrypt.passphrase_mismatch": "the passphrases don't match",
+	"encrypt.plain_backups":       "%[2]d existing backups in %[1]s are still plaintext",
+	"encrypt.unlock_hint":         "Use 'todo unlock' to cache the key instead of typing the passphrase every time",
+
+	"error.add_task":            "failed to add task: %v",
+	"error.check_db":            "failed to check the database: %v",
+	"error.create_project":      "failed to create project: %v",
+	"error.delete_task":         "failed to delete task: %v",
+	"error.deliveries":          "failed to get deliveries: %v",
+	"error.focus_sessions":      "failed to get pomodoro sessions: %v",
+	"error.get_task":            "failed to get task: %v",
+	"error.invalid_category":    "invalid category, must be work, study, life or other",
+	"error.invalid_priority":    "invalid priority, must be 1-4",
+	"error.invalid_reminder_id": "invalid reminder ID",
+	"error.invalid_task_id":     "invalid task ID",
+	"error.invalid_webhook_id":  "invalid webhook ID",
+	"error.json":                "failed to write JSON: %v",
+	"error.list_projects":       "failed to list projects: %v",
+	"error.list_tasks":          "failed to list tasks: %v",
+	"error.not_encrypted":       "the database is not encrypted",
+	"error.project_archived":    "project %q is archived, tasks can't be added to it",
+	"error.project_progress":    "failed to get project progress: %v",
+	"error.quick_add":           "failed to parse quick-add syntax: %v",
+	"error.running_timer":       "failed to get the timer: %v",
+	"error.search":              "search failed: %v",
+	"error.stop_timer":          "failed to stop the timer: %v",
+	"error.task_not_found":      "task %d not found",
+	"error.time_entries":        "failed to get time entries: %v",
+	"error.tracked_time":        "failed to get tracked time: %v",
+	"error.trends":              "failed to get trends: %v",
+	"error.update_project":      "failed to update project: %v",
+	"error.update_task":         "failed to update task: %v",
+
+	"estimate.column_actual":    "Actual",
+	"estimate.column_ratio":     "Ratio",
+	"estimate.column_source":    "Source",
+	"estimate.duration_summary": "Duration estimates: %d tasks, mean actual/estimate = %.2f, %d within ±25%%",
+	"estimate.none":             "No completed tasks with estimates",
+	"estimate.optimistic":       "Estimates are optimistic: tasks usually take longer than estimated",
+	"estimate.pessimistic":      "Estimates are conservative: tasks usually take less time than estimated",
+	"estimate.points_summary":   "Point estimates: %d tasks, %.1f hours per point on average",
+	"estimate.source_elapsed":   "elapsed",
+	"estimate.source_tracked":   "tracked",
+	"estimate.tasks":            "%d tasks",
+	"estimate.title":            "🎯 Estimate accuracy",
+	"estimate.unestimated":      ", %d unestimated",
+
+	"export.create_failed":  "failed to create file: %v",
+	"export.done":           "Exported %d tasks to %s",
+	"export.failed":         "export failed: %v",
+	"export.invalid_format": "invalid export format, must be jsonl or csv",
+
+	"focus.abandoned":         "Gave up after %d/%d pomodoros",
+	"focus.blocked":           "task %d is still waiting on %s, not marked as completed",
+	"focus.break":             "☕ Break",
+	"focus.break_done":        "Break is over, starting the next focus round",
+	"focus.completed":         "task %d is already completed",
+	"focus.done":              "Completed %d pomodoros",
+	"focus.interruptions":     "  (%d interruptions)",
+	"focus.invalid_config":    "invalid pomodoro settings: %v",
+	"focus.round":             "🍅 %d/%d Focus",
+	"focus.round_break":       "Focus round %d finished, take a %s break",
+	"focus.round_done":        "Focus round %d finished",
+	"focus.running":           "task %d is being timed, run 'todo stop' first or use --no-track",
+	"focus.save_failed":       "failed to save the pomodoro session: %v",
+	"focus.started":           "Focusing on task %d: %s (%s × %d), press Enter to log an interruption, Ctrl+C to give up",
+	"focus.title":             "Pomodoro",
+	"focus.transition_failed": "can't update the task state: %v",
+
+	"graph.failed":         "failed to get the dependency graph: %v",
+	"graph.invalid_format": "invalid format, must be dot or mermaid",
+	"graph.write_failed":   "failed to write the dependency graph: %v",
+
+	"list.deferred_hidden": "%d deferred tasks hidden (use --all to show them)",
+	"list.invalid_sort":    "invalid sort field, must be priority, created_at, updated_at or due_at",
+	"list.invalid_state":   "invalid workflow state, must be %s",
+	"list.invalid_status":  "invalid status, must be pending or completed",
+	"list.more":            "More tasks, use --after %s for the next page",
+
+	"lock.done": "Cached key cleared",
+	"lock.none": "No cached key",
+
+	"log.done":             "Logged %[2]s for task %[1]d, %[3]s in total",
+	"log.failed":           "failed to log time: %v",
+	"log.invalid_duration": "the duration must be greater than 0",
+
+	"move.blocked": "task %d is still waiting on %s, use --force to complete it anyway",
+	"move.done":    "Task %d: %s → %s",
+	"move.failed":  "can't move task: %v",
+	"move.next":    "Next states: %s",
+
+	"project.archived_msg":   "Project %q archived",
+	"project.created":        "Project created (ID: %d)",
+	"project.exists":         "project %q already exists (ID: %d)",
+	"project.get_failed":     "failed to get project: %v",
+	"project.invalid_status": "invalid project status, must be active, on_hold or completed",
+	"project.not_found":      "project %q not found, create it with 'todo project create'",
+	"project.unarchived":     "Project %q unarchived",
+	"project.updated":        "Project %q updated",
+
+	"remind.add_failed":    "failed to add reminder: %v",
+	"remind.added":         "Reminder added (ID: %d), fires at %s",
+	"remind.conflict":      "--at and --before can't be used together",
+	"remind.delete_failed": "failed to delete reminder: %v",
+	"remind.deleted":       "Reminder %d deleted",
+	"remind.list_failed":   "failed to list reminders: %v",
+	"remind.missing":       "use --at or --before to set the reminder time",
+	"remind.no_due":        "task %d has no due date, --before can't be used",
+	"remind.snooze_failed": "failed to snooze reminder: %v",
+	"remind.snoozed":       "Reminder %d snoozed until %s",
+
+	"report.due":                 "due",
+	"report.failed":              "failed to generate the report: %v",
+	"report.generated":           "Generated at %s",
+	"report.heading":             "%s (%s ~ %s)",
+	"report.none":                "None",
+	"report.section_added":       "Added",
+	"report.section_completed":   "Completed",
+	"report.section_heading":     "%s (%d)",
+	"report.section_in_progress": "In progress",
+	"report.section_overdue":     "Overdue",
+	"report.summary":             "Summary",
+	"report.summary_failed":      "failed to generate the AI summary: %v",
+	"report.summary_prompt": `Below is my %s. Write a 3 to 5 sentence summary: highlight what was completed, point out the risks in overdue and in-progress work, and suggest next steps.
+Output only the summary paragraph itself, with no heading or list, and don't call any tools.
+
+%s`,
+	"report.template_failed": "failed to load the template: %v",
+	"report.title_day":       "Daily report",
+	"report.title_week":      "Weekly report",
+	"report.write_failed":    "failed to write the report: %v",
+	"report.written":         "Report written to %s",
+
+	"restore.backed_up":      "Current database backed up to %s",
+	"restore.backup_failed":  "failed to back up the current database, nothing restored: %v",
+	"restore.confirm":        "Replace %s with this backup? The current data is backed up first (y/N): ",
+	"restore.done":           "Restored from %s",
+	"restore.failed":         "restore failed: %v",
+	"restore.info":           "Backup: %s (schema %d, %d tasks)",
+	"restore.invalid_backup": "can't use this backup: %v",
+	"restore.undo_hint":      "Use 'todo restore %s' to go back to the state before the restore",
+
+	"search.found": "Found %d matching tasks:",
+	"search.more":  "More matching tasks, use --after %s for the next page",
+	"search.none":  "No tasks matching '%s'",
+
+	"snooze.cleared": "Task %d is no longer deferred",
+	"snooze.done":    "Task %d deferred until %s",
+	"snooze.missing": "specify how long to snooze, e.g. 3d or next monday",
+
+	"start.completed": "task %d is completed and can't be timed",
+	"start.failed":    "failed to start the timer: %v",
+	"start.running":   "task %d is being timed, run 'todo stop' first or use --switch",
+	"start.started":   "Started timing task %d: %s",
+	"start.stopped":   "Stopped timing task %d: %s this session",
+
+	"state.blocked":     "Blocked",
+	"state.done":        "Done",
+	"state.in_progress": "In progress",
+	"state.review":      "Review",
+	"state.todo":        "To do",
+
+	"stats.accuracy_failed": "failed to get estimate accuracy: %v",
+	"stats.failed":          "failed to get statistics: %v",
+	"stats.invalid_period":  "invalid granularity, must be day or week",
+
+	"stop.done": "Stopped timing task %d: %s this session, %s in total",
+
+	"task.added":     "Task added (ID: %d)",
+	"task.completed": "Task %d completed",
+
+	"time.invalid":          "can't parse time %q, e.g. tomorrow 9am, next friday, in 3 days, 明天下午三点, 2026-11-01",
+	"time.invalid_duration": "can't parse duration %q, e.g. 10m, 2h, 1d12h",
+	"time.invalid_estimate": "can't parse estimate %q, e.g. 2h, 1h30m, 3pt",
+
+	"timer.entries_total":       "Total: %s",
+	"timer.focus_interruptions": ", %d interruptions",
+	"timer.no_entries":          "No time entries",
+	"timer.none":                "No timer is running",
+	"timer.running":             "running",
+	"timer.running_since":       "   Started at %s, running for %s",
+	"timer.running_task":        "⏱  Task %d %s",
+	"timer.task_missing":        "(task not found)",
+	"timer.tracked":             "⏱  Time tracked: %s (%d entries)",
+	"timer.tracked_running":     ", running",
+
+	"trends.aging":            "🕰  Age of open tasks (%d in total):",
+	"trends.aging_over":       "over %d days",
+	"trends.aging_range":      "%d-%d days",
+	"trends.aging_within":     "within %d days",
+	"trends.column_period":    "Period",
+	"trends.completed":        "Done",
+	"trends.created":          "New",
+	"trends.days":             "%.1f days",
+	"trends.lead_by_category": "⏳ Average lead time (by category):",
+	"trends.lead_by_priority": "⏳ Average lead time (by priority):",
+	"trends.lead_line":        "  • %s: %s (%d tasks)",
+	"trends.streak":           "🔥 Completion streak: %d days now, %d days at most",
+	"trends.streak_end":       " (ending %s)",
+	"trends.summary":          "%d created, %d completed",
+	"trends.title":            "📈 Productivity trends (%s ~ %s)",
+	"trends.week":             "wk %s",
+
+	"tui.add_title":          " Add task ",
+	"tui.added":              "Task %d added",
+	"tui.agent_failed":       "can't start the AI assistant: %v",
+	"tui.blocked":            "task %d is still waiting on %s",
+	"tui.cancel":             "Cancel",
+	"tui.chat":               " AI chat (c) ",
+	"tui.chat_error":         "Error: %v",
+	"tui.chat_failed":        "chat failed",
+	"tui.chat_unavailable":   "AI chat is unavailable",
+	"tui.completed":          "Task %d completed",
+	"tui.deleted":            "Task %d deleted, press u to undo",
+	"tui.detail":             " Details ",
+	"tui.edit_title":         " Edit task %d ",
+	"tui.failed":             "terminal UI error: %v",
+	"tui.filter":             " Filter / ",
+	"tui.filter_placeholder": "keyword #category +tag @project !priority is:open|done|all",
+	"tui.help": `[::b]Shortcuts[::-]
+
+  j/k ↑/↓   move up and down
+  space/x   toggle completed
+  a         add a task
+  e         edit the task
+  d         delete the task (can be undone until you quit)
+  u         undo the last delete
+  /         filter: keyword #category +tag @project !priority is:open|done|all
+  c         switch to the AI chat
+  Tab       cycle through the list, filter bar and chat
+  r         refresh
+  ?         show this help
+  q         quit
+
+Press Esc or Enter to close`,
+	"tui.help_title":      " Help ",
+	"tui.hint":            "Press ? for shortcuts",
+	"tui.no_task":         "No tasks",
+	"tui.no_terminal":     "the full-screen UI must run in a terminal",
+	"tui.nothing_to_undo": "Nothing to undo",
+	"tui.refresh_failed":  "refresh failed: %v",
+	"tui.refreshed":       "Refreshed",
+	"tui.reopened":        "Task %d marked as pending",
+	"tui.restored":        "Restored task %d",
+	"tui.save":            "Save",
+	"tui.save_failed":     "failed to save the task: %v",
+	"tui.tasks":           " Tasks ",
+	"tui.tasks_count":     " Tasks (%d) ",
+	"tui.thinking":        "AI is thinking...",
+	"tui.title_required":  "the title must not be empty",
+	"tui.update_failed":   "failed to update the task: %v",
+	"tui.updated":         "Task %d updated",
+	"tui.you":             " You: ",
+	"tui.you_said":        "You:",
+
+	"unlock.done":   "Unlocked, no passphrase needed for %s",
+	"unlock.failed": "failed to cache the key: %v",
+
+	"webhook.add_failed":          "failed to add webhook: %v",
+	"webhook.added":               "Webhook added (ID: %d)",
+	"webhook.delete_failed":       "failed to delete webhook: %v",
+	"webhook.deleted":             "Webhook %d deleted",
+	"webhook.delivered":           "Delivery %d succeeded",
+	"webhook.delivery_failed":     "delivery %d failed: %s",
+	"webhook.disabled":            "Webhook %d disabled",
+	"webhook.enabled":             "Webhook %d enabled",
+	"webhook.flush_failed":        "delivery failed: %v",
+	"webhook.flushed":             "Delivered %d records",
+	"webhook.get_failed":          "failed to get webhook: %v",
+	"webhook.invalid_delivery_id": "invalid delivery ID",
+	"webhook.invalid_event":       "invalid event type: %s",
+	"webhook.invalid_filter":      "invalid filter: %v",
+	"webhook.list_failed":         "failed to list webhooks: %v",
+	"webhook.not_found":           "webhook %d not found",
+	"webhook.nothing_to_retry":    "No deliveries to retry",
+	"webhook.redeliver_failed":    "failed to redeliver %d: %v",
+	"webhook.retry_usage":         "specify a delivery ID or use --failed",
+	"webhook.secret":              "Signing secret: %s",
+	"webhook.test_failed":         "test failed: %v",
+	"webhook.tested":              "Test event delivered to %s",
+	"webhook.update_failed":       "failed to update the webhook: %v",
+
+	"weekday.0": "Sun",
+	"weekday.1": "Mon",
+	"weekday.2": "Tue",
+	"weekday.3": "Wed",
+	"weekday.4": "Thu",
+	"weekday.5": "Fri",
+	"weekday.6": "Sat",
+
+	"workflow.fallback": "%v, using the default workflow",
+
+	"workspace.column_db":     "Database",
+	"workspace.column_name":   "Name",
+	"workspace.create_failed": "failed to create workspace: %v",
+	"workspace.created":       "Workspace %q created (%s)",
+	"workspace.db_overrides":  "Note: %s sets db, which takes precedence over workspace",
+	"workspace.exists":        "workspace %q already exists",
+	"workspace.legacy":        "%s in the current directory is no longer used by default, the database in use is %s. To keep using it, run 'todo config set db %s --local'",
+	"workspace.list_failed":   "failed to list workspaces: %v",
+	"workspace.not_found":     "workspace %q not found, create it with 'todo workspace create %s'",
+	"workspace.outside":       "The current database doesn't belong to any workspace: %s",
+	"workspace.switched":      "Switched to workspace %q (%s)",
+	"workspace.use_hint":      "Use 'todo workspace use %s' to switch to it",
+}
+
+func init() {
+	for key, message := range enUSMessages {
+		enUS[key] = message
+	}
+}
@@ -0,0 +1,100 @@
+// Package i18n 命令行界面的多语言消息目录，目前支持简体中文 (zh-CN) 和英文 (en-US)。
+//
+// 消息按键查找，例如 T("stats.total", 3)。当前语言缺少某个键时回退到简体中文，
+// 都没有时返回键本身，便于发现漏翻的消息。
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Locale 语言区域
+type Locale string
+
+const (
+	ZhCN Locale = "zh-CN"
+	EnUS Locale = "en-US"
+
+	// Default 无法识别语言设置时使用的默认语言
+	Default = ZhCN
+)
+
+var (
+	catalogs = map[Locale]map[string]string{
+		ZhCN: zhCN,
+		EnUS: enUS,
+	}
+	current = Default
+)
+
+// Locales 支持的所有语言
+func Locales() []Locale {
+	return []Locale{ZhCN, EnUS}
+}
+
+// ParseLocale 解析语言设置，兼容 --lang 参数和 LANG 环境变量的写法，
+// 例如 "en"、"en-US"、"en_US.UTF-8"、"zh"、"zh_CN.UTF-8"
+func ParseLocale(value string) (Locale, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if i := strings.IndexAny(value, ".@"); i >= 0 {
+		value = value[:i]
+	}
+	language, _, _ := strings.Cut(strings.ReplaceAll(value, "_", "-"), "-")
+	switch language {
+	case "zh":
+		return ZhCN, true
+	case "en":
+		return EnUS, true
+	}
+	return "", false
+}
+
+// Detect 确定使用的语言：依次检查 flag（--lang 参数）、TODO_LANG、LC_ALL、LC_MESSAGES 和 LANG，
+// 第一个能识别的设置生效，都无法识别时使用 Default
+func Detect(flag string) Locale {
+	candidates := []string{flag}
+	for _, name := range []string{"TODO_LANG", "LC_ALL", "LC_MESSAGES", "LANG"} {
+		candidates = append(candidates, os.Getenv(name))
+	}
+	for _, value := range candidates {
+		if locale, ok := ParseLocale(value); ok {
+			return locale
+		}
+	}
+	return Default
+}
+
+// SetLocale 设置当前语言
+func SetLocale(locale Locale) {
+	if _, ok := catalogs[locale]; ok {
+		current = locale
+	}
+}
+
+// Current 当前语言
+func Current() Locale {
+	return current
+}
+
+// Lookup 在当前语言的目录中查找消息，不回退到默认语言
+func Lookup(key string) (string, bool) {
+	message, ok := catalogs[current][key]
+	return message, ok
+}
+
+// T 返回当前语言的消息，有参数时按 fmt.Sprintf 格式化
+func T(key string, args ...interface{}) string {
+	message, ok := catalogs[current][key]
+	if !ok {
+		message, ok = catalogs[Default][key]
+	}
+	if !ok {
+		message = key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
@@ -0,0 +1,99 @@
+package i18n
+
+// zhCN 简体中文消息目录，也是其他语言缺少消息时的回退
+var zhCN = map[string]string{
+	// 优先级和分类
+	"priority.1":     "低",
+	"priority.2":     "中",
+	"priority.3":     "高",
+	"priority.4":     "紧急",
+	"category.work":  "工作",
+	"category.study": "学习",
+	"category.life":  "生活",
+	"category.other": "其他",
+
+	// 任务字段
+	"field.id":          "ID",
+	"field.title":       "标题",
+	"field.status":      "状态",
+	"field.category":    "分类",
+	"field.project":     "项目",
+	"field.priority":    "优先级",
+	"field.state":       "工作流",
+	"field.created":     "创建时间",
+	"field.updated":     "更新时间",
+	"field.completed":   "完成时间",
+	"field.deferred":    "延后至",
+	"field.due":         "截止时间",
+	"field.due_short":   "截止",
+	"field.tags":        "标签",
+	"field.estimate":    "估算",
+	"field.blocked_by":  "等待前置任务",
+	"field.description": "描述",
+	"field.progress":    "进度",
+	"task.overdue":      " (已逾期)",
+	"task.none":         "暂无任务",
+	"task.parsed":       "解析结果:",
+	"task.total":        "总计: %d 个任务",
+	"task.blocked":      "，%d 个等待前置任务 (⊘)",
+
+	// 表格列
+	"column.unknown": "未知的列 %q，可用的列：%s",
+	"column.empty":   "至少需要一列，可用的列：%s",
+
+	// 统计
+	"stats.title":           "📊 统计信息",
+	"stats.total":           "📋 总任务数: %d",
+	"stats.completed":       "✓ 已完成: %d",
+	"stats.pending":         "○ 待完成: %d",
+	"stats.rate":            "📈 完成率: %.1f%%",
+	"stats.by_state":        "🗂  按工作流状态统计:",
+	"stats.by_category":     "📁 按分类统计:",
+	"stats.by_priority":     "⚡ 待办任务优先级分布:",
+	"stats.time":            "⏱  计时汇总: 共 %s",
+	"stats.remaining":       "📐 剩余工作量: %s",
+	"stats.focus":           "🍅 番茄钟: 完成 %d 个",
+	"stats.focus_abandoned": "，放弃 %d 个",
+	"stats.focus_summary":   "，中断 %d 次，专注 %s",
+	"stats.group_category":  "按分类",
+	"stats.group_project":   "按项目",
+	"stats.group_recent":    "最近几天",
+
+	// AI 助手
+	"agent.greeting":      "我是你的智能待办助手，可以帮你管理任务。",
+	"agent.examples":      "你可以问我：",
+	"agent.example_list":  "• 'list' 或 '显示所有任务'",
+	"agent.example_stats": "• '统计' 或 '总结一下'",
+	"agent.example_add":   "• '添加任务：写周报'",
+	"agent.example_done":  "• '完成任务 1'",
+	"agent.example_find":  "• '搜索包含会议的任务'",
+	"agent.example_free":  "• 或者用自然语言描述你想做什么",
+	"agent.exit_hint":     "输入 'exit' 或 'quit' 退出。",
+	"agent.tool_call":     "🔧 调用工具: %s...",
+
+	// Webhook
+	"webhook.none":            "暂无 Webhook 订阅",
+	"webhook.all_events":      "全部事件",
+	"webhook.no_filter":       "无",
+	"webhook.summary":         "     事件: %s | 过滤: %s",
+	"webhook.no_deliveries":   "暂无投递记录",
+	"webhook.inactive":        " (已停用)",
+	"webhook.column_event":    "事件",
+	"webhook.column_status":   "状态",
+	"webhook.column_attempts": "次数",
+	"webhook.column_detail":   "下次重试/错误",
+
+	// 提醒
+	"reminder.none":         "暂无提醒",
+	"reminder.task_missing": "(任务不存在)",
+	"reminder.no_due":       "无截止时间",
+	"reminder.at":           "定时",
+	"reminder.before_due":   "截止前 %s",
+	"reminder.snoozed":      "，已推迟",
+	"reminder.line":         "[%d] 任务 %d %s | %s | %s",
+	"reminder.fired":        "%s | 已触发 %s",
+
+	// 项目
+	"project.none":     "暂无项目",
+	"project.archived": "已归档",
+}
@@ -0,0 +1,464 @@
+package i18n
+
+// zhCNMessages 命令输出的提示和错误消息，键以命令名开头，多个命令共用的以 "error." 等开头
+var zhCNMessages = map[string]string{
+	"agenda.day":           "%s 周%s",
+	"agenda.empty":         "接下来没有到期的任务",
+	"agenda.negative_days": "天数不能为负数",
+	"agenda.overdue":       "已逾期 (%d)",
+	"agenda.today":         "今天 %s",
+	"agenda.tomorrow":      "明天 %s",
+
+	"backup.column_file":        "文件",
+	"backup.column_kind":        "类型",
+	"backup.column_size":        "大小",
+	"backup.column_time":        "时间",
+	"backup.done":               "已备份到 %s",
+	"backup.failed":             "备份失败: %v",
+	"backup.kind_manual":        "手动",
+	"backup.kind_pre_migration": "升级前 (schema %s)",
+	"backup.kind_pre_repair":    "修复前",
+	"backup.kind_pre_restore":   "恢复前",
+	"backup.none":               "暂无备份",
+	"backup.prune_failed":       "清理旧备份失败: %v",
+	"backup.pruned":             "已删除 %d 个旧备份 (保留最新 %d 个)",
+
+	"calendar.invalid_month": "无法解析月份 %q，示例：2026-11、next month、下个月",
+	"calendar.legend":        "  (! 有逾期  • 未完成  ✓ 全部完成)",
+	"calendar.overdue":       "，%d 个已逾期",
+	"calendar.summary":       "本月 %d 个任务到期",
+	"calendar.title":         "%[1]d 年 %[2]d 月",
+
+	"chart.aging":       "🕰  未完成任务存在时长:",
+	"chart.burndown":    "🔥 燃尽图（每个周期结束时未完成的任务数）:",
+	"chart.by_category": "📁 分类分布:",
+	"chart.by_priority": "⚡ 待办优先级分布:",
+	"chart.daily":       "📈 每天新建/完成 (共 %d/%d):",
+	"chart.rate":        "完成率 %.1f%% (%d/%d)",
+	"chart.title":       "📊 统计图表",
+	"chart.weekly":      "📈 每周新建/完成 (共 %d/%d):",
+
+	"chat.agent_failed": "Agent 错误: %v",
+	"chat.api_key_hint": `请设置 QWEN_API_KEY 环境变量，或者使用配置项 llm.api_key：
+你可以创建一个 .env 文件并添加：
+QWEN_API_KEY=your_api_key_here
+或者执行：todo config set llm.api_key your_api_key_here`,
+	"chat.ask_list":  "显示所有待办任务",
+	"chat.ask_stats": "显示统计信息和总结",
+	"chat.bye":       "再见！",
+	"chat.cleared":   "对话历史已清空",
+	"chat.help": `可用命令：
+• list/ls - 显示所有任务
+• stats - 显示统计信息
+• + <文本> - 快速添加任务，例如 '+ 写周报 !3 #work due:fri'
+• help - 显示此帮助
+• exit - 退出
+
+或者直接用自然语言描述你想做什么，例如：
+• '帮我添加一个任务：准备项目演示'
+• '完成任务 3'
+• '有哪些工作相关的未完成任务？'`,
+	"chat.no_api_key":  "未找到大模型 API Key",
+	"chat.prompt":      "你: ",
+	"chat.read_failed": "读取输入失败: %v",
+
+	"complete.blocked": "任务 %d 还在等待前置任务 %s，完成前置任务后再试，或使用 --force 强制完成",
+	"complete.undone":  "任务 %d 已标记为未完成",
+
+	"config.column_key":    "配置项",
+	"config.column_source": "来源",
+	"config.column_value":  "值",
+	"config.file_missing":  " (不存在)",
+	"config.project_file":  "项目配置: %s",
+	"config.set":           "已设置 %s (%s)",
+	"config.set_profile":   "已设置 %s (profile %s，%s)",
+	"config.untrusted":     "项目配置 %s 不受信任，已忽略其中的 %s。确认该项目可信后运行: todo config set trusted_projects %s",
+	"config.user_file":     "用户配置: %s",
+	"config.user_only":     "%s 只在受信任的项目中生效，需要时运行: todo config set trusted_projects %s",
+
+	"daemon.no_notifier":  "至少需要一个通知渠道",
+	"daemon.notify_due":   "（截止 %s）",
+	"daemon.notify_late":  "，已延迟 %s",
+	"daemon.notify_title": "待办提醒",
+	"daemon.started":      "提醒后台进程已启动 (通知渠道: %s)，按 Ctrl+C 退出",
+	"daemon.stopped":      "提醒后台进程已退出",
+
+	"decrypt.done":   "数据库已解密 (%s)",
+	"decrypt.failed": "解密失败: %v",
+
+	"delete.cancelled":          "已取消",
+	"delete.choose":             "请输入选项序号 (1-%d) 来删除任务，输入 0 取消: ",
+	"delete.confirm":            "确定要删除任务 %d 吗？(y/N): ",
+	"delete.done":               "任务 %d 已删除",
+	"delete.invalid_choice":     "无效的选择",
+	"delete.option":             "选项 [%d] | 任务ID: %d | %s | %s",
+	"delete.option_category":    "         | 分类: %s | 优先级%d",
+	"delete.option_description": "         | 描述: %s",
+	"delete.selected":           "您选择删除的任务:",
+	"delete.status_completed":   "已完成",
+	"delete.status_pending":     "未完成",
+
+	"depend.add_failed":           "添加依赖失败: %v",
+	"depend.added":                "任务 %d 现在依赖任务 %d",
+	"depend.cycle":                "添加依赖会形成环: %s",
+	"depend.dependents":           "\n依赖任务 %d 的任务:",
+	"depend.dependents_failed":    "获取后续任务失败: %v",
+	"depend.none":                 "  (无)",
+	"depend.not_found":            "任务 %d 没有依赖任务 %d",
+	"depend.prerequisites":        "\n任务 %d 的前置任务:",
+	"depend.prerequisites_failed": "获取前置任务失败: %v",
+	"depend.remove_failed":        "移除依赖失败: %v",
+	"depend.removed":              "任务 %d 不再依赖任务 %d",
+	"depend.self":                 "任务不能依赖自己",
+
+	"doctor.advice.indexes":               "执行 'todo doctor --fix' 重建缺失的索引",
+	"doctor.advice.integrity":             "索引损坏可以执行 'todo doctor --fix' 重建；表数据损坏请使用 'todo restore' 从备份恢复",
+	"doctor.advice.orphan_deliveries":     "执行 'todo doctor --fix' 删除这些投递记录",
+	"doctor.advice.orphan_dependencies":   "执行 'todo doctor --fix' 删除这些依赖关系",
+	"doctor.advice.orphan_focus_sessions": "执行 'todo doctor --fix' 删除这些番茄钟记录",
+	"doctor.advice.orphan_projects":       "执行 'todo doctor --fix' 将这些任务移出项目",
+	"doctor.advice.orphan_reminders":      "执行 'todo doctor --fix' 删除这些提醒",
+	"doctor.advice.orphan_time_entries":   "执行 'todo doctor --fix' 删除这些计时记录",
+	"doctor.advice.schema":                "执行 'todo doctor --fix' 或任意其他命令会自动升级 schema；版本高于当前程序时请升级 todo",
+	"doctor.backed_up":                    "数据库已备份到 %s",
+	"doctor.backup_failed":                "备份数据库失败，未修复: %v",
+	"doctor.check.indexes":                "索引",
+	"doctor.check.integrity":              "完整性 (PRAGMA integrity_check)",
+	"doctor.check.orphan_deliveries":      "Webhook 投递记录引用的订阅",
+	"doctor.check.orphan_dependencies":    "依赖关系引用的任务",
+	"doctor.check.orphan_focus_sessions":  "番茄钟记录引用的任务",
+	"doctor.check.orphan_projects":        "任务引用的项目",
+	"doctor.check.orphan_reminders":       "提醒引用的任务",
+	"doctor.check.orphan_time_entries":    "计时记录引用的任务",
+	"doctor.check.schema":                 "Schema 版本",
+	"doctor.database":                     "数据库: %s (schema %d)",
+	"doctor.healthy":                      "没有发现问题",
+	"doctor.more":                         "… 另有 %d 个",
+	"doctor.problems":                     "%s: %d 个问题",
+	"doctor.repair_failed":                "修复失败: %v",
+	"doctor.repaired":                     "已修复",
+	"doctor.title":                        "数据库检查",
+	"doctor.unhealthy":                    "%d 个检查项发现问题",
+
+	"edit.done":        "任务 %d 已更新",
+	"edit.empty_title": "标题不能为空",
+	"edit.nothing":     "请至少指定一个要修改的字段，参见 'todo edit --help'",
+
+	"encrypt.already":             "数据库已经加密 (%s)",
+	"encrypt.confirm_passphrase":  "再次输入口令: ",
+	"encrypt.done":                "数据库已加密 (%s)",
+	"encrypt.empty_passphrase":    "口令不能为空",
+	"encrypt.enter_passphrase":    "数据库已加密，请输入口令: ",
+	"encrypt.failed":              "加密失败: %v",
+	"encrypt.new_passphrase":      "设置口令: ",
+	"encrypt.passphrase_mismatch": "两次输入的口令不一致",
+	"encrypt.plain_backups":       "%s 中已有的 %d 个备份仍是明文",
+	"encrypt.unlock_hint":         "使用 'todo unlock' 缓存密钥，避免每次输入口令",
+
+	"error.add_task":            "添加任务失败: %v",
+	"error.check_db":            "检查数据库失败: %v",
+	"error.create_project":      "创建项目失败: %v",
+	"error.delete_task":         "删除任务失败: %v",
+	"error.deliveries":          "获取投递记录失败: %v",
+	"error.focus_sessions":      "获取番茄钟记录失败: %v",
+	"error.get_task":            "获取任务失败: %v",
+	"error.invalid_category":    "无效的分类，必须是 work, study, life 或 other",
+	"error.invalid_priority":    "无效的优先级，必须是 1-4",
+	"error.invalid_reminder_id": "无效的提醒 ID",
+	"error.invalid_task_id":     "无效的任务 ID",
+	"error.invalid_webhook_id":  "无效的 Webhook ID",
+	"error.json":                "输出 JSON 失败: %v",
+	"error.list_projects":       "获取项目列表失败: %v",
+	"error.list_tasks":          "获取任务列表失败: %v",
+	"error.not_encrypted":       "数据库没有加密",
+	"error.project_archived":    "项目 %q 已归档，不能添加任务",
+	"error.project_progress":    "获取项目进度失败: %v",
+	"error.quick_add":           "解析快速添加语法失败: %v",
+	"error.running_timer":       "获取计时器失败: %v",
+	"error.search":              "搜索失败: %v",
+	"error.stop_timer":          "停止计时失败: %v",
+	"error.task_not_found":      "任务 %d 不存在",
+	"error.time_entries":        "获取计时记录失败: %v",
+	"error.tracked_time":        "获取累计计时失败: %v",
+	"error.trends":              "获取趋势失败: %v",
+	"error.update_project":      "更新项目失败: %v",
+	"error.update_task":         "更新任务失败: %v",
+
+	"estimate.column_actual":    "实际",
+	"estimate.column_ratio":     "比值",
+	"estimate.column_source":    "来源",
+	"estimate.duration_summary": "按时长估算: %d 个任务，平均 实际/估算 = %.2f，%d 个在 ±25%% 以内",
+	"estimate.none":             "暂无有估算的已完成任务",
+	"estimate.optimistic":       "整体偏乐观：实际耗时普遍超过估算",
+	"estimate.pessimistic":      "整体偏保守：实际耗时普遍少于估算",
+	"estimate.points_summary":   "按故事点估算: %d 个任务，平均每点 %.1f 小时",
+	"estimate.source_elapsed":   "经过时间",
+	"estimate.source_tracked":   "计时",
+	"estimate.tasks":            "%d 个任务",
+	"estimate.title":            "🎯 估算准确度",
+	"estimate.unestimated":      "，%d 个未估算",
+
+	"export.create_failed":  "创建文件失败: %v",
+	"export.done":           "已导出 %d 个任务到 %s",
+	"export.failed":         "导出失败: %v",
+	"export.invalid_format": "无效的导出格式，必须是 jsonl 或 csv",
+
+	"focus.abandoned":         "已放弃，本次完成 %d/%d 个番茄钟",
+	"focus.blocked":           "任务 %d 还在等待前置任务 %s，未标记完成",
+	"focus.break":             "☕ 休息",
+	"focus.break_done":        "休息结束，开始下一轮专注",
+	"focus.completed":         "任务 %d 已完成",
+	"focus.done":              "完成 %d 个番茄钟",
+	"focus.interruptions":     "  (中断 %d 次)",
+	"focus.invalid_config":    "番茄钟配置无效: %v",
+	"focus.round":             "🍅 %d/%d 专注",
+	"focus.round_break":       "第 %d 轮专注结束，休息 %s",
+	"focus.round_done":        "第 %d 轮专注结束",
+	"focus.running":           "任务 %d 正在计时，请先运行 'todo stop'，或使用 --no-track",
+	"focus.save_failed":       "保存番茄钟记录失败: %v",
+	"focus.started":           "开始专注任务 %d: %s (%s × %d)，回车记录中断，Ctrl+C 放弃",
+	"focus.title":             "番茄钟",
+	"focus.transition_failed": "无法更新任务状态: %v",
+
+	"graph.failed":         "获取依赖图失败: %v",
+	"graph.invalid_format": "无效的格式，必须是 dot 或 mermaid",
+	"graph.write_failed":   "输出依赖图失败: %v",
+
+	"list.deferred_hidden": "另有 %d 个延后中的任务已隐藏 (使用 --all 显示)",
+	"list.invalid_sort":    "无效的排序字段,必须是 priority, created_at, updated_at 或 due_at",
+	"list.invalid_state":   "无效的工作流状态,必须是 %s",
+	"list.invalid_status":  "无效的状态,必须是 pending 或 completed",
+	"list.more":            "还有更多任务，使用 --after %s 查看下一页",
+
+	"lock.done": "已清除缓存的密钥",
+	"lock.none": "没有缓存的密钥",
+
+	"log.done":             "已为任务 %d 记录 %s，累计 %s",
+	"log.failed":           "补录耗时失败: %v",
+	"log.invalid_duration": "时长必须大于 0",
+
+	"move.blocked": "任务 %d 还在等待前置任务 %s，使用 --force 强制完成",
+	"move.done":    "任务 %d: %s → %s",
+	"move.failed":  "无法移动任务: %v",
+	"move.next":    "接下来可以移动到: %s",
+
+	"project.archived_msg":   "项目 %q 已归档",
+	"project.created":        "项目已创建 (ID: %d)",
+	"project.exists":         "项目 %q 已存在 (ID: %d)",
+	"project.get_failed":     "获取项目失败: %v",
+	"project.invalid_status": "无效的项目状态，必须是 active, on_hold 或 completed",
+	"project.not_found":      "项目 %q 不存在，可以使用 'todo project create' 创建",
+	"project.unarchived":     "项目 %q 已取消归档",
+	"project.updated":        "项目 %q 已更新",
+
+	"remind.add_failed":    "添加提醒失败: %v",
+	"remind.added":         "提醒已添加 (ID: %d)，将于 %s 触发",
+	"remind.conflict":      "--at 和 --before 只能指定一个",
+	"remind.delete_failed": "删除提醒失败: %v",
+	"remind.deleted":       "提醒 %d 已删除",
+	"remind.list_failed":   "获取提醒列表失败: %v",
+	"remind.missing":       "请使用 --at 或 --before 指定提醒时间",
+	"remind.no_due":        "任务 %d 没有截止时间，无法使用 --before",
+	"remind.snooze_failed": "推迟提醒失败: %v",
+	"remind.snoozed":       "提醒 %d 已推迟到 %s",
+
+	"report.due":                 "截止",
+	"report.failed":              "生成报告失败: %v",
+	"report.generated":           "生成于 %s",
+	"report.heading":             "%s（%s ~ %s）",
+	"report.none":                "无",
+	"report.section_added":       "新增",
+	"report.section_completed":   "已完成",
+	"report.section_heading":     "%s（%d）",
+	"report.section_in_progress": "进行中",
+	"report.section_overdue":     "已逾期",
+	"report.summary":             "总结",
+	"report.summary_failed":      "生成 AI 总结失败: %v",
+	"report.summary_prompt": `下面是我的%s。请用 3 到 5 句话写一段总结：概括完成的重点，指出逾期和进行中的风险，并给出下一步建议。
+只输出总结段落本身，不要标题、不要列表，也不要调用工具。
+
+%s`,
+	"report.template_failed": "加载模板失败: %v",
+	"report.title_day":       "日报",
+	"report.title_week":      "周报",
+	"report.write_failed":    "写入报告失败: %v",
+	"report.written":         "报告已写入 %s",
+
+	"restore.backed_up":      "当前数据库已备份到 %s",
+	"restore.backup_failed":  "备份当前数据库失败，未恢复: %v",
+	"restore.confirm":        "确定要用该备份替换 %s 吗？当前数据会先备份 (y/N): ",
+	"restore.done":           "已从 %s 恢复",
+	"restore.failed":         "恢复失败: %v",
+	"restore.info":           "备份: %s (schema %d, %d 个任务)",
+	"restore.invalid_backup": "无法使用该备份: %v",
+	"restore.undo_hint":      "可以使用 'todo restore %s' 回到恢复前的状态",
+
+	"search.found": "找到 %d 个匹配的任务:",
+	"search.more":  "还有更多匹配的任务，使用 --after %s 查看下一页",
+	"search.none":  "未找到包含 '%s' 的任务",
+
+	"snooze.cleared": "任务 %d 已取消延后",
+	"snooze.done":    "任务 %d 已延后至 %s",
+	"snooze.missing": "请指定延后时间，例如 3d 或 next monday",
+
+	"start.completed": "任务 %d 已完成，不能开始计时",
+	"start.failed":    "开始计时失败: %v",
+	"start.running":   "任务 %d 正在计时，请先运行 'todo stop'，或使用 --switch 切换",
+	"start.started":   "开始为任务 %d 计时: %s",
+	"start.stopped":   "任务 %d 计时已停止，本次 %s",
+
+	"state.blocked":     "受阻",
+	"state.done":        "完成",
+	"state.in_progress": "进行中",
+	"state.review":      "评审",
+	"state.todo":        "待办",
+
+	"stats.accuracy_failed": "获取估算准确度失败: %v",
+	"stats.failed":          "获取统计信息失败: %v",
+	"stats.invalid_period":  "无效的粒度，必须是 day 或 week",
+
+	"stop.done": "任务 %d 计时已停止，本次 %s，累计 %s",
+
+	"task.added":     "任务已添加 (ID: %d)",
+	"task.completed": "任务 %d 已完成",
+
+	"time.invalid":          "无法解析时间 %q，示例：tomorrow 9am、next friday、in 3 days、明天下午三点、2026-11-01",
+	"time.invalid_duration": "无法解析时长 %q，示例：10m、2h、1d12h",
+	"time.invalid_estimate": "无法解析估算 %q，示例：2h、1h30m、3pt",
+
+	"timer.entries_total":       "累计: %s",
+	"timer.focus_interruptions": "，中断 %d 次",
+	"timer.no_entries":          "暂无计时记录",
+	"timer.none":                "没有正在运行的计时器",
+	"timer.running":             "计时中",
+	"timer.running_since":       "   开始于 %s，已计时 %s",
+	"timer.running_task":        "⏱  任务 %d %s",
+	"timer.task_missing":        "(任务不存在)",
+	"timer.tracked":             "⏱  累计计时: %s (%d 条记录)",
+	"timer.tracked_running":     "，计时中",
+
+	"trends.aging":            "🕰  未完成任务存在时长 (共 %d 个):",
+	"trends.aging_over":       "%d 天以上",
+	"trends.aging_range":      "%d-%d 天",
+	"trends.aging_within":     "%d 天内",
+	"trends.column_period":    "周期",
+	"trends.completed":        "完成",
+	"trends.created":          "新建",
+	"trends.days":             "%.1f 天",
+	"trends.lead_by_category": "⏳ 平均完成耗时（按分类）:",
+	"trends.lead_by_priority": "⏳ 平均完成耗时（按优先级）:",
+	"trends.lead_line":        "  • %s: %s (%d 个)",
+	"trends.streak":           "🔥 连续完成: 当前 %d 天，最长 %d 天",
+	"trends.streak_end":       " (截至 %s)",
+	"trends.summary":          "新建 %d 个，完成 %d 个",
+	"trends.title":            "📈 生产力趋势 (%s ~ %s)",
+	"trends.week":             "%s 周",
+
+	"tui.add_title":          " 添加任务 ",
+	"tui.added":              "任务 %d 已添加",
+	"tui.agent_failed":       "无法启动 AI 助手: %v",
+	"tui.blocked":            "任务 %d 还在等待前置任务 %s",
+	"tui.cancel":             "取消",
+	"tui.chat":               " AI 对话 (c) ",
+	"tui.chat_error":         "错误: %v",
+	"tui.chat_failed":        "对话失败",
+	"tui.chat_unavailable":   "AI 对话不可用",
+	"tui.completed":          "任务 %d 已完成",
+	"tui.deleted":            "任务 %d 已删除，按 u 撤销",
+	"tui.detail":             " 详情 ",
+	"tui.edit_title":         " 编辑任务 %d ",
+	"tui.failed":             "终端界面出错: %v",
+	"tui.filter":             " 过滤 / ",
+	"tui.filter_placeholder": "关键词 #分类 +标签 @项目 !优先级 is:open|done|all",
+	"tui.help": `[::b]快捷键[::-]
+
+  j/k ↑/↓   上下移动
+  space/x   切换完成状态
+  a         添加任务
+  e         编辑任务
+  d         删除任务（退出前可以撤销）
+  u         撤销删除
+  /         过滤：关键词 #分类 +标签 @项目 !优先级 is:open|done|all
+  c         切换到 AI 对话
+  Tab       在列表、过滤栏、对话之间切换
+  r         刷新
+  ?         显示帮助
+  q         退出
+
+按 Esc 或 Enter 关闭`,
+	"tui.help_title":      " 帮助 ",
+	"tui.hint":            "按 ? 查看快捷键",
+	"tui.no_task":         "没有任务",
+	"tui.no_terminal":     "全屏界面需要在终端中运行",
+	"tui.nothing_to_undo": "没有可以撤销的删除",
+	"tui.refresh_failed":  "刷新失败: %v",
+	"tui.refreshed":       "已刷新",
+	"tui.reopened":        "任务 %d 已标记为未完成",
+	"tui.restored":        "已恢复任务 %d",
+	"tui.save":            "保存",
+	"tui.save_failed":     "保存任务失败: %v",
+	"tui.tasks":           " 任务 ",
+	"tui.tasks_count":     " 任务 (%d) ",
+	"tui.thinking":        "AI 正在思考...",
+	"tui.title_required":  "标题不能为空",
+	"tui.update_failed":   "更新任务失败: %v",
+	"tui.updated":         "任务 %d 已更新",
+	"tui.you":             " 你: ",
+	"tui.you_said":        "你:",
+
+	"unlock.done":   "已解锁，%s 内不需要再输入口令",
+	"unlock.failed": "缓存密钥失败: %v",
+
+	"webhook.add_failed":          "添加 Webhook 失败: %v",
+	"webhook.added":               "Webhook 已添加 (ID: %d)",
+	"webhook.delete_failed":       "删除 Webhook 失败: %v",
+	"webhook.deleted":             "Webhook %d 已删除",
+	"webhook.delivered":           "投递 %d 已送达",
+	"webhook.delivery_failed":     "投递 %d 失败: %s",
+	"webhook.disabled":            "Webhook %d 已停用",
+	"webhook.enabled":             "Webhook %d 已启用",
+	"webhook.flush_failed":        "投递失败: %v",
+	"webhook.flushed":             "已投递 %d 条记录",
+	"webhook.get_failed":          "获取 Webhook 失败: %v",
+	"webhook.invalid_delivery_id": "无效的投递 ID",
+	"webhook.invalid_event":       "无效的事件类型: %s",
+	"webhook.invalid_filter":      "无效的过滤条件: %v",
+	"webhook.list_failed":         "获取 Webhook 列表失败: %v",
+	"webhook.not_found":           "Webhook %d 不存在",
+	"webhook.nothing_to_retry":    "没有需要重新投递的记录",
+	"webhook.redeliver_failed":    "重新投递 %d 失败: %v",
+	"webhook.retry_usage":         "请指定投递 ID 或使用 --failed",
+	"webhook.secret":              "签名密钥: %s",
+	"webhook.test_failed":         "测试失败: %v",
+	"webhook.tested":              "测试事件已投递到 %s",
+	"webhook.update_failed":       "更新 Webhook 失败: %v",
+
+	"weekday.0": "日",
+	"weekday.1": "一",
+	"weekday.2": "二",
+	"weekday.3": "三",
+	"weekday.4": "四",
+	"weekday.5": "五",
+	"weekday.6": "六",
+
+	"workflow.fallback": "%v，使用默认工作流",
+
+	"workspace.column_db":     "数据库",
+	"workspace.column_name":   "名称",
+	"workspace.create_failed": "创建工作区失败: %v",
+	"workspace.created":       "工作区 %q 已创建 (%s)",
+	"workspace.db_overrides":  "注意: %s 中设置了 db，它优先于 workspace",
+	"workspace.exists":        "工作区 %q 已存在",
+	"workspace.legacy":        "当前目录下的 %s 不再默认使用，现在使用的数据库是 %s。继续使用它可以执行 'todo config set db %s --local'",
+	"workspace.list_failed":   "获取工作区列表失败: %v",
+	"workspace.not_found":     "工作区 %q 不存在，可以使用 'todo workspace create %s' 创建",
+	"workspace.outside":       "当前使用的数据库不属于任何工作区: %s",
+	"workspace.switched":      "已切换到工作区 %q (%s)",
+	"workspace.use_hint":      "使用 'todo workspace use %s' 切换到该工作区",
+}
+
+func init() {
+	for key, message := range zhCNMessages {
+		zhCN[key] = message
+	}
+}
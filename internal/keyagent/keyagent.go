@@ -0,0 +1,150 @@
+// Package keyagent 实现缓存加密数据库密钥的后台代理，类似 ssh-agent。
+//
+// 'todo unlock' 输入口令后把派生出的密钥交给代理，之后的命令通过运行时目录中的
+// unix socket 取回密钥，不需要再次输入口令。每个密钥有过期时间，全部过期或执行
+// 'todo lock' 后代理自动退出。密钥只保存在代理进程的内存中，不会写入磁盘。
+package keyagent
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/workspace"
+)
+
+// Command 启动代理进程使用的隐藏子命令
+const Command = "key-agent"
+
+// 请求类型
+const (
+	opGet  = "get"
+	opAdd  = "add"
+	opLock = "lock"
+)
+
+// dialTimeout 连接和读写 socket 的超时时间
+const dialTimeout = 2 * time.Second
+
+// request 客户端发给代理的请求，每个连接一个请求，JSON 编码并以换行结尾
+type request struct {
+	Op  string `json:"op"`
+	ID  string `json:"id,omitempty"`
+	Key []byte `json:"key,omitempty"`
+	// TTL 密钥的有效期（秒）
+	TTL int64 `json:"ttl,omitempty"`
+}
+
+// response 代理的回复
+type response struct {
+	Key   []byte `json:"key,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// SocketPath 代理监听的 socket 路径
+func SocketPath() (string, error) {
+	dir, err := workspace.RuntimeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "agent.sock"), nil
+}
+
+// Get 从代理取回 id 对应的密钥。代理未运行或没有该密钥时返回 nil
+func Get(id string) ([]byte, error) {
+	resp, err := call(request{Op: opGet, ID: id})
+	if err != nil || resp == nil {
+		return nil, err
+	}
+	return resp.Key, nil
+}
+
+// Add 把密钥交给代理保存 ttl 时间，代理未运行时先启动
+func Add(id string, key []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		return errors.New("ttl must be positive")
+	}
+	req := request{Op: opAdd, ID: id, Key: key, TTL: int64(ttl / time.Second)}
+	resp, err := call(req)
+	if err != nil {
+		return err
+	}
+	if resp != nil {
+		return nil
+	}
+
+	if err := start(); err != nil {
+		return err
+	}
+	resp, err = call(req)
+	if err == nil && resp == nil {
+		err = errors.New("key agent is not running")
+	}
+	return err
+}
+
+// Lock 清除代理中的所有密钥，代理随后退出。代理未运行时返回 false
+func Lock() (bool, error) {
+	resp, err := call(request{Op: opLock})
+	return resp != nil, err
+}
+
+// call 发送请求并读取回复。代理未运行时返回 nil, nil
+func call(req request) (*response, error) {
+	path, err := SocketPath()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.DialTimeout("unix", path, dialTimeout)
+	if err != nil {
+		// socket 不存在或是上次退出留下的残留文件
+		return nil, nil
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(dialTimeout))
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return nil, fmt.Errorf("failed to send request to key agent: %w", err)
+	}
+	var resp response
+	if err := json.NewDecoder(bufio.NewReader(conn)).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to read key agent response: %w", err)
+	}
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+	return &resp, nil
+}
+
+// start 以后台进程启动代理，等待 socket 可用
+func start() error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to start key agent: %w", err)
+	}
+	cmd := exec.Command(exe, Command)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start key agent: %w", err)
+	}
+	cmd.Process.Release()
+
+	path, err := SocketPath()
+	if err != nil {
+		return err
+	}
+	deadline := time.Now().Add(dialTimeout)
+	for time.Now().Before(deadline) {
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	return errors.New("key agent did not start in time")
+}
@@ -0,0 +1,135 @@
+package keyagent
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// entry 缓存的密钥
+type entry struct {
+	key       []byte
+	expiresAt time.Time
+}
+
+// server 代理进程的状态
+type server struct {
+	mu       sync.Mutex
+	keys     map[string]entry
+	listener net.Listener
+}
+
+// Serve 在运行时目录中监听 socket 并处理请求，直到所有密钥过期或被清除。
+// 已有代理在运行时返回错误
+func Serve() error {
+	path, err := SocketPath()
+	if err != nil {
+		return err
+	}
+	if conn, err := net.DialTimeout("unix", path, dialTimeout); err == nil {
+		conn.Close()
+		return fmt.Errorf("key agent is already running (%s)", path)
+	}
+	// 上次退出留下的 socket 文件
+	os.Remove(path)
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	defer os.Remove(path)
+	if err := os.Chmod(path, 0o600); err != nil {
+		listener.Close()
+		return fmt.Errorf("failed to protect %s: %w", path, err)
+	}
+
+	s := &server{keys: make(map[string]entry), listener: listener}
+	go s.expireLoop()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			// 监听被关闭，代理退出
+			return nil
+		}
+		go s.handle(conn)
+	}
+}
+
+// handle 处理一个连接上的请求
+func (s *server) handle(conn net.Conn) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(dialTimeout))
+
+	var req request
+	if err := json.NewDecoder(bufio.NewReader(conn)).Decode(&req); err != nil {
+		return
+	}
+	json.NewEncoder(conn).Encode(s.apply(req))
+	if req.Op == opLock {
+		// 回复发出后再关闭监听，代理随后退出
+		s.listener.Close()
+	}
+}
+
+// apply 执行请求
+func (s *server) apply(req request) response {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch req.Op {
+	case opGet:
+		e, ok := s.keys[req.ID]
+		if !ok || time.Now().After(e.expiresAt) {
+			return response{}
+		}
+		return response{Key: e.key}
+	case opAdd:
+		if req.ID == "" || len(req.Key) == 0 || req.TTL <= 0 {
+			return response{Error: "invalid add request"}
+		}
+		s.keys[req.ID] = entry{key: req.Key, expiresAt: time.Now().Add(time.Duration(req.TTL) * time.Second)}
+		return response{}
+	case opLock:
+		s.clear()
+		return response{}
+	}
+	return response{Error: fmt.Sprintf("unknown request %q", req.Op)}
+}
+
+// expireLoop 定期删除过期的密钥，没有密钥时关闭监听让代理退出。
+// 刚启动时还没有收到第一个密钥，等待一段时间再检查
+func (s *server) expireLoop() {
+	started := time.Now()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for range ticker.C {
+		s.mu.Lock()
+		now := time.Now()
+		for id, e := range s.keys {
+			if now.After(e.expiresAt) {
+				clear(e.key)
+				delete(s.keys, id)
+			}
+		}
+		empty := len(s.keys) == 0
+		s.mu.Unlock()
+
+		if empty && time.Since(started) > dialTimeout {
+			s.listener.Close()
+			return
+		}
+	}
+}
+
+// clear 清零并删除所有密钥，调用方持有锁
+func (s *server) clear() {
+	for id, e := range s.keys {
+		clear(e.key)
+		delete(s.keys, id)
+	}
+}
@@ -0,0 +1,113 @@
+package models
+
+import (
+	"time"
+)
+
+// dateLayout 议程和日历中日期的 JSON 格式
+const dateLayout = "2006-01-02"
+
+// AgendaDay 某一天到期的任务
+type AgendaDay struct {
+	Date  string  `json:"date"`
+	Tasks []*Task `json:"tasks"`
+	// Day 当天 0 点（本地时间）
+	Day time.Time `json:"-"`
+}
+
+// Agenda 议程：已逾期的任务和从今天开始每天到期的任务
+type Agenda struct {
+	From    time.Time   `json:"from"`
+	To      time.Time   `json:"to"`
+	Overdue []*Task     `json:"overdue"`
+	Days    []AgendaDay `json:"days"`
+}
+
+// NewAgenda 将任务按截止时间分为已逾期和今天起 days+1 天内的每一天，
+// tasks 应按截止时间排序，没有截止时间或超出范围的任务会被忽略
+func NewAgenda(tasks []*Task, now time.Time, days int) *Agenda {
+	today := GranularityDay.PeriodStart(now)
+	agenda := &Agenda{
+		From:    today,
+		To:      AgendaEnd(now, days),
+		Overdue: []*Task{},
+	}
+	for i := 0; i <= days; i++ {
+		day := today.AddDate(0, 0, i)
+		agenda.Days = append(agenda.Days, AgendaDay{Date: day.Format(dateLayout), Day: day, Tasks: []*Task{}})
+	}
+
+	for _, task := range tasks {
+		if task.DueAt == nil {
+			continue
+		}
+		if task.IsOverdue(now) {
+			agenda.Overdue = append(agenda.Overdue, task)
+			continue
+		}
+		i := int(GranularityDay.PeriodStart(*task.DueAt).Sub(today).Hours()+12) / 24
+		if i >= 0 && i < len(agenda.Days) {
+			agenda.Days[i].Tasks = append(agenda.Days[i].Tasks, task)
+		}
+	}
+	return agenda
+}
+
+// AgendaEnd 议程范围的结束时间：今天之后第 days 天的最后一刻
+func AgendaEnd(now time.Time, days int) time.Time {
+	return GranularityDay.PeriodStart(now).AddDate(0, 0, days+1).Add(-time.Nanosecond)
+}
+
+// CalendarDay 月历中的一天
+type CalendarDay struct {
+	Date      string  `json:"date"`
+	Due       int     `json:"due"`
+	Completed int     `json:"completed"`
+	Overdue   int     `json:"overdue"`
+	TaskIDs   []int64 `json:"task_ids"`
+	// Day 当天 0 点（本地时间）
+	Day time.Time `json:"-"`
+}
+
+// Calendar 一个月内每天到期的任务数
+type Calendar struct {
+	Month string        `json:"month"`
+	Days  []CalendarDay `json:"days"`
+	// Start 当月 1 日 0 点（本地时间）
+	Start time.Time `json:"-"`
+}
+
+// MonthRange 返回 t 所在月份的第一天 0 点和最后一天的最后一刻
+func MonthRange(t time.Time) (time.Time, time.Time) {
+	t = t.Local()
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.Local)
+	return start, start.AddDate(0, 1, 0).Add(-time.Nanosecond)
+}
+
+// NewCalendar 统计 month 所在月份每天到期、已完成和逾期的任务，范围外的任务会被忽略
+func NewCalendar(tasks []*Task, month, now time.Time) *Calendar {
+	start, end := MonthRange(month)
+	calendar := &Calendar{Month: start.Format("2006-01"), Start: start}
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		calendar.Days = append(calendar.Days, CalendarDay{Date: day.Format(dateLayout), Day: day, TaskIDs: []int64{}})
+	}
+
+	for _, task := range tasks {
+		if task.DueAt == nil {
+			continue
+		}
+		due := task.DueAt.Local()
+		if due.Before(start) || due.After(end) {
+			continue
+		}
+		day := &calendar.Days[due.Day()-1]
+		day.Due++
+		day.TaskIDs = append(day.TaskIDs, task.ID)
+		if task.Status == StatusCompleted {
+			day.Completed++
+		} else if task.IsOverdue(now) {
+			day.Overdue++
+		}
+	}
+	return calendar
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// 自动备份的原因，用于备份文件名
+const (
+	BackupReasonPreRestore = "pre-restore"
+	BackupReasonPreRepair  = "pre-repair"
+)
+
+// BackupReasonPreMigration 升级到指定 schema 版本之前的自动备份
+func BackupReasonPreMigration(version int) string {
+	return fmt.Sprintf("pre-v%d", version)
+}
+
+// BackupFile 一个备份文件
+type BackupFile struct {
+	Path      string    `json:"path"`
+	CreatedAt time.Time `json:"created_at"`
+	Size      int64     `json:"size"`
+	// Reason 自动备份的原因，手动备份为空
+	Reason string `json:"reason,omitempty"`
+}
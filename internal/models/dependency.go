@@ -0,0 +1,101 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrSelfDependency 任务不能依赖自己
+	ErrSelfDependency = errors.New("task cannot depend on itself")
+	// ErrDependencyCycle 添加依赖会形成环
+	ErrDependencyCycle = errors.New("dependency cycle")
+)
+
+// DependencyGraph 任务依赖图：任务 ID → 它依赖的前置任务 ID
+type DependencyGraph map[int64][]int64
+
+// Add 添加一条依赖边
+func (g DependencyGraph) Add(taskID, dependsOnID int64) {
+	g[taskID] = append(g[taskID], dependsOnID)
+}
+
+// Path 沿依赖方向查找从 from 到 to 的路径，不存在时返回 nil
+func (g DependencyGraph) Path(from, to int64) []int64 {
+	prev := map[int64]int64{from: from}
+	queue := []int64{from}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == to {
+			path := []int64{to}
+			for current != from {
+				current = prev[current]
+				path = append([]int64{current}, path...)
+			}
+			return path
+		}
+		for _, next := range g[current] {
+			if _, seen := prev[next]; !seen {
+				prev[next] = current
+				queue = append(queue, next)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckEdge 检查添加 taskID 依赖 dependsOnID 是否合法（不能自依赖、不能形成环）
+func (g DependencyGraph) CheckEdge(taskID, dependsOnID int64) error {
+	if taskID == dependsOnID {
+		return ErrSelfDependency
+	}
+	// 前置任务已经（间接）依赖当前任务时，再加这条边就会形成环
+	if path := g.Path(dependsOnID, taskID); path != nil {
+		return &CycleError{Path: append([]int64{taskID}, path...)}
+	}
+	return nil
+}
+
+// CycleError 添加依赖会形成的环，Path 从新依赖的任务开始并回到该任务
+type CycleError struct {
+	Path []int64
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("%v: %s", ErrDependencyCycle, e.PathString())
+}
+
+func (e *CycleError) Unwrap() error {
+	return ErrDependencyCycle
+}
+
+// PathString 形如 "2 -> 3 -> 2" 的环路径
+func (e *CycleError) PathString() string {
+	ids := make([]string, len(e.Path))
+	for i, id := range e.Path {
+		ids[i] = fmt.Sprint(id)
+	}
+	return strings.Join(ids, " -> ")
+}
+
+// Nodes 图中出现的所有任务 ID，升序
+func (g DependencyGraph) Nodes() []int64 {
+	seen := make(map[int64]bool)
+	for taskID, deps := range g {
+		seen[taskID] = true
+		for _, id := range deps {
+			seen[id] = true
+		}
+	}
+
+	nodes := make([]int64, 0, len(seen))
+	for id := range seen {
+		nodes = append(nodes, id)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i] < nodes[j] })
+	return nodes
+}
@@ -0,0 +1,55 @@
+package models
+
+// 数据库检查项
+const (
+	CheckIntegrity           = "integrity"
+	CheckSchema              = "schema"
+	CheckIndexes             = "indexes"
+	CheckOrphanDependencies  = "orphan_dependencies"
+	CheckOrphanReminders     = "orphan_reminders"
+	CheckOrphanTimeEntries   = "orphan_time_entries"
+	CheckOrphanFocusSessions = "orphan_focus_sessions"
+	CheckOrphanProjects      = "orphan_projects"
+	CheckOrphanDeliveries    = "orphan_deliveries"
+)
+
+// HealthCheck 一项数据库检查的结果
+type HealthCheck struct {
+	Name string `json:"name"`
+	// Problems 发现的问题，例如 integrity_check 的输出、缺失的索引、"#3 → #12"（第 3 行引用了不存在的 12）
+	Problems []string `json:"problems,omitempty"`
+	// Fixable 为 true 时发现的问题可以通过 'todo doctor --fix' 自动修复
+	Fixable bool `json:"fixable,omitempty"`
+}
+
+// OK 是否没有发现问题
+func (c HealthCheck) OK() bool {
+	return len(c.Problems) == 0
+}
+
+// HealthReport 数据库检查报告
+type HealthReport struct {
+	Path          string        `json:"path"`
+	SchemaVersion int           `json:"schema_version"`
+	Checks        []HealthCheck `json:"checks"`
+}
+
+// Healthy 所有检查都没有发现问题
+func (r *HealthReport) Healthy() bool {
+	for _, check := range r.Checks {
+		if !check.OK() {
+			return false
+		}
+	}
+	return true
+}
+
+// Fixable 是否有可以自动修复的问题
+func (r *HealthReport) Fixable() bool {
+	for _, check := range r.Checks {
+		if !check.OK() && check.Fixable {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/dateparse"
+)
+
+// Estimate 工作量估算，按时长或故事点估算，二者只使用其一
+type Estimate struct {
+	Duration time.Duration `json:"duration,omitempty"`
+	Points   float64       `json:"points,omitempty"`
+}
+
+// IsZero 是否未估算
+func (e Estimate) IsZero() bool {
+	return e.Duration == 0 && e.Points == 0
+}
+
+// String 格式化估算，例如 "2h30m" 或 "3pt"
+func (e Estimate) String() string {
+	switch {
+	case e.Points != 0:
+		return strconv.FormatFloat(e.Points, 'f', -1, 64) + "pt"
+	case e.Duration != 0:
+		return FormatDuration(e.Duration)
+	default:
+		return ""
+	}
+}
+
+var pointsPattern = regexp.MustCompile(`(?i)^(\d+(?:\.\d+)?)\s*(pt|pts|sp|points?|点)$`)
+
+// ParseEstimate 解析估算，支持时长（2h、1h30m、1d）和故事点（3pt、5sp、2 points）。
+// "0" 或 "none" 表示清除估算。
+func ParseEstimate(value string) (Estimate, error) {
+	value = strings.TrimSpace(value)
+	switch strings.ToLower(value) {
+	case "0", "none", "":
+		return Estimate{}, nil
+	}
+
+	if m := pointsPattern.FindStringSubmatch(value); m != nil {
+		points, err := strconv.ParseFloat(m[1], 64)
+		if err != nil {
+			return Estimate{}, err
+		}
+		return Estimate{Points: points}, nil
+	}
+
+	d, err := dateparse.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return Estimate{}, fmt.Errorf("invalid estimate %q", value)
+	}
+	return Estimate{Duration: d}, nil
+}
+
+// EffortBucket 某个分类或项目下未完成任务的剩余工作量
+type EffortBucket struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+	// Tasks 有估算的未完成任务数，Unestimated 没有估算的未完成任务数
+	Tasks       int `json:"tasks"`
+	Unestimated int `json:"unestimated"`
+	// Remaining 时长估算减去已计时时长（不小于 0）之和
+	Remaining time.Duration `json:"remaining,omitempty"`
+	Points    float64       `json:"points,omitempty"`
+}
+
+// EffortSummary 剩余工作量汇总
+type EffortSummary struct {
+	Total      EffortBucket   `json:"total"`
+	ByCategory []EffortBucket `json:"by_category,omitempty"`
+	ByProject  []EffortBucket `json:"by_project,omitempty"`
+}
+
+// EstimateAccuracy 单个已完成任务的估算与实际耗时对比
+type EstimateAccuracy struct {
+	TaskID   int64         `json:"task_id"`
+	Title    string        `json:"title"`
+	Estimate Estimate      `json:"estimate"`
+	Actual   time.Duration `json:"actual"`
+	// Source 实际耗时来源：tracked 为计时记录，elapsed 为创建到完成的时间
+	Source string `json:"source"`
+	// Ratio 实际耗时 / 估算时长，仅时长估算有意义
+	Ratio float64 `json:"ratio,omitempty"`
+}
+
+// AccuracyReport 估算准确度报告
+type AccuracyReport struct {
+	Tasks []EstimateAccuracy `json:"tasks"`
+	// MeanRatio 时长估算的平均 实际/估算 比值，大于 1 表示普遍低估
+	MeanRatio float64 `json:"mean_ratio,omitempty"`
+	// WithinRange 实际耗时落在估算 ±25% 内的任务数
+	WithinRange   int `json:"within_range"`
+	DurationTasks int `json:"duration_tasks"`
+	// HoursPerPoint 故事点估算的任务平均每点耗时（小时）
+	HoursPerPoint float64 `json:"hours_per_point,omitempty"`
+	PointTasks    int     `json:"point_tasks"`
+}
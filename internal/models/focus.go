@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+)
+
+// FocusSession 一个番茄钟（一轮专注）的记录
+type FocusSession struct {
+	ID        int64         `json:"id"`
+	TaskID    int64         `json:"task_id"`
+	StartedAt time.Time     `json:"started_at"`
+	EndedAt   time.Time     `json:"ended_at"`
+	Planned   time.Duration `json:"planned"`
+	// Completed 为 false 表示本轮被放弃
+	Completed     bool `json:"completed"`
+	Interruptions int  `json:"interruptions"`
+	// Notes 中断原因，每行一条
+	Notes     string    `json:"notes,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Duration 实际专注时长
+func (s *FocusSession) Duration() time.Duration {
+	return s.EndedAt.Sub(s.StartedAt)
+}
+
+// FocusStats 番茄钟统计
+type FocusStats struct {
+	Completed     int           `json:"completed"`
+	Abandoned     int           `json:"abandoned"`
+	Interruptions int           `json:"interruptions"`
+	FocusTime     time.Duration `json:"focus_time"`
+}
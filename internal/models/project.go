@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// ProjectStatus 项目状态
+type ProjectStatus string
+
+const (
+	ProjectActive    ProjectStatus = "active"
+	ProjectOnHold    ProjectStatus = "on_hold"
+	ProjectCompleted ProjectStatus = "completed"
+)
+
+// IsValid 是否为合法的项目状态
+func (s ProjectStatus) IsValid() bool {
+	switch s {
+	case ProjectActive, ProjectOnHold, ProjectCompleted:
+		return true
+	}
+	return false
+}
+
+// Project 项目，任务可以归属于某个项目
+type Project struct {
+	ID          int64         `json:"id"`
+	Name        string        `json:"name"`
+	Description string        `json:"description,omitempty"`
+	Status      ProjectStatus `json:"status"`
+	Archived    bool          `json:"archived"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+// NewProject 创建新项目
+func NewProject(name, description string) *Project {
+	now := time.Now()
+	return &Project{
+		Name:        name,
+		Description: description,
+		Status:      ProjectActive,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// ProjectProgress 项目进度
+type ProjectProgress struct {
+	Project        *Project `json:"project"`
+	Total          int      `json:"total"`
+	Completed      int      `json:"completed"`
+	CompletionRate float64  `json:"completion_rate"`
+}
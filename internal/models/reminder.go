@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+)
+
+// Reminder 任务提醒。RemindAt 为绝对时间；为空时按截止时间前 Offset 触发。
+type Reminder struct {
+	ID           int64         `json:"id"`
+	TaskID       int64         `json:"task_id"`
+	RemindAt     *time.Time    `json:"remind_at,omitempty"`
+	Offset       time.Duration `json:"offset,omitempty"`
+	SnoozedUntil *time.Time    `json:"snoozed_until,omitempty"`
+	FiredAt      *time.Time    `json:"fired_at,omitempty"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+// TriggerAt 计算提醒的触发时间。基于截止时间的提醒在任务没有截止时间时无法触发。
+func (r *Reminder) TriggerAt(task *Task) (time.Time, bool) {
+	if r.SnoozedUntil != nil {
+		return *r.SnoozedUntil, true
+	}
+	if r.RemindAt != nil {
+		return *r.RemindAt, true
+	}
+	if task != nil && task.DueAt != nil {
+		return task.DueAt.Add(-r.Offset), true
+	}
+	return time.Time{}, false
+}
+
+// NewReminderAt 创建绝对时间提醒
+func NewReminderAt(taskID int64, at time.Time) *Reminder {
+	return &Reminder{TaskID: taskID, RemindAt: &at, CreatedAt: time.Now()}
+}
+
+// NewReminderBeforeDue 创建截止时间前的提醒
+func NewReminderBeforeDue(taskID int64, offset time.Duration) *Reminder {
+	return &Reminder{TaskID: taskID, Offset: offset, CreatedAt: time.Now()}
+}
+
+// DueReminder 到期提醒及其任务
+type DueReminder struct {
+	Reminder  *Reminder `json:"reminder"`
+	Task      *Task     `json:"task"`
+	TriggerAt time.Time `json:"trigger_at"`
+}
@@ -43,11 +43,22 @@ type Task struct {
 	CreatedAt   time.Time    `json:"created_at"`
 	UpdatedAt   time.Time    `json:"updated_at"`
 	CompletedAt *time.Time   `json:"completed_at,omitempty"`
+	DueAt       *time.Time   `json:"due_at,omitempty"`
+	DeferUntil  *time.Time   `json:"defer_until,omitempty"`
+	Tags        []string     `json:"tags,omitempty"`
+	ProjectID   int64        `json:"project_id,omitempty"`
+	// State 工作流状态，为空时由 Status 推导，见 Workflow.StateOf
+	State string `json:"state,omitempty"`
+	// Estimate 工作量估算
+	Estimate Estimate `json:"estimate,omitzero"`
+	// BlockedBy 尚未完成的前置任务 ID，查询时填充，不单独保存
+	BlockedBy []int64 `json:"blocked_by,omitempty"`
 }
 
 // MarkCompleted 标记为已完成
 func (t *Task) MarkCompleted() {
 	t.Status = StatusCompleted
+	t.State = ""
 	now := time.Now()
 	t.CompletedAt = &now
 	t.UpdatedAt = now
@@ -56,10 +67,50 @@ func (t *Task) MarkCompleted() {
 // MarkPending 标记为未完成
 func (t *Task) MarkPending() {
 	t.Status = StatusPending
+	t.State = ""
 	t.CompletedAt = nil
 	t.UpdatedAt = time.Now()
 }
 
+// IsOverdue 是否已过截止时间且未完成
+func (t *Task) IsOverdue(now time.Time) bool {
+	return t.Status != StatusCompleted && t.DueAt != nil && t.DueAt.Before(now)
+}
+
+// IsBlocked 是否还有未完成的前置任务
+func (t *Task) IsBlocked() bool {
+	return t.Status != StatusCompleted && len(t.BlockedBy) > 0
+}
+
+// IsDeferred 是否处于延后状态（延后时间未到）
+func (t *Task) IsDeferred(now time.Time) bool {
+	return t.DeferUntil != nil && t.DeferUntil.After(now)
+}
+
+// Defer 延后任务到指定时间，传入 nil 取消延后
+func (t *Task) Defer(until *time.Time) {
+	t.DeferUntil = until
+	t.UpdatedAt = time.Now()
+}
+
+// HasTag 是否带有指定标签
+func (t *Task) HasTag(tag string) bool {
+	for _, existing := range t.Tags {
+		if existing == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTag 添加标签，已存在时忽略
+func (t *Task) AddTag(tag string) {
+	if tag == "" || t.HasTag(tag) {
+		return
+	}
+	t.Tags = append(t.Tags, tag)
+}
+
 // NewTask 创建新任务
 func NewTask(title, description string, category TaskCategory, priority Priority) *Task {
 	now := time.Now()
@@ -82,4 +133,15 @@ type Statistics struct {
 	CompletionRate float64                `json:"completion_rate"`
 	ByCategory     map[TaskCategory]int   `json:"by_category"`
 	ByPriority     map[Priority]int       `json:"by_priority"`
+	ByState        []StateCount           `json:"by_state,omitempty"`
+	Time           *TimeSummary           `json:"time,omitempty"`
+	Focus          *FocusStats            `json:"focus,omitempty"`
+	Remaining      *EffortSummary         `json:"remaining,omitempty"`
+}
+
+// StateCount 某个工作流状态下的任务数
+type StateCount struct {
+	State string `json:"state"`
+	Label string `json:"label"`
+	Count int    `json:"count"`
 }
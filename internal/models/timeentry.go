@@ -0,0 +1,85 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrTimerRunning 已有正在运行的计时器
+var ErrTimerRunning = errors.New("a timer is already running")
+
+// TimeEntry 任务的一段计时记录。EndedAt 为空表示计时器仍在运行。
+type TimeEntry struct {
+	ID        int64      `json:"id"`
+	TaskID    int64      `json:"task_id"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+	Note      string     `json:"note,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NewTimeEntry 创建从 start 开始计时的记录
+func NewTimeEntry(taskID int64, start time.Time) *TimeEntry {
+	return &TimeEntry{TaskID: taskID, StartedAt: start, CreatedAt: time.Now()}
+}
+
+// NewLoggedTimeEntry 创建手动补录的记录，以 end 为结束时间向前推 d
+func NewLoggedTimeEntry(taskID int64, d time.Duration, end time.Time, note string) *TimeEntry {
+	return &TimeEntry{
+		TaskID:    taskID,
+		StartedAt: end.Add(-d),
+		EndedAt:   &end,
+		Note:      note,
+		CreatedAt: time.Now(),
+	}
+}
+
+// IsRunning 计时器是否仍在运行
+func (e *TimeEntry) IsRunning() bool {
+	return e.EndedAt == nil
+}
+
+// Duration 记录的时长，运行中的计时器计算到 now
+func (e *TimeEntry) Duration(now time.Time) time.Duration {
+	end := now
+	if e.EndedAt != nil {
+		end = *e.EndedAt
+	}
+	if end.Before(e.StartedAt) {
+		return 0
+	}
+	return end.Sub(e.StartedAt)
+}
+
+// TimeBucket 某个维度（分类、项目、日期）下的累计时长
+type TimeBucket struct {
+	Key      string        `json:"key"`
+	Label    string        `json:"label"`
+	Duration time.Duration `json:"duration"`
+	Hours    float64       `json:"hours"`
+}
+
+// TimeSummary 计时汇总
+type TimeSummary struct {
+	Total      time.Duration `json:"total"`
+	TotalHours float64       `json:"total_hours"`
+	ByCategory []TimeBucket  `json:"by_category,omitempty"`
+	ByProject  []TimeBucket  `json:"by_project,omitempty"`
+	ByDay      []TimeBucket  `json:"by_day,omitempty"`
+}
+
+// FormatDuration 将时长格式化为 1h30m 形式，精确到分钟
+func FormatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	hours := int(d / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dh%02dm", hours, minutes)
+	}
+}
@@ -0,0 +1,126 @@
+package models
+
+import (
+	"time"
+)
+
+// Granularity 趋势统计的时间粒度
+type Granularity string
+
+const (
+	GranularityDay  Granularity = "day"
+	GranularityWeek Granularity = "week"
+)
+
+// IsValid 是否为支持的粒度
+func (g Granularity) IsValid() bool {
+	return g == GranularityDay || g == GranularityWeek
+}
+
+// PeriodStart 返回 t 所在周期的开始时间（本地时间 0 点，周从周一开始）
+func (g Granularity) PeriodStart(t time.Time) time.Time {
+	t = t.Local()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+	if g == GranularityWeek {
+		offset := (int(day.Weekday()) + 6) % 7
+		day = day.AddDate(0, 0, -offset)
+	}
+	return day
+}
+
+// Next 返回下一个周期的开始时间
+func (g Granularity) Next(start time.Time) time.Time {
+	if g == GranularityWeek {
+		return start.AddDate(0, 0, 7)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// Label 周期的显示名称
+func (g Granularity) Label(start time.Time) string {
+	if g == GranularityWeek {
+		return start.Format("01-02") + " 周"
+	}
+	return start.Format("01-02")
+}
+
+// RangeStart 返回截至 now、长度为 span 的统计范围的开始时间。
+// 范围按整天计算并包含今天，例如 7d 表示从 6 天前的 0 点开始。
+func RangeStart(now time.Time, span time.Duration) time.Time {
+	return GranularityDay.PeriodStart(now).Add(-span).AddDate(0, 0, 1)
+}
+
+// TrendPeriod 一个周期内新建和完成的任务数
+type TrendPeriod struct {
+	Start     time.Time `json:"start"`
+	Label     string    `json:"label"`
+	Created   int       `json:"created"`
+	Completed int       `json:"completed"`
+}
+
+// LeadTime 一组已完成任务从创建到完成的平均耗时，Key 为分类或优先级
+type LeadTime struct {
+	Key     string        `json:"key"`
+	Count   int           `json:"count"`
+	Average time.Duration `json:"average"`
+	Days    float64       `json:"average_days"`
+}
+
+// Streak 连续有任务完成的天数
+type Streak struct {
+	Current int `json:"current"`
+	Longest int `json:"longest"`
+	// LongestEnd 最长连续记录的最后一天
+	LongestEnd *time.Time `json:"longest_end,omitempty"`
+}
+
+// AgingBucket 未完成任务按存在时长分组
+type AgingBucket struct {
+	Label   string `json:"label"`
+	MinDays int    `json:"min_days"`
+	// MaxDays 为 0 表示没有上限
+	MaxDays int `json:"max_days,omitempty"`
+	Count   int `json:"count"`
+}
+
+// agingBuckets 未完成任务的存在时长分组
+var agingBuckets = []AgingBucket{
+	{Label: "7 天内", MinDays: 0, MaxDays: 7},
+	{Label: "8-30 天", MinDays: 8, MaxDays: 30},
+	{Label: "31-90 天", MinDays: 31, MaxDays: 90},
+	{Label: "90 天以上", MinDays: 91},
+}
+
+// NewAgingBuckets 返回一组空的存在时长分组
+func NewAgingBuckets() []AgingBucket {
+	buckets := make([]AgingBucket, len(agingBuckets))
+	copy(buckets, agingBuckets)
+	return buckets
+}
+
+// AddAge 将存在 days 天的任务计入对应分组
+func AddAge(buckets []AgingBucket, days int) {
+	for i := range buckets {
+		if days >= buckets[i].MinDays && (buckets[i].MaxDays == 0 || days <= buckets[i].MaxDays) {
+			buckets[i].Count++
+			return
+		}
+	}
+}
+
+// ProductivityTrends 一段时间内的生产力趋势
+type ProductivityTrends struct {
+	From        time.Time     `json:"from"`
+	To          time.Time     `json:"to"`
+	Granularity Granularity   `json:"granularity"`
+	Periods     []TrendPeriod `json:"periods"`
+	Created     int           `json:"created"`
+	Completed   int           `json:"completed"`
+	// LeadTimeByCategory、LeadTimeByPriority 统计周期内完成的任务
+	LeadTimeByCategory []LeadTime `json:"lead_time_by_category,omitempty"`
+	LeadTimeByPriority []LeadTime `json:"lead_time_by_priority,omitempty"`
+	Streak             Streak     `json:"streak"`
+	// Aging 当前所有未完成任务的存在时长分布
+	Aging     []AgingBucket `json:"aging"`
+	OpenTasks int           `json:"open_tasks"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+)
+
+// Webhook Webhook 订阅
+type Webhook struct {
+	ID        int64     `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"-"`
+	Events    []string  `json:"events,omitempty"`
+	Filter    string    `json:"filter,omitempty"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DeliveryStatus 投递状态
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+// WebhookDelivery Webhook 投递记录（发件箱）
+type WebhookDelivery struct {
+	ID            int64          `json:"id"`
+	WebhookID     int64          `json:"webhook_id"`
+	Event         string         `json:"event"`
+	Payload       string         `json:"payload"`
+	Status        DeliveryStatus `json:"status"`
+	Attempts      int            `json:"attempts"`
+	NextAttemptAt time.Time      `json:"next_attempt_at"`
+	LastError     string         `json:"last_error,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+	DeliveredAt   *time.Time     `json:"delivered_at,omitempty"`
+}
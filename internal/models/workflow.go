@@ -0,0 +1,206 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrUnknownState 工作流中不存在该状态
+	ErrUnknownState = errors.New("unknown workflow state")
+	// ErrTransitionNotAllowed 工作流不允许该状态转换
+	ErrTransitionNotAllowed = errors.New("transition not allowed")
+)
+
+// WorkflowState 工作流中的一个状态
+type WorkflowState struct {
+	Name  string `json:"name"`
+	Label string `json:"label,omitempty"`
+	// Done 为 true 时进入该状态即视为任务完成
+	Done bool `json:"done,omitempty"`
+}
+
+// DisplayName 显示名称，未设置 Label 时使用 Name
+func (s WorkflowState) DisplayName() string {
+	if s.Label != "" {
+		return s.Label
+	}
+	return s.Name
+}
+
+// Workflow 看板工作流：状态列表、初始状态和允许的状态转换。
+//
+// 任务的 Status (pending/completed) 由工作流状态决定：进入 Done 状态时标记完成，
+// 离开 Done 状态时标记未完成。State 为空的任务按 Status 映射到初始状态或第一个完成状态。
+type Workflow struct {
+	States      []WorkflowState     `json:"states"`
+	Initial     string              `json:"initial"`
+	Transitions map[string][]string `json:"transitions"`
+}
+
+// DefaultWorkflow 默认工作流：todo → in_progress → review → done，任何未完成状态都可以进入 blocked
+func DefaultWorkflow() *Workflow {
+	return &Workflow{
+		States: []WorkflowState{
+			{Name: "todo", Label: "待办"},
+			{Name: "in_progress", Label: "进行中"},
+			{Name: "blocked", Label: "受阻"},
+			{Name: "review", Label: "评审"},
+			{Name: "done", Label: "完成", Done: true},
+		},
+		Initial: "todo",
+		Transitions: map[string][]string{
+			"todo":        {"in_progress", "blocked", "done"},
+			"in_progress": {"todo", "blocked", "review", "done"},
+			"blocked":     {"todo", "in_progress"},
+			"review":      {"in_progress", "blocked", "done"},
+			"done":        {"todo", "in_progress"},
+		},
+	}
+}
+
+// Validate 检查工作流定义是否完整一致
+func (w *Workflow) Validate() error {
+	if len(w.States) == 0 {
+		return fmt.Errorf("workflow has no states")
+	}
+
+	seen := make(map[string]bool)
+	hasDone := false
+	for _, state := range w.States {
+		if state.Name == "" {
+			return fmt.Errorf("workflow state name is empty")
+		}
+		if seen[state.Name] {
+			return fmt.Errorf("duplicate workflow state %q", state.Name)
+		}
+		seen[state.Name] = true
+		hasDone = hasDone || state.Done
+	}
+
+	if !hasDone {
+		return fmt.Errorf("workflow needs at least one done state")
+	}
+	if !seen[w.Initial] {
+		return fmt.Errorf("initial state %q is not defined", w.Initial)
+	}
+	if w.isDone(w.Initial) {
+		return fmt.Errorf("initial state %q cannot be a done state", w.Initial)
+	}
+
+	for from, targets := range w.Transitions {
+		if !seen[from] {
+			return fmt.Errorf("transition from undefined state %q", from)
+		}
+		for _, to := range targets {
+			if !seen[to] {
+				return fmt.Errorf("transition from %q to undefined state %q", from, to)
+			}
+		}
+	}
+
+	return nil
+}
+
+// State 按名称查找状态
+func (w *Workflow) State(name string) (WorkflowState, bool) {
+	for _, state := range w.States {
+		if state.Name == name {
+			return state, true
+		}
+	}
+	return WorkflowState{}, false
+}
+
+// StateNames 所有状态名称，按定义顺序
+func (w *Workflow) StateNames() []string {
+	names := make([]string, len(w.States))
+	for i, state := range w.States {
+		names[i] = state.Name
+	}
+	return names
+}
+
+// DoneState 第一个完成状态，用于 'todo complete'
+func (w *Workflow) DoneState() string {
+	for _, state := range w.States {
+		if state.Done {
+			return state.Name
+		}
+	}
+	return ""
+}
+
+// StateOf 返回任务当前所处的状态。
+// State 为空或不在工作流中时，按 Status 映射到初始状态或完成状态。
+func (w *Workflow) StateOf(task *Task) string {
+	return w.Resolve(task.State, task.Status)
+}
+
+// Resolve 将存储的状态名和完成状态映射为工作流中的状态
+func (w *Workflow) Resolve(state string, status TaskStatus) string {
+	if s, ok := w.State(state); ok && s.Done == (status == StatusCompleted) {
+		return state
+	}
+	if status == StatusCompleted {
+		return w.DoneState()
+	}
+	return w.Initial
+}
+
+// Allowed 从 from 状态允许转换到的状态
+func (w *Workflow) Allowed(from string) []string {
+	return w.Transitions[from]
+}
+
+// CanTransition 是否允许从 from 转换到 to
+func (w *Workflow) CanTransition(from, to string) bool {
+	for _, target := range w.Transitions[from] {
+		if target == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Transition 将任务移动到 to 状态，并同步完成状态
+func (w *Workflow) Transition(task *Task, to string) error {
+	target, ok := w.State(to)
+	if !ok {
+		return fmt.Errorf("%w: %q (available: %s)", ErrUnknownState, to, strings.Join(w.StateNames(), ", "))
+	}
+
+	from := w.StateOf(task)
+	if from == to {
+		return nil
+	}
+	if !w.CanTransition(from, to) {
+		allowed := w.Allowed(from)
+		if len(allowed) == 0 {
+			return fmt.Errorf("%w: %s is a final state", ErrTransitionNotAllowed, from)
+		}
+		return fmt.Errorf("%w: %s -> %s (allowed: %s)", ErrTransitionNotAllowed, from, to, strings.Join(allowed, ", "))
+	}
+
+	switch {
+	case target.Done && task.Status != StatusCompleted:
+		task.MarkCompleted()
+	case !target.Done && task.Status == StatusCompleted:
+		task.MarkPending()
+	default:
+		task.UpdatedAt = time.Now()
+	}
+	// 初始状态和默认完成状态可以由 Status 推导，不单独保存
+	task.State = to
+	if to == w.Initial || to == w.DoneState() {
+		task.State = ""
+	}
+	return nil
+}
+
+func (w *Workflow) isDone(name string) bool {
+	state, ok := w.State(name)
+	return ok && state.Done
+}
@@ -0,0 +1,236 @@
+// Package quickadd 解析快速添加语法，从一行文本中提取任务的标题、优先级、分类、标签和时间。
+//
+// 语法：
+//
+//	!3 或 !!!        优先级（1-4，或感叹号个数）
+//	#work            分类（work/study/life/other，也可以写 工作/学习/生活/其他），其他 # 开头的词保留在标题中
+//	+release         标签，可以有多个，标签中不能有逗号
+//	@launch          所属项目名称，含空格时用引号：@"Q4 launch"
+//	due:fri          截止时间，多个词用引号或下划线：due:"next friday"、due:明天下午三点
+//	snooze:3d        延后时间（也可以写 defer:）
+//	\#1              反斜杠转义，按原样保留在标题中
+//
+// 其余文字按顺序组成任务标题，例如 "Prepare demo !3 #work +release due:fri"
+// 解析为标题 "Prepare demo"、优先级 3、分类 work、标签 release、截止本周五。
+package quickadd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/dateparse"
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+// 只写日期时使用的默认时刻
+const (
+	dueDefaultClock   = 23*time.Hour + 59*time.Minute
+	deferDefaultClock = 0
+)
+
+// Result 解析结果，未出现的字段保持零值
+type Result struct {
+	Title      string              `json:"title"`
+	Category   models.TaskCategory `json:"category,omitempty"`
+	Priority   models.Priority     `json:"priority,omitempty"`
+	Tags       []string            `json:"tags,omitempty"`
+	Project    string              `json:"project,omitempty"`
+	DueAt      *time.Time          `json:"due_at,omitempty"`
+	DeferUntil *time.Time          `json:"defer_until,omitempty"`
+}
+
+var categoryAliases = map[string]models.TaskCategory{
+	"work": models.CategoryWork, "工作": models.CategoryWork,
+	"study": models.CategoryStudy, "学习": models.CategoryStudy,
+	"life": models.CategoryLife, "生活": models.CategoryLife,
+	"other": models.CategoryOther, "其他": models.CategoryOther,
+}
+
+var (
+	priorityDigit = regexp.MustCompile(`^!([1-4])$`)
+	priorityBangs = regexp.MustCompile(`^!{1,4}$`)
+)
+
+// Parse 解析快速添加文本，相对时间以 now 为基准
+func Parse(input string, now time.Time) (*Result, error) {
+	result := &Result{}
+	var title []string
+
+	for _, token := range tokenize(input) {
+		if token.quoted {
+			title = append(title, token.text)
+			continue
+		}
+		text := token.text
+
+		switch {
+		case strings.HasPrefix(text, `\`) && len(text) > 1:
+			title = append(title, text[1:])
+
+		case priorityDigit.MatchString(text):
+			result.Priority = models.Priority(text[1] - '0')
+
+		case priorityBangs.MatchString(text) && len(text) > 1:
+			result.Priority = models.Priority(len(text))
+
+		case strings.HasPrefix(text, "#") && len(text) > 1:
+			// 只有已知的分类名是分类语法，"Fix bug #123" 中的 #123 保留在标题中
+			category, ok := categoryAliases[strings.ToLower(text[1:])]
+			if !ok {
+				title = append(title, text)
+				continue
+			}
+			result.Category = category
+
+		case strings.HasPrefix(text, "+") && len(text) > 1:
+			tag := strings.Trim(text[1:], ",")
+			if tag == "" {
+				title = append(title, text)
+				continue
+			}
+			// 标签以逗号分隔保存，标签本身不能包含逗号
+			if strings.Contains(tag, ",") {
+				return nil, fmt.Errorf("tag %q must not contain commas, use separate +tags", tag)
+			}
+			if !contains(result.Tags, tag) {
+				result.Tags = append(result.Tags, tag)
+			}
+
+		case strings.HasPrefix(text, "@") && len(text) > 1:
+			result.Project = text[1:]
+
+		default:
+			key, value, ok := cutField(text)
+			if !ok {
+				title = append(title, text)
+				continue
+			}
+
+			switch key {
+			case "due":
+				due, err := parseTime(value, now, dueDefaultClock)
+				if err != nil {
+					return nil, fmt.Errorf("invalid due date: %w", err)
+				}
+				result.DueAt = &due
+			case "snooze", "defer":
+				until, err := parseTime(value, now, deferDefaultClock)
+				if err != nil {
+					return nil, fmt.Errorf("invalid snooze date: %w", err)
+				}
+				result.DeferUntil = &until
+			}
+		}
+	}
+
+	result.Title = strings.Join(title, " ")
+	if result.Title == "" {
+		return nil, fmt.Errorf("task title is empty")
+	}
+	return result, nil
+}
+
+// Apply 将解析到的字段写入任务，未解析到的字段保持原值。
+// 项目只解析出名称，需要调用方转换为 ProjectID。
+func (r *Result) Apply(task *models.Task) {
+	task.Title = r.Title
+	if r.Category != "" {
+		task.Category = r.Category
+	}
+	if r.Priority != 0 {
+		task.Priority = r.Priority
+	}
+	for _, tag := range r.Tags {
+		task.AddTag(tag)
+	}
+	if r.DueAt != nil {
+		task.DueAt = r.DueAt
+	}
+	if r.DeferUntil != nil {
+		task.DeferUntil = r.DeferUntil
+	}
+}
+
+// Task 按解析结果创建任务，未指定的分类和优先级使用默认值
+func (r *Result) Task(defaultCategory models.TaskCategory, defaultPriority models.Priority) *models.Task {
+	task := models.NewTask(r.Title, "", defaultCategory, defaultPriority)
+	r.Apply(task)
+	return task
+}
+
+type token struct {
+	text   string
+	quoted bool // 整个词都在引号中，不作为语法解析
+}
+
+// tokenize 按空白切分，双引号内的空白不切分
+func tokenize(input string) []token {
+	var tokens []token
+	var current strings.Builder
+	inQuote, wholeQuoted, started := false, false, false
+
+	flush := func() {
+		if started {
+			tokens = append(tokens, token{text: current.String(), quoted: wholeQuoted})
+		}
+		current.Reset()
+		inQuote, wholeQuoted, started = false, false, false
+	}
+
+	for _, r := range input {
+		switch {
+		case r == '"':
+			if !started {
+				wholeQuoted = true
+			}
+			started = true
+			inQuote = !inQuote
+		case !inQuote && (r == ' ' || r == '\t' || r == '\n' || r == '　'):
+			flush()
+		default:
+			if !inQuote && wholeQuoted {
+				wholeQuoted = false
+			}
+			started = true
+			current.WriteRune(r)
+		}
+	}
+	flush()
+	return tokens
+}
+
+// cutField 拆分 key:value 形式的字段，只识别已知的字段名
+func cutField(text string) (string, string, bool) {
+	key, value, ok := strings.Cut(strings.Replace(text, "：", ":", 1), ":")
+	if !ok {
+		return "", "", false
+	}
+	key = strings.ToLower(key)
+	switch key {
+	case "due", "snooze", "defer":
+	default:
+		return "", "", false
+	}
+	if value == "" {
+		return "", "", false
+	}
+	return key, strings.ReplaceAll(value, "_", " "), true
+}
+
+func parseTime(value string, now time.Time, defaultClock time.Duration) (time.Time, error) {
+	if d, err := dateparse.ParseDuration(value); err == nil {
+		return now.Add(d), nil
+	}
+	return dateparse.ParseWithOptions(value, now, dateparse.Options{DefaultClock: defaultClock})
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package reminder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+// Notification 一次提醒通知
+type Notification struct {
+	Title     string       `json:"title"`
+	Message   string       `json:"message"`
+	Task      *models.Task `json:"task"`
+	TriggerAt time.Time    `json:"trigger_at"`
+}
+
+// Notifier 通知渠道
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, n Notification) error
+}
+
+// StdoutNotifier 将提醒打印到输出流
+type StdoutNotifier struct {
+	Out io.Writer
+}
+
+func (n *StdoutNotifier) Name() string { return "stdout" }
+
+func (n *StdoutNotifier) Notify(ctx context.Context, notification Notification) error {
+	_, err := fmt.Fprintf(n.Out, "[%s] ⏰ %s: %s\n",
+		time.Now().Format("2006-01-02 15:04:05"), notification.Title, notification.Message)
+	return err
+}
+
+// BellNotifier 发出终端响铃
+type BellNotifier struct {
+	Out io.Writer
+}
+
+func (n *BellNotifier) Name() string { return "bell" }
+
+func (n *BellNotifier) Notify(ctx context.Context, notification Notification) error {
+	_, err := io.WriteString(n.Out, "\a")
+	return err
+}
+
+// DesktopNotifier 发送桌面通知（Linux 使用 notify-send，macOS 使用 osascript）
+type DesktopNotifier struct{}
+
+func (n *DesktopNotifier) Name() string { return "desktop" }
+
+func (n *DesktopNotifier) Notify(ctx context.Context, notification Notification) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q",
+			notification.Message, notification.Title)
+		cmd = exec.CommandContext(ctx, "osascript", "-e", script)
+	default:
+		cmd = exec.CommandContext(ctx, "notify-send", "--app-name=todo",
+			notification.Title, notification.Message)
+	}
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %w %s", cmd.Path, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// WebhookNotifier 将提醒以 JSON POST 到指定 URL
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+func (n *WebhookNotifier) Name() string { return "webhook" }
+
+func (n *WebhookNotifier) Notify(ctx context.Context, notification Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := n.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// NewNotifier 根据名称创建通知渠道，webhook 渠道需要 webhookURL
+func NewNotifier(name string, out io.Writer, webhookURL string) (Notifier, error) {
+	switch name {
+	case "stdout":
+		return &StdoutNotifier{Out: out}, nil
+	case "bell":
+		return &BellNotifier{Out: out}, nil
+	case "desktop", "notify-send":
+		return &DesktopNotifier{}, nil
+	case "webhook":
+		if webhookURL == "" {
+			return nil, fmt.Errorf("webhook notifier requires a URL")
+		}
+		return &WebhookNotifier{URL: webhookURL}, nil
+	default:
+		return nil, fmt.Errorf("unknown notifier %q", name)
+	}
+}
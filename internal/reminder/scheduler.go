@@ -0,0 +1,138 @@
+package reminder
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/WHITE13452/toDoList/internal/storage"
+)
+
+// Scheduler 提醒调度器：睡眠到下一个提醒的触发时间（最长 PollInterval），
+// 醒来后触发所有到期的提醒。
+type Scheduler struct {
+	storage      *storage.Storage
+	notifiers    []Notifier
+	PollInterval time.Duration
+	// OnTick 每轮调度后执行，例如投递 Webhook 发件箱
+	OnTick func(ctx context.Context)
+	Log    io.Writer
+}
+
+// NewScheduler 创建提醒调度器
+func NewScheduler(storage *storage.Storage, notifiers []Notifier) *Scheduler {
+	return &Scheduler{
+		storage:      storage,
+		notifiers:    notifiers,
+		PollInterval: time.Minute,
+		Log:          os.Stderr,
+	}
+}
+
+// Run 持续运行调度器，直到 ctx 被取消
+func (s *Scheduler) Run(ctx context.Context) error {
+	for {
+		if _, err := s.FireDue(ctx); err != nil {
+			fmt.Fprintf(s.Log, "⚠ reminder: %v\n", err)
+		}
+		if s.OnTick != nil {
+			s.OnTick(ctx)
+		}
+
+		wait, err := s.nextWait(time.Now())
+		if err != nil {
+			fmt.Fprintf(s.Log, "⚠ reminder: %v\n", err)
+			wait = s.PollInterval
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// nextWait 计算距离下一个提醒的等待时间，不超过 PollInterval，
+// 以便及时发现新增或修改的提醒
+func (s *Scheduler) nextWait(now time.Time) (time.Duration, error) {
+	upcoming, err := s.storage.GetUpcomingReminders()
+	if err != nil {
+		return 0, err
+	}
+
+	wait := s.PollInterval
+	if len(upcoming) > 0 {
+		if until := upcoming[0].TriggerAt.Sub(now); until < wait {
+			wait = until
+		}
+	}
+	if wait < time.Second {
+		wait = time.Second
+	}
+	return wait, nil
+}
+
+// FireDue 触发所有到期的提醒，返回触发数量。
+// 提醒在通知前先标记为已触发，因此进程重启或多个守护进程同时运行都不会重复通知。
+func (s *Scheduler) FireDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	upcoming, err := s.storage.GetUpcomingReminders()
+	if err != nil {
+		return 0, err
+	}
+
+	fired := 0
+	for _, due := range upcoming {
+		if due.TriggerAt.After(now) {
+			break
+		}
+
+		claimed, err := s.storage.MarkReminderFired(due.Reminder.ID, now)
+		if err != nil {
+			return fired, err
+		}
+		if !claimed {
+			continue
+		}
+
+		s.notify(ctx, buildNotification(due, now))
+		fired++
+	}
+
+	return fired, nil
+}
+
+func (s *Scheduler) notify(ctx context.Context, notification Notification) {
+	for _, notifier := range s.notifiers {
+		notifyCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		if err := notifier.Notify(notifyCtx, notification); err != nil {
+			fmt.Fprintf(s.Log, "⚠ notifier %s failed: %v\n", notifier.Name(), err)
+		}
+		cancel()
+	}
+}
+
+func buildNotification(due *models.DueReminder, now time.Time) Notification {
+	task := due.Task
+	message := fmt.Sprintf("[%d] %s", task.ID, task.Title)
+	if task.DueAt != nil {
+		message += i18n.T("daemon.notify_due", task.DueAt.Local().Format("01-02 15:04"))
+	}
+	if now.Sub(due.TriggerAt) > time.Minute {
+		message += i18n.T("daemon.notify_late", now.Sub(due.TriggerAt).Round(time.Minute))
+	}
+
+	return Notification{
+		Title:     i18n.T("daemon.notify_title"),
+		Message:   message,
+		Task:      task,
+		TriggerAt: due.TriggerAt,
+	}
+}
@@ -0,0 +1,253 @@
+// Package report 根据任务列表生成日报、周报，使用 text/template 渲染为 Markdown 或 HTML。
+package report
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+//go:embed templates/*.tmpl
+var builtinTemplates embed.FS
+
+// Period 报告周期
+type Period string
+
+const (
+	PeriodDay  Period = "day"
+	PeriodWeek Period = "week"
+)
+
+// Format 报告格式
+type Format string
+
+const (
+	FormatMarkdown Format = "md"
+	FormatHTML     Format = "html"
+)
+
+// IsValid 是否为支持的格式
+func (f Format) IsValid() bool {
+	return f == FormatMarkdown || f == FormatHTML
+}
+
+// Range 返回 at 所在周期的开始和结束时间（结束时间不包含）
+func (p Period) Range(at time.Time) (time.Time, time.Time, error) {
+	switch p {
+	case PeriodDay:
+		start := models.GranularityDay.PeriodStart(at)
+		return start, start.AddDate(0, 0, 1), nil
+	case PeriodWeek:
+		start := models.GranularityWeek.PeriodStart(at)
+		return start, start.AddDate(0, 0, 7), nil
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid period %q (available: day, week)", p)
+	}
+}
+
+// Group 同一分类下的任务
+type Group struct {
+	Category models.TaskCategory
+	Tasks    []*models.Task
+}
+
+// Section 报告中的一节，例如已完成、进行中
+type Section struct {
+	Key    string
+	Title  string
+	Count  int
+	Groups []Group
+}
+
+// Data 传给模板的数据
+type Data struct {
+	Title  string
+	Period Period
+	From   time.Time
+	// To 周期结束时间（不包含）
+	To          time.Time
+	GeneratedAt time.Time
+	Sections    []Section
+	// Summary AI 生成的总结，为空时模板中不显示
+	Summary string
+}
+
+// Section 按 key 查找一节，供模板使用，例如 {{with .Section "completed"}}
+func (d *Data) Section(key string) *Section {
+	for i := range d.Sections {
+		if d.Sections[i].Key == key {
+			return &d.Sections[i]
+		}
+	}
+	return nil
+}
+
+// Build 从任务列表中整理出报告数据：
+// 周期内完成的任务、处于中间工作流状态的任务、已逾期未完成的任务和周期内新建的任务。
+func Build(tasks []*models.Task, workflow *models.Workflow, period Period, at, now time.Time) (*Data, error) {
+	from, to, err := period.Range(at)
+	if err != nil {
+		return nil, err
+	}
+
+	title := i18n.T("report.title_day")
+	if period == PeriodWeek {
+		title = i18n.T("report.title_week")
+	}
+
+	inRange := func(t time.Time) bool {
+		return !t.Before(from) && t.Before(to)
+	}
+	// 查看过去的周期时，逾期按周期结束时计算
+	cutoff := now
+	if to.Before(now) {
+		cutoff = to
+	}
+
+	var completed, inProgress, overdue, added []*models.Task
+	for _, task := range tasks {
+		if task.Status == models.StatusCompleted {
+			if task.CompletedAt != nil && inRange(*task.CompletedAt) {
+				completed = append(completed, task)
+			}
+		} else {
+			if workflow.StateOf(task) != workflow.Initial {
+				inProgress = append(inProgress, task)
+			}
+			if task.IsOverdue(cutoff) {
+				overdue = append(overdue, task)
+			}
+		}
+		if inRange(task.CreatedAt) {
+			added = append(added, task)
+		}
+	}
+
+	return &Data{
+		Title:       title,
+		Period:      period,
+		From:        from,
+		To:          to,
+		GeneratedAt: now,
+		Sections: []Section{
+			newSection("completed", completed),
+			newSection("in_progress", inProgress),
+			newSection("overdue", overdue),
+			newSection("added", added),
+		},
+	}, nil
+}
+
+// newSection 按分类分组，组内按优先级从高到低排列
+func newSection(key string, tasks []*models.Task) Section {
+	byCategory := make(map[models.TaskCategory][]*models.Task)
+	for _, task := range tasks {
+		byCategory[task.Category] = append(byCategory[task.Category], task)
+	}
+
+	section := Section{Key: key, Title: i18n.T("report.section_" + key), Count: len(tasks)}
+	for _, category := range []models.TaskCategory{models.CategoryWork, models.CategoryStudy, models.CategoryLife, models.CategoryOther} {
+		group := byCategory[category]
+		if len(group) == 0 {
+			continue
+		}
+		sort.SliceStable(group, func(i, j int) bool {
+			if group[i].Priority != group[j].Priority {
+				return group[i].Priority > group[j].Priority
+			}
+			return group[i].ID < group[j].ID
+		})
+		section.Groups = append(section.Groups, Group{Category: category, Tasks: group})
+	}
+	return section
+}
+
+// LoadTemplate 加载模板。path 不为空时使用该文件；否则依次查找
+// 配置目录下的 report.<format>.tmpl（例如 ~/.config/todo/report.md.tmpl）和内置模板。
+func LoadTemplate(format Format, path string) (*template.Template, error) {
+	if !format.IsValid() {
+		return nil, fmt.Errorf("invalid format %q (available: md, html)", format)
+	}
+
+	name := fmt.Sprintf("report.%s.tmpl", format)
+	if path == "" {
+		if dir, err := os.UserConfigDir(); err == nil {
+			candidate := filepath.Join(dir, "todo", name)
+			if _, err := os.Stat(candidate); err == nil {
+				path = candidate
+			}
+		}
+	}
+
+	var text []byte
+	var err error
+	if path != "" {
+		text, err = os.ReadFile(path)
+	} else {
+		text, err = builtinTemplates.ReadFile("templates/" + name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+
+	tmpl, err := template.New(name).Funcs(funcs).Parse(string(text))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// Render 使用模板渲染报告
+func Render(w io.Writer, tmpl *template.Template, data *Data) error {
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to render report: %w", err)
+	}
+	return nil
+}
+
+// funcs 模板中可用的函数
+var funcs = template.FuncMap{
+	// t 当前语言的消息，例如 {{t "report.generated" (date .GeneratedAt "2006-01-02")}}
+	"t": i18n.T,
+	// lang 当前语言，例如 "zh-CN"
+	"lang": func() string {
+		return string(i18n.Current())
+	},
+	"category": func(category models.TaskCategory) string {
+		if message, ok := i18n.Lookup("category." + string(category)); ok {
+			return message
+		}
+		return string(category)
+	},
+	"date": func(t time.Time, layout string) string {
+		return t.Format(layout)
+	},
+	// lastDay 周期的最后一天，To 本身不包含在周期内
+	"lastDay": func(t time.Time) time.Time {
+		return t.AddDate(0, 0, -1)
+	},
+	"priority": func(p models.Priority) string {
+		return strings.Repeat("!", int(p))
+	},
+	"tags": func(tags []string) string {
+		if len(tags) == 0 {
+			return ""
+		}
+		return "+" + strings.Join(tags, " +")
+	},
+	"due": func(task *models.Task) string {
+		if task.DueAt == nil {
+			return ""
+		}
+		return task.DueAt.Format("01-02 15:04")
+	},
+}
@@ -0,0 +1,462 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/encryption"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/WHITE13452/toDoList/internal/workspace"
+	"github.com/mattn/go-sqlite3"
+)
+
+// backupTimeLayout 备份文件名中的时间格式
+const backupTimeLayout = "20060102-150405"
+
+// backupNamePattern 备份文件名：<前缀>-<时间>[-<原因>].db，例如 todolist-20261018-093000-pre-v7.db
+var backupNamePattern = regexp.MustCompile(`^(.+)-(\d{8}-\d{6})(?:-([a-z0-9-]+))?\.db$`)
+
+// BackupInfo 备份文件的内容摘要
+type BackupInfo struct {
+	Path          string
+	SchemaVersion int
+	Tasks         int
+}
+
+// BackupDir 数据库的默认备份目录：数据库所在目录下的 backups
+func BackupDir(dbPath string) string {
+	return filepath.Join(filepath.Dir(dbPath), "backups")
+}
+
+// BackupPath 在 dir 中为数据库生成带时间戳的备份文件名，reason 为空表示手动备份
+func BackupPath(dir, dbPath string, at time.Time, reason string) string {
+	name := backupPrefix(dbPath) + "-" + at.Format(backupTimeLayout)
+	if reason != "" {
+		name += "-" + reason
+	}
+	return filepath.Join(dir, name+".db")
+}
+
+// backupPrefix 备份文件名的前缀，例如 .todolist.db 和 todolist.db 都是 "todolist"
+func backupPrefix(dbPath string) string {
+	base := filepath.Base(dbPath)
+	prefix := strings.TrimPrefix(strings.TrimSuffix(base, filepath.Ext(base)), ".")
+	if prefix == "" {
+		return "todolist"
+	}
+	return prefix
+}
+
+// ListBackups 列出 dir 中属于该数据库的备份，按时间从新到旧排序
+func ListBackups(dir, dbPath string) ([]models.BackupFile, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	prefix := backupPrefix(dbPath)
+	var backups []models.BackupFile
+	for _, entry := range entries {
+		match := backupNamePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil || match[1] != prefix {
+			continue
+		}
+		createdAt, err := time.ParseInLocation(backupTimeLayout, match[2], time.Local)
+		if err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		backups = append(backups, models.BackupFile{
+			Path:      filepath.Join(dir, entry.Name()),
+			CreatedAt: createdAt,
+			Size:      info.Size(),
+			Reason:    match[3],
+		})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.After(backups[j].CreatedAt)
+	})
+	return backups, nil
+}
+
+// RotateBackups 只保留最新的 keep 个手动备份，返回删除的文件。自动备份不参与轮换
+func RotateBackups(dir, dbPath string, keep int) ([]string, error) {
+	backups, err := ListBackups(dir, dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	kept := 0
+	for _, backup := range backups {
+		if backup.Reason != "" {
+			continue
+		}
+		if kept < keep {
+			kept++
+			continue
+		}
+		if err := os.Remove(backup.Path); err != nil {
+			return removed, fmt.Errorf("failed to remove old backup: %w", err)
+		}
+		removed = append(removed, backup.Path)
+	}
+	return removed, nil
+}
+
+// Backup 使用 SQLite 在线备份 API 将数据库复制到 dest，备份期间仍然可以读写。
+// dest 已存在时返回错误；先写入临时文件，完成后再重命名，不会留下不完整的备份。
+// 整库加密时备份文件使用相同的密钥加密
+func (s *Storage) Backup(dest string) error {
+	if _, err := os.Stat(dest); err == nil {
+		return fmt.Errorf("backup file already exists: %s", dest)
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	var tmp string
+	if s.sealed != nil {
+		// 明文只写入运行时目录
+		tmp = s.sealed.workPath + ".backup"
+	} else {
+		var err error
+		if tmp, err = tempPath(dest); err != nil {
+			return fmt.Errorf("failed to create backup: %w", err)
+		}
+	}
+	if err := writeBackup(s.db, tmp); err != nil {
+		return fmt.Errorf("failed to create backup: %w", err)
+	}
+
+	if s.sealed != nil {
+		defer os.Remove(tmp)
+		plain, err := os.ReadFile(tmp)
+		if err == nil {
+			err = encryption.SealFile(dest, s.sealed.header, s.sealed.key, plain)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create backup: %w", err)
+		}
+		return nil
+	}
+
+	return renameBackup(tmp, dest)
+}
+
+// tempPath 在 path 所在目录中生成唯一的临时文件名，同时创建同一个备份的多个进程不会写入同一个临时文件
+func tempPath(path string) (string, error) {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return "", err
+	}
+	f.Close()
+	return f.Name(), nil
+}
+
+// writeBackup 使用在线备份 API 将 src 复制到新文件 tmp，失败时删除 tmp
+func writeBackup(src *sql.DB, tmp string) error {
+	os.Remove(tmp)
+	target, err := sql.Open("sqlite3", tmp)
+	if err != nil {
+		return err
+	}
+	err = copyDatabase(target, src)
+	if err == nil {
+		// 备份复制了 WAL 模式的标记，改回普通日志模式，备份始终是单个文件，只读打开时也不会留下 -wal/-shm
+		_, err = target.Exec("PRAGMA journal_mode = DELETE")
+	}
+	if closeErr := target.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+	}
+	return err
+}
+
+// BackupFile 不打开存储，将数据库文件 dbPath 备份到 dest，用于数据库可能已经损坏的场合。
+// 普通数据库以只读方式打开并使用在线备份 API，包含 WAL 中尚未写回的修改，无法读取时按原样复制文件；
+// 整库加密的数据库按原样复制，备份仍然是加密的
+func BackupFile(dbPath, dest string) error {
+	if _, err := os.Stat(dest); err == nil {
+		return fmt.Errorf("backup file already exists: %s", dest)
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	sealed, err := encryption.IsSealedFile(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to create backup: %w", err)
+	}
+	if !sealed {
+		if src, err := openReadOnly(dbPath); err == nil {
+			tmp, err := tempPath(dest)
+			if err == nil {
+				err = writeBackup(src, tmp)
+			}
+			src.Close()
+			if err == nil {
+				return renameBackup(tmp, dest)
+			}
+		}
+	}
+
+	data, err := os.ReadFile(dbPath)
+	if err == nil {
+		err = writeFileAtomic(dest, data)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create backup: %w", err)
+	}
+	return nil
+}
+
+// renameBackup 将写好的临时文件重命名为备份文件
+func renameBackup(tmp, dest string) error {
+	if err := os.Rename(tmp, dest); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to create backup: %w", err)
+	}
+	return nil
+}
+
+// InspectBackup 检查备份文件能否用于恢复：必须是完整的任务数据库，
+// schema 版本不高于当前程序支持的版本。整库加密的备份通过 opts.KeyProvider 获取密钥
+func InspectBackup(path string, opts Options) (*BackupInfo, error) {
+	db, cleanup, err := openBackup(path, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+	defer db.Close()
+
+	info := &BackupInfo{Path: path}
+	var tables int
+	err = db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'tasks'`).Scan(&tables)
+	if err != nil {
+		return nil, fmt.Errorf("not a valid database: %w", err)
+	}
+	if tables == 0 {
+		return nil, fmt.Errorf("not a todo database: tasks table is missing")
+	}
+
+	if err := db.QueryRow("PRAGMA user_version").Scan(&info.SchemaVersion); err != nil {
+		return nil, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if info.SchemaVersion > SchemaVersion() {
+		return nil, fmt.Errorf("backup schema version %d is newer than supported version %d, please upgrade todo first",
+			info.SchemaVersion, SchemaVersion())
+	}
+
+	problems, err := integrityCheck(db)
+	if err != nil {
+		return nil, err
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("backup failed integrity check: %s", problems[0])
+	}
+
+	if err := db.QueryRow("SELECT COUNT(*) FROM tasks").Scan(&info.Tasks); err != nil {
+		return nil, fmt.Errorf("failed to count tasks: %w", err)
+	}
+	return info, nil
+}
+
+// RestoreFile 不打开当前数据库，直接用备份文件替换数据库文件 dbPath，并删除旧的 -wal/-shm 文件，
+// 用于数据库已经损坏、无法打开的场合。恢复后数据库的加密方式与备份相同，旧版本的备份在下次打开时自动升级。
+// 替换文件时其他进程不能正在使用该数据库。调用方应该先用 InspectBackup 检查备份，并用 BackupFile 备份当前数据库
+func RestoreFile(dbPath, backupPath string, opts Options) error {
+	if _, err := InspectBackup(backupPath, opts); err != nil {
+		return err
+	}
+	data, err := os.ReadFile(backupPath)
+	if err != nil {
+		return fmt.Errorf("failed to read backup: %w", err)
+	}
+
+	// 整库加密的数据库残留的工作副本比恢复的内容旧，不能在下次打开时使用
+	release, err := lockWorkingCopy(dbPath)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create database directory: %w", err)
+	}
+	if err := writeFileAtomic(dbPath, data); err != nil {
+		return fmt.Errorf("failed to restore backup: %w", err)
+	}
+	for _, suffix := range []string{"-wal", "-shm", "-journal"} {
+		os.Remove(dbPath + suffix)
+	}
+	return nil
+}
+
+// openBackup 以只读方式打开数据库或备份文件。整库加密的备份先解密到运行时目录中的临时文件，
+// 使用完后调用 cleanup 删除
+func openBackup(path string, opts Options) (*sql.DB, func(), error) {
+	sealed, err := encryption.IsSealedFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	if !sealed {
+		db, err := openReadOnly(path)
+		return db, func() {}, err
+	}
+
+	h, err := encryption.ReadFileHeader(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	key, err := opts.key(h)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, plain, err := encryption.OpenFile(path, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decrypt %s: %w", path, err)
+	}
+
+	dir, err := workspace.PlaintextDir()
+	if err != nil {
+		return nil, nil, err
+	}
+	tmp, err := os.CreateTemp(dir, "backup-*.db")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decrypt %s: %w", path, err)
+	}
+	tmpPath := tmp.Name()
+	cleanup := func() {
+		// 解密后的数据库文件可能是 WAL 模式，只读打开时也会创建 -wal/-shm
+		for _, suffix := range []string{"", "-wal", "-shm"} {
+			os.Remove(tmpPath + suffix)
+		}
+	}
+	_, err = tmp.Write(plain)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("failed to decrypt %s: %w", path, err)
+	}
+
+	db, err := openReadOnly(tmpPath)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return db, cleanup, nil
+}
+
+// backupBeforeMigration 已有数据的数据库需要升级 schema 时，先在备份目录中创建自动备份
+func (s *Storage) backupBeforeMigration() error {
+	if s.path == ":memory:" || strings.HasPrefix(s.path, "file:") {
+		return nil
+	}
+
+	version, err := s.schemaVersion()
+	if err != nil || version >= SchemaVersion() {
+		return err
+	}
+
+	var tables int
+	err = s.db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'tasks'`).Scan(&tables)
+	if err != nil {
+		return fmt.Errorf("failed to inspect database: %w", err)
+	}
+	if tables == 0 {
+		// 新建的数据库
+		return nil
+	}
+
+	dest := BackupPath(BackupDir(s.path), s.path, time.Now(), models.BackupReasonPreMigration(SchemaVersion()))
+	if err := s.Backup(dest); err != nil {
+		// 同时打开数据库的其他进程已经创建了同名的备份
+		if _, statErr := os.Stat(dest); statErr == nil {
+			return nil
+		}
+		return fmt.Errorf("failed to back up database before migration: %w", err)
+	}
+	return nil
+}
+
+// openReadOnly 以只读方式打开数据库文件，文件不存在时返回错误而不是创建新数据库
+func openReadOnly(path string) (*sql.DB, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	if _, err := os.Stat(abs); err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	uri := url.URL{Scheme: "file", Path: abs, RawQuery: "mode=ro"}
+	db, err := sql.Open("sqlite3", uri.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	return db, nil
+}
+
+// copyDatabase 使用 SQLite 在线备份 API 将 src 的主数据库完整复制到 dst
+func copyDatabase(dst, src *sql.DB) error {
+	ctx := context.Background()
+	dstConn, err := dst.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer dstConn.Close()
+	srcConn, err := src.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer srcConn.Close()
+
+	return dstConn.Raw(func(dstDriver interface{}) error {
+		return srcConn.Raw(func(srcDriver interface{}) error {
+			dstSQLite, ok := dstDriver.(*sqlite3.SQLiteConn)
+			if !ok {
+				return fmt.Errorf("unexpected driver connection %T", dstDriver)
+			}
+			srcSQLite, ok := srcDriver.(*sqlite3.SQLiteConn)
+			if !ok {
+				return fmt.Errorf("unexpected driver connection %T", srcDriver)
+			}
+
+			backup, err := dstSQLite.Backup("main", srcSQLite, "main")
+			if err != nil {
+				return err
+			}
+			// -1 表示一次复制所有页
+			done, err := backup.Step(-1)
+			if err != nil {
+				backup.Finish()
+				return err
+			}
+			if !done {
+				backup.Finish()
+				return fmt.Errorf("backup did not complete")
+			}
+			return backup.Finish()
+		})
+	})
+}
@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// 并发访问相关的设置。CLI、提醒后台进程和 TUI 可能同时使用同一个数据库文件：
+// WAL 模式下读写互不阻塞，写入之间由 busy_timeout 排队，仍然遇到 SQLITE_BUSY 时按退避策略重试
+const (
+	// busyTimeout SQLite 等待其他连接释放锁的时间
+	busyTimeout = 5 * time.Second
+	// maxOpenConns 连接池大小。WAL 模式下多个读连接可以并发，写入仍然串行
+	maxOpenConns = 4
+	// busyRetries 遇到 SQLITE_BUSY 后的最大重试次数
+	busyRetries = 5
+	// retryBaseDelay/retryMaxDelay 重试的退避时间，每次翻倍并加入随机抖动
+	retryBaseDelay = 20 * time.Millisecond
+	retryMaxDelay  = 500 * time.Millisecond
+)
+
+// openDB 打开数据库连接池：WAL 日志、busy_timeout、写事务使用 BEGIN IMMEDIATE。
+// 内存数据库每个连接都是独立的数据库，只使用一个连接
+func openDB(path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite3", dsn(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if isMemory(path) {
+		db.SetMaxOpenConns(1)
+		return db, nil
+	}
+	db.SetMaxOpenConns(maxOpenConns)
+	db.SetMaxIdleConns(maxOpenConns)
+	return db, nil
+}
+
+// dsn 在数据库路径后追加连接参数，保留路径中已有的参数
+func dsn(path string) string {
+	params := []string{
+		fmt.Sprintf("_busy_timeout=%d", busyTimeout/time.Millisecond),
+		// 事务开始时就获取写锁，避免读事务升级为写事务时直接返回 SQLITE_BUSY
+		"_txlock=immediate",
+		// 时间统一以 UTC 写入，SQL 中按字符串比较和排序才正确；读取时转换为本地时间
+		"_loc=auto",
+	}
+	if !isMemory(path) {
+		// WAL 模式下 synchronous=NORMAL 仍然保证数据库不会损坏，只有断电时可能丢失最后的提交
+		params = append(params, "_journal_mode=WAL", "_synchronous=NORMAL")
+	}
+
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return path + separator + strings.Join(params, "&")
+}
+
+// isMemory 是否为内存数据库
+func isMemory(path string) bool {
+	return path == ":memory:" || strings.Contains(path, "mode=memory")
+}
+
+// isBusy 错误是否由其他连接持有锁引起
+func isBusy(err error) bool {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked
+	}
+	return false
+}
+
+// retryBusy 执行 fn，遇到 SQLITE_BUSY 时退避后重试
+func retryBusy(fn func() error) error {
+	delay := retryBaseDelay
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || !isBusy(err) || attempt >= busyRetries {
+			return err
+		}
+		time.Sleep(delay/2 + time.Duration(rand.Int63n(int64(delay))))
+		delay = min(delay*2, retryMaxDelay)
+	}
+}
+
+// exec 执行写入语句，遇到 SQLITE_BUSY 时重试
+func (s *Storage) exec(query string, args ...interface{}) (sql.Result, error) {
+	var result sql.Result
+	err := retryBusy(func() error {
+		var err error
+		result, err = s.db.Exec(query, args...)
+		return err
+	})
+	return result, err
+}
+
+// begin 开始写事务，遇到 SQLITE_BUSY 时重试。事务使用 BEGIN IMMEDIATE，开始后不会再因为锁失败
+func (s *Storage) begin() (*sql.Tx, error) {
+	var tx *sql.Tx
+	err := retryBusy(func() error {
+		var err error
+		tx, err = s.db.Begin()
+		return err
+	})
+	return tx, err
+}
@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+// TestConcurrentAccess 多个存储实例（模拟 CLI、daemon 和 TUI 三个进程）在多个 goroutine 中同时读写同一个数据库，
+// 不应出现 SQLITE_BUSY 或 database is locked，所有写入都应生效
+func TestConcurrentAccess(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "todo.db")
+
+	const (
+		stores     = 3
+		workers    = 8
+		iterations = 25
+	)
+
+	var opened []*Storage
+	for i := 0; i < stores; i++ {
+		s, err := New(path)
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		opened = append(opened, s)
+	}
+	t.Cleanup(func() {
+		for _, s := range opened {
+			s.Close()
+		}
+	})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, stores*workers*iterations*3)
+	for i := 0; i < stores*workers; i++ {
+		s := opened[i%stores]
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for j := 0; j < iterations; j++ {
+				task := models.NewTask(fmt.Sprintf("任务 %d-%d", worker, j), "", models.CategoryWork, models.PriorityMedium)
+				if err := s.AddTask(task); err != nil {
+					errs <- fmt.Errorf("AddTask: %w", err)
+					continue
+				}
+				task.Title += " (已更新)"
+				if err := s.UpdateTask(task); err != nil {
+					errs <- fmt.Errorf("UpdateTask: %w", err)
+				}
+				if _, err := s.GetAllTasks(TaskFilter{}); err != nil {
+					errs <- fmt.Errorf("GetAllTasks: %w", err)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if isBusy(err) || strings.Contains(err.Error(), "database is locked") {
+			t.Errorf("lock contention was not absorbed: %v", err)
+		} else {
+			t.Errorf("%v", err)
+		}
+	}
+
+	tasks, err := opened[0].GetAllTasks(TaskFilter{})
+	if err != nil {
+		t.Fatalf("GetAllTasks: %v", err)
+	}
+	if want := stores * workers * iterations; len(tasks) != want {
+		t.Errorf("got %d tasks, want %d", len(tasks), want)
+	}
+	for _, task := range tasks {
+		if !strings.HasSuffix(task.Title, " (已更新)") {
+			t.Errorf("task %d was not updated: %q", task.ID, task.Title)
+		}
+	}
+}
+
+// TestConcurrentMigration 多个进程同时打开旧版本的数据库，每个迁移只执行一次，所有进程都能正常打开
+func TestConcurrentMigration(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "todo.db")
+
+	// 只有初始建表语句、尚未执行任何迁移的数据库
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	if _, err := db.Exec("INSERT INTO tasks (title, created_at, updated_at) VALUES ('旧任务', '2026-01-02 03:04:05+08:00', '2026-01-02 03:04:05+08:00')"); err != nil {
+		t.Fatalf("insert task: %v", err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	const stores = 8
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	opened := make([]*Storage, stores)
+	errs := make([]error, stores)
+	for i := 0; i < stores; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			opened[i], errs[i] = New(path)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("store %d: New: %v", i, err)
+			continue
+		}
+		defer opened[i].Close()
+	}
+	if t.Failed() {
+		return
+	}
+
+	version, err := opened[0].schemaVersion()
+	if err != nil {
+		t.Fatalf("schemaVersion: %v", err)
+	}
+	if version != SchemaVersion() {
+		t.Errorf("schema version = %d, want %d", version, SchemaVersion())
+	}
+	tasks, err := opened[stores-1].GetAllTasks(TaskFilter{})
+	if err != nil {
+		t.Fatalf("GetAllTasks: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Title != "旧任务" {
+		t.Errorf("tasks after migration = %v, want the old task", tasks)
+	}
+	backups, err := ListBackups(BackupDir(path), path)
+	if err != nil {
+		t.Fatalf("ListBackups: %v", err)
+	}
+	if len(backups) == 0 {
+		t.Errorf("no pre-migration backup was created")
+	}
+}
@@ -0,0 +1,166 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+// openPrerequisiteCondition 任务还有未完成的前置任务（用于 tasks 表的查询条件）
+const openPrerequisiteCondition = `EXISTS (
+	SELECT 1 FROM task_dependencies d
+	JOIN tasks p ON p.id = d.depends_on_id
+	WHERE d.task_id = tasks.id AND p.status != 'completed'
+)`
+
+// AddDependency 添加依赖：taskID 需要等 dependsOnID 完成。会拒绝自依赖和形成环的依赖。
+// 检查和写入在同一个写事务中完成，并发添加依赖时也不会形成环
+func (s *Storage) AddDependency(taskID, dependsOnID int64) error {
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, id := range []int64{taskID, dependsOnID} {
+		var exists int
+		if err := tx.QueryRow("SELECT COUNT(*) FROM tasks WHERE id = ?", id).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to get task: %w", err)
+		}
+		if exists == 0 {
+			return fmt.Errorf("task %d not found", id)
+		}
+	}
+
+	graph, err := readDependencyGraph(tx)
+	if err != nil {
+		return err
+	}
+	if err := graph.CheckEdge(taskID, dependsOnID); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		"INSERT OR IGNORE INTO task_dependencies (task_id, depends_on_id, created_at) VALUES (?, ?, ?)",
+		taskID, dependsOnID, time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add dependency: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit dependency: %w", err)
+	}
+	return nil
+}
+
+// RemoveDependency 删除依赖，返回是否存在该依赖
+func (s *Storage) RemoveDependency(taskID, dependsOnID int64) (bool, error) {
+	result, err := s.exec(
+		"DELETE FROM task_dependencies WHERE task_id = ? AND depends_on_id = ?",
+		taskID, dependsOnID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove dependency: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// GetPrerequisites 获取任务依赖的前置任务
+func (s *Storage) GetPrerequisites(taskID int64) ([]*models.Task, error) {
+	query := "SELECT " + taskColumns + ` FROM tasks
+	WHERE id IN (SELECT depends_on_id FROM task_dependencies WHERE task_id = ?)
+	ORDER BY id`
+	return s.queryTasks(query, taskID)
+}
+
+// GetDependents 获取依赖该任务的后续任务
+func (s *Storage) GetDependents(taskID int64) ([]*models.Task, error) {
+	query := "SELECT " + taskColumns + ` FROM tasks
+	WHERE id IN (SELECT task_id FROM task_dependencies WHERE depends_on_id = ?)
+	ORDER BY id`
+	return s.queryTasks(query, taskID)
+}
+
+// GetDependencyGraph 读取完整的依赖图
+func (s *Storage) GetDependencyGraph() (models.DependencyGraph, error) {
+	return readDependencyGraph(s.db)
+}
+
+// queryer *sql.DB 和 *sql.Tx 共有的查询方法
+type queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// readDependencyGraph 从数据库或事务中读取依赖图
+func readDependencyGraph(q queryer) (models.DependencyGraph, error) {
+	rows, err := q.Query("SELECT task_id, depends_on_id FROM task_dependencies ORDER BY task_id, depends_on_id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dependencies: %w", err)
+	}
+	defer rows.Close()
+
+	graph := make(models.DependencyGraph)
+	for rows.Next() {
+		var taskID, dependsOnID int64
+		if err := rows.Scan(&taskID, &dependsOnID); err != nil {
+			return nil, fmt.Errorf("failed to scan dependency: %w", err)
+		}
+		graph.Add(taskID, dependsOnID)
+	}
+
+	return graph, rows.Err()
+}
+
+// fillBlockedBy 为任务填充尚未完成的前置任务 ID
+func (s *Storage) fillBlockedBy(tasks []*models.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	byID := make(map[int64]*models.Task, len(tasks))
+	placeholders := make([]string, 0, len(tasks))
+	args := make([]interface{}, 0, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+		task.BlockedBy = nil
+		placeholders = append(placeholders, "?")
+		args = append(args, task.ID)
+	}
+
+	// 任务很多时不带 IN 条件，直接读取全部未完成的依赖
+	query := `SELECT d.task_id, d.depends_on_id FROM task_dependencies d
+	JOIN tasks p ON p.id = d.depends_on_id
+	WHERE p.status != 'completed'`
+	if len(tasks) <= 500 {
+		query += " AND d.task_id IN (" + strings.Join(placeholders, ", ") + ")"
+	} else {
+		args = nil
+	}
+	query += " ORDER BY d.task_id, d.depends_on_id"
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query dependencies: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID, dependsOnID int64
+		if err := rows.Scan(&taskID, &dependsOnID); err != nil {
+			return fmt.Errorf("failed to scan dependency: %w", err)
+		}
+		if task, ok := byID[taskID]; ok {
+			task.BlockedBy = append(task.BlockedBy, dependsOnID)
+		}
+	}
+
+	return rows.Err()
+}
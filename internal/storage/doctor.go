@@ -0,0 +1,219 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+// orphanCheck 检查引用了不存在的记录的行。detail 查询返回一列描述，
+// repair 删除或修正这些行，二者使用相同的条件
+type orphanCheck struct {
+	name   string
+	detail string
+	repair string
+}
+
+var orphanChecks = []orphanCheck{
+	{
+		name: models.CheckOrphanDependencies,
+		detail: `SELECT '#' || task_id || ' → #' || depends_on_id FROM task_dependencies
+			WHERE task_id NOT IN (SELECT id FROM tasks) OR depends_on_id NOT IN (SELECT id FROM tasks)`,
+		repair: `DELETE FROM task_dependencies
+			WHERE task_id NOT IN (SELECT id FROM tasks) OR depends_on_id NOT IN (SELECT id FROM tasks)`,
+	},
+	{
+		name:   models.CheckOrphanReminders,
+		detail: `SELECT '#' || id || ' → #' || task_id FROM reminders WHERE task_id NOT IN (SELECT id FROM tasks)`,
+		repair: `DELETE FROM reminders WHERE task_id NOT IN (SELECT id FROM tasks)`,
+	},
+	{
+		name:   models.CheckOrphanTimeEntries,
+		detail: `SELECT '#' || id || ' → #' || task_id FROM time_entries WHERE task_id NOT IN (SELECT id FROM tasks)`,
+		repair: `DELETE FROM time_entries WHERE task_id NOT IN (SELECT id FROM tasks)`,
+	},
+	{
+		name:   models.CheckOrphanFocusSessions,
+		detail: `SELECT '#' || id || ' → #' || task_id FROM focus_sessions WHERE task_id NOT IN (SELECT id FROM tasks)`,
+		repair: `DELETE FROM focus_sessions WHERE task_id NOT IN (SELECT id FROM tasks)`,
+	},
+	{
+		name: models.CheckOrphanProjects,
+		detail: `SELECT '#' || id || ' → #' || project_id FROM tasks
+			WHERE project_id != 0 AND project_id NOT IN (SELECT id FROM projects)`,
+		repair: `UPDATE tasks SET project_id = 0
+			WHERE project_id != 0 AND project_id NOT IN (SELECT id FROM projects)`,
+	},
+	{
+		name:   models.CheckOrphanDeliveries,
+		detail: `SELECT '#' || id || ' → #' || webhook_id FROM webhook_outbox WHERE webhook_id NOT IN (SELECT id FROM webhooks)`,
+		repair: `DELETE FROM webhook_outbox WHERE webhook_id NOT IN (SELECT id FROM webhooks)`,
+	},
+}
+
+// indexPattern 匹配 schema 和迁移中的建索引语句
+var indexPattern = regexp.MustCompile(`CREATE (?:UNIQUE )?INDEX IF NOT EXISTS (\w+) ON [^;]+;`)
+
+// expectedIndexes schema 和迁移中定义的索引，键为索引名，值为建索引语句
+func expectedIndexes() map[string]string {
+	indexes := make(map[string]string)
+	sources := []string{schema}
+	for _, m := range migrations {
+		sources = append(sources, m.sql)
+	}
+	for _, source := range sources {
+		for _, match := range indexPattern.FindAllStringSubmatch(source, -1) {
+			indexes[match[1]] = match[0]
+		}
+	}
+	return indexes
+}
+
+// Check 检查数据库：PRAGMA integrity_check、schema 版本、索引是否完整，以及引用了不存在的任务、项目或 Webhook 的记录
+func (s *Storage) Check() (*models.HealthReport, error) {
+	return checkDatabase(s.db, s.path)
+}
+
+// CheckFile 以只读方式打开数据库文件并检查，不建表、不迁移，也不会修改文件，
+// 用于数据库可能已经损坏的场合。整库加密的数据库通过 opts.KeyProvider 获取密钥
+func CheckFile(path string, opts Options) (*models.HealthReport, error) {
+	db, cleanup, err := openBackup(path, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+	defer db.Close()
+	return checkDatabase(db, path)
+}
+
+// checkDatabase Check 和 CheckFile 的实现
+func checkDatabase(db *sql.DB, path string) (*models.HealthReport, error) {
+	var version int
+	if err := db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return nil, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	report := &models.HealthReport{Path: path, SchemaVersion: version}
+
+	problems, err := integrityCheck(db)
+	if err != nil {
+		// 损坏严重时 integrity_check 本身也会失败，其他检查无法进行，只能从备份恢复
+		report.Checks = append(report.Checks, models.HealthCheck{Name: models.CheckIntegrity, Problems: []string{err.Error()}})
+		return report, nil
+	}
+	// 索引损坏可以通过 REINDEX 修复，表数据损坏只能从备份恢复
+	fixable := true
+	for _, problem := range problems {
+		if !strings.Contains(problem, "index") {
+			fixable = false
+		}
+	}
+	report.Checks = append(report.Checks, models.HealthCheck{Name: models.CheckIntegrity, Problems: problems, Fixable: fixable && len(problems) > 0})
+
+	// 旧版本的数据库打开时会自动升级
+	schemaCheck := models.HealthCheck{Name: models.CheckSchema, Fixable: version < SchemaVersion()}
+	if version != SchemaVersion() {
+		schemaCheck.Problems = []string{fmt.Sprintf("version %d, expected %d", version, SchemaVersion())}
+	}
+	report.Checks = append(report.Checks, schemaCheck)
+
+	missing, err := missingIndexes(db)
+	if err != nil {
+		return nil, err
+	}
+	report.Checks = append(report.Checks, models.HealthCheck{Name: models.CheckIndexes, Problems: missing, Fixable: len(missing) > 0})
+
+	for _, check := range orphanChecks {
+		details, err := queryStrings(db, check.detail)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check %s: %w", check.name, err)
+		}
+		report.Checks = append(report.Checks, models.HealthCheck{Name: check.name, Problems: details, Fixable: len(details) > 0})
+	}
+
+	return report, nil
+}
+
+// Repair 修复可以自动修复的问题：重建缺失的索引、REINDEX，并清理孤立的记录。
+// 所有修改在一个事务中完成。调用方应该先备份数据库
+func (s *Storage) Repair() error {
+	tx, err := s.begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, statement := range expectedIndexes() {
+		if _, err := tx.Exec(statement); err != nil {
+			return fmt.Errorf("failed to recreate index: %w", err)
+		}
+	}
+	if _, err := tx.Exec("REINDEX"); err != nil {
+		return fmt.Errorf("failed to rebuild indexes: %w", err)
+	}
+	for _, check := range orphanChecks {
+		if _, err := tx.Exec(check.repair); err != nil {
+			return fmt.Errorf("failed to repair %s: %w", check.name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit repair: %w", err)
+	}
+	return nil
+}
+
+// missingIndexes schema 中定义但数据库中不存在的索引
+func missingIndexes(db *sql.DB) ([]string, error) {
+	existing, err := queryStrings(db, `SELECT name FROM sqlite_master WHERE type = 'index'`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list indexes: %w", err)
+	}
+	have := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		have[name] = true
+	}
+
+	var missing []string
+	for name := range expectedIndexes() {
+		if !have[name] {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
+
+// integrityCheck 执行 PRAGMA integrity_check，返回发现的问题，没有问题时返回 nil
+func integrityCheck(db *sql.DB) ([]string, error) {
+	results, err := queryStrings(db, "PRAGMA integrity_check(100)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to check integrity: %w", err)
+	}
+	if len(results) == 1 && results[0] == "ok" {
+		return nil, nil
+	}
+	return results, nil
+}
+
+// queryStrings 执行只返回一列的查询
+func queryStrings(db *sql.DB, query string) ([]string, error) {
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
@@ -0,0 +1,525 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/WHITE13452/toDoList/internal/encryption"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/WHITE13452/toDoList/internal/workspace"
+)
+
+// settingEncryption settings 表中保存 fields 模式加密参数的键
+const settingEncryption = "encryption"
+
+// sealedStore 整库加密的数据库。运行期间使用运行时目录中解密后的工作副本，关闭时重新加密写回
+type sealedStore struct {
+	header   *encryption.Header
+	key      []byte
+	workPath string
+	lockPath string
+}
+
+// key 通过 KeyProvider 获取 h 的密钥并验证，未设置 KeyProvider 时返回 encryption.ErrLocked
+func (o Options) key(h *encryption.Header) ([]byte, error) {
+	if o.KeyProvider == nil {
+		return nil, encryption.ErrLocked
+	}
+	key, err := o.KeyProvider(h)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.Verify(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// EncryptionMode 当前的加密模式，未加密时为空
+func (s *Storage) EncryptionMode() string {
+	switch {
+	case s.sealed != nil:
+		return encryption.ModeStore
+	case s.fields != nil:
+		return encryption.ModeFields
+	}
+	return ""
+}
+
+// EncryptionHeader 当前的加密参数，未加密时为 nil
+func (s *Storage) EncryptionHeader() *encryption.Header {
+	if s.sealed != nil {
+		return s.sealed.header
+	}
+	return s.fields
+}
+
+// Encrypt 使用 header 和 key 加密数据库。fields 模式在一个事务中加密所有任务的标题和描述；
+// store 模式加密整个数据库文件，之后改用工作副本
+func (s *Storage) Encrypt(h *encryption.Header, key []byte) error {
+	if mode := s.EncryptionMode(); mode != "" {
+		return fmt.Errorf("database is already encrypted (%s)", mode)
+	}
+	if err := h.Verify(key); err != nil {
+		return err
+	}
+
+	switch h.Mode {
+	case encryption.ModeFields:
+		return s.encryptFields(h, key)
+	case encryption.ModeStore:
+		return s.sealStore(h, key)
+	}
+	return fmt.Errorf("invalid encryption mode %q", h.Mode)
+}
+
+// Decrypt 解密数据库，恢复为普通的 SQLite 数据库
+func (s *Storage) Decrypt() error {
+	switch s.EncryptionMode() {
+	case encryption.ModeFields:
+		return s.decryptFields()
+	case encryption.ModeStore:
+		return s.unsealStore()
+	}
+	return errors.New("database is not encrypted")
+}
+
+// loadEncryption 读取 fields 模式的加密参数
+func (s *Storage) loadEncryption() error {
+	var value string
+	err := s.db.QueryRow("SELECT value FROM settings WHERE key = ?", settingEncryption).Scan(&value)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read encryption settings: %w", err)
+	}
+
+	var h encryption.Header
+	if err := json.Unmarshal([]byte(value), &h); err != nil {
+		return fmt.Errorf("failed to parse encryption settings: %w", err)
+	}
+	s.fields = &h
+	return nil
+}
+
+// fieldCipher fields 模式使用的 Cipher，第一次调用时通过 Options.KeyProvider 获取密钥。未加密时返回 nil
+func (s *Storage) fieldCipher() (*encryption.Cipher, error) {
+	if s.fields == nil {
+		return nil, nil
+	}
+	if s.cipher != nil {
+		return s.cipher, nil
+	}
+
+	key, err := s.opts.key(s.fields)
+	if err != nil {
+		return nil, err
+	}
+	c, err := encryption.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	s.cipher = c
+	return c, nil
+}
+
+// encryptField fields 模式下加密写入的字段，未加密时原样返回
+func (s *Storage) encryptField(value string) (string, error) {
+	c, err := s.fieldCipher()
+	if err != nil || c == nil {
+		return value, err
+	}
+	return c.EncryptString(value)
+}
+
+// decryptField fields 模式下解密读取的字段，未加密时原样返回
+func (s *Storage) decryptField(value string) (string, error) {
+	if !encryption.IsEncryptedString(value) {
+		return value, nil
+	}
+	c, err := s.fieldCipher()
+	if err != nil {
+		return "", err
+	}
+	if c == nil {
+		return "", errors.New("encrypted field found but database encryption is not configured")
+	}
+	return c.DecryptString(value)
+}
+
+// encryptTask 返回写入数据库的标题和描述，fields 模式下为密文
+func (s *Storage) encryptTask(task *models.Task) (string, string, error) {
+	title, err := s.encryptField(task.Title)
+	if err != nil {
+		return "", "", err
+	}
+	description, err := s.encryptField(task.Description)
+	if err != nil {
+		return "", "", err
+	}
+	return title, description, nil
+}
+
+// decryptTask 解密任务的标题和描述
+func (s *Storage) decryptTask(task *models.Task) error {
+	var err error
+	if task.Title, err = s.decryptField(task.Title); err != nil {
+		return err
+	}
+	task.Description, err = s.decryptField(task.Description)
+	return err
+}
+
+// encryptFields 加密所有任务的标题和描述以及发件箱中的任务快照，并保存加密参数
+func (s *Storage) encryptFields(h *encryption.Header, key []byte) error {
+	c, err := encryption.NewCipher(key)
+	if err != nil {
+		return err
+	}
+	headerJSON, err := json.Marshal(h)
+	if err != nil {
+		return fmt.Errorf("failed to encode encryption settings: %w", err)
+	}
+
+	err = s.convertFields(func(value string) (string, error) {
+		if encryption.IsEncryptedString(value) {
+			return value, nil
+		}
+		return c.EncryptString(value)
+	}, func(tx *sql.Tx) error {
+		_, err := tx.Exec("INSERT INTO settings (key, value) VALUES (?, ?)", settingEncryption, string(headerJSON))
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encrypt database: %w", err)
+	}
+
+	s.fields = h
+	s.cipher = c
+	return nil
+}
+
+// decryptFields 解密所有字段并删除加密参数
+func (s *Storage) decryptFields() error {
+	if _, err := s.fieldCipher(); err != nil {
+		return err
+	}
+
+	err := s.convertFields(s.decryptField, func(tx *sql.Tx) error {
+		_, err := tx.Exec("DELETE FROM settings WHERE key = ?", settingEncryption)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to decrypt database: %w", err)
+	}
+
+	s.fields = nil
+	s.cipher = nil
+	return nil
+}
+
+// convertFields 在一个事务中用 convert 转换所有加密字段，然后执行 finish
+func (s *Storage) convertFields(convert func(string) (string, error), finish func(tx *sql.Tx) error) error {
+	tx, err := s.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	columns := []struct {
+		table  string
+		column string
+	}{
+		{"tasks", "title"},
+		{"tasks", "description"},
+		{"webhook_outbox", "payload"},
+	}
+	for _, col := range columns {
+		if err := convertColumn(tx, col.table, col.column, convert); err != nil {
+			return err
+		}
+	}
+
+	if err := finish(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// convertColumn 转换一列的所有非空值
+func convertColumn(tx *sql.Tx, table, column string, convert func(string) (string, error)) error {
+	rows, err := tx.Query(fmt.Sprintf("SELECT id, %s FROM %s WHERE %s IS NOT NULL AND %s != ''", column, table, column, column))
+	if err != nil {
+		return err
+	}
+	values := make(map[int64]string)
+	for rows.Next() {
+		var id int64
+		var value string
+		if err := rows.Scan(&id, &value); err != nil {
+			rows.Close()
+			return err
+		}
+		values[id] = value
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	update := fmt.Sprintf("UPDATE %s SET %s = ? WHERE id = ?", table, column)
+	for id, value := range values {
+		converted, err := convert(value)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(update, converted, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchDecrypted fields 模式下标题和描述无法在 SQL 中匹配，返回在内存中匹配解密后任务的函数
+func matchDecrypted(keyword string) func(*models.Task) bool {
+	keyword = strings.ToLower(keyword)
+	return func(task *models.Task) bool {
+		return strings.Contains(strings.ToLower(task.Title), keyword) ||
+			strings.Contains(strings.ToLower(task.Description), keyword) ||
+			strings.Contains(strings.ToLower(strings.Join(task.Tags, ",")), keyword)
+	}
+}
+
+// openSealedStore 解密整库加密的数据库到工作副本，返回工作副本的状态。key 为 nil 时通过 opts.KeyProvider 获取。
+// 工作副本加锁，同一时间只能被一个进程使用。上次异常退出留下的工作副本可能与加密文件不一致
+// （例如之后恢复了备份），不再使用，先覆写再删除，重新从加密文件解密
+func openSealedStore(path string, key []byte, opts Options) (*sealedStore, error) {
+	h, err := encryption.ReadFileHeader(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read encrypted database: %w", err)
+	}
+
+	workPath, lockPath, err := workingCopyPaths(path)
+	if err != nil {
+		return nil, err
+	}
+	store := &sealedStore{header: h, workPath: workPath, lockPath: lockPath}
+
+	if err := acquireLock(store.lockPath); err != nil {
+		return nil, err
+	}
+
+	if key == nil {
+		key, err = opts.key(h)
+	} else {
+		err = h.Verify(key)
+	}
+	if err != nil {
+		os.Remove(store.lockPath)
+		return nil, err
+	}
+	store.key = key
+
+	wipeWorkingCopy(store.workPath)
+	_, plain, err := encryption.OpenFile(path, key)
+	if err == nil {
+		err = os.WriteFile(store.workPath, plain, 0o600)
+	}
+	if err != nil {
+		os.Remove(store.workPath)
+		os.Remove(store.lockPath)
+		return nil, fmt.Errorf("failed to decrypt database: %w", err)
+	}
+	return store, nil
+}
+
+// workingCopyPaths 整库加密的数据库 path 在运行时目录中的工作副本和锁文件
+func workingCopyPaths(path string) (string, string, error) {
+	dir, err := workspace.PlaintextDir()
+	if err != nil {
+		return "", "", err
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", "", err
+	}
+	sum := sha256.Sum256([]byte(abs))
+	name := hex.EncodeToString(sum[:8])
+	return filepath.Join(dir, name+".db"), filepath.Join(dir, name+".lock"), nil
+}
+
+// lockWorkingCopy 锁定数据库 path 的工作副本并删除残留的工作副本，在替换数据库文件期间阻止其他进程打开它。
+// 其他进程正在使用时返回错误，完成后调用返回的函数释放锁
+func lockWorkingCopy(path string) (func(), error) {
+	workPath, lockPath, err := workingCopyPaths(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := acquireLock(lockPath); err != nil {
+		return nil, err
+	}
+	wipeWorkingCopy(workPath)
+	return func() { os.Remove(lockPath) }, nil
+}
+
+// wipeWorkingCopy 删除残留的明文工作副本及其 -wal/-shm/-journal 文件，删除前先用零覆写内容
+func wipeWorkingCopy(workPath string) {
+	for _, suffix := range []string{"", "-wal", "-shm", "-journal"} {
+		path := workPath + suffix
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if f, err := os.OpenFile(path, os.O_WRONLY, 0); err == nil {
+			f.Write(make([]byte, info.Size()))
+			f.Sync()
+			f.Close()
+		}
+		os.Remove(path)
+	}
+}
+
+// close 重新加密工作副本并写回 path，然后删除工作副本和锁
+func (st *sealedStore) close(path string) error {
+	plain, err := os.ReadFile(st.workPath)
+	if err != nil {
+		return fmt.Errorf("failed to read working copy: %w", err)
+	}
+	if err := encryption.SealFile(path, st.header, st.key, plain); err != nil {
+		return err
+	}
+	st.release()
+	return nil
+}
+
+// release 删除工作副本和锁
+func (st *sealedStore) release() {
+	for _, suffix := range []string{"", "-wal", "-shm", "-journal"} {
+		os.Remove(st.workPath + suffix)
+	}
+	os.Remove(st.lockPath)
+}
+
+// sealStore 将当前数据库文件加密，之后改用工作副本
+func (s *Storage) sealStore(h *encryption.Header, key []byte) error {
+	if s.path == ":memory:" || strings.HasPrefix(s.path, "file:") {
+		return errors.New("in-memory databases cannot be encrypted")
+	}
+
+	// 关闭连接，确保所有修改都已写入数据库文件
+	if _, err := s.exec("PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return fmt.Errorf("failed to checkpoint database: %w", err)
+	}
+	if err := s.db.Close(); err != nil {
+		return fmt.Errorf("failed to close database: %w", err)
+	}
+
+	reopen := func(path string) error {
+		db, err := openDB(path)
+		if err != nil {
+			return err
+		}
+		s.db = db
+		return nil
+	}
+
+	plain, err := os.ReadFile(s.path)
+	if err != nil {
+		reopen(s.path)
+		return fmt.Errorf("failed to read database: %w", err)
+	}
+	if err := encryption.SealFile(s.path, h, key, plain); err != nil {
+		reopen(s.path)
+		return err
+	}
+	for _, suffix := range []string{"-wal", "-shm", "-journal"} {
+		os.Remove(s.path + suffix)
+	}
+
+	// 加密后的文件在本进程退出前仍然通过工作副本使用
+	sealed, err := openSealedStore(s.path, key, s.opts)
+	if err != nil {
+		return err
+	}
+	s.sealed = sealed
+	return reopen(sealed.workPath)
+}
+
+// unsealStore 将工作副本写回为普通数据库文件
+func (s *Storage) unsealStore() error {
+	if _, err := s.exec("PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return fmt.Errorf("failed to checkpoint database: %w", err)
+	}
+	if err := s.db.Close(); err != nil {
+		return fmt.Errorf("failed to close database: %w", err)
+	}
+
+	plain, err := os.ReadFile(s.sealed.workPath)
+	if err == nil {
+		err = writeFileAtomic(s.path, plain)
+	}
+	if err != nil {
+		db, openErr := openDB(s.sealed.workPath)
+		if openErr == nil {
+			s.db = db
+		}
+		return fmt.Errorf("failed to write decrypted database: %w", err)
+	}
+
+	s.sealed.release()
+	s.sealed = nil
+	db, err := openDB(s.path)
+	if err != nil {
+		return err
+	}
+	s.db = db
+	return nil
+}
+
+// acquireLock 创建锁文件并写入当前进程号。锁文件已存在时，持有锁的进程仍在运行则返回错误，否则视为残留并接管。
+// 锁在存储关闭前一直持有，不等待：daemon 和 TUI 运行期间，其他进程打开整库加密的数据库会立即失败
+func acquireLock(path string) error {
+	for attempt := 0; attempt < 2; attempt++ {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			_, err = fmt.Fprintf(f, "%d\n", os.Getpid())
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			return err
+		}
+		if !os.IsExist(err) {
+			return fmt.Errorf("failed to lock database: %w", err)
+		}
+
+		data, _ := os.ReadFile(path)
+		pid, _ := strconv.Atoi(strings.TrimSpace(string(data)))
+		if pid > 0 && processAlive(pid) {
+			return fmt.Errorf("encrypted database is in use by process %d (lock file %s); store mode allows one process at a time, stop it or use fields mode", pid, path)
+		}
+		os.Remove(path)
+	}
+	return fmt.Errorf("failed to lock database: %s", path)
+}
+
+// writeFileAtomic 先写入临时文件再重命名
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
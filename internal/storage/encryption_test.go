@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestAcquireLockStaleLock 持有锁的进程已退出时接管残留的锁文件，仍在运行时报错
+func TestAcquireLockStaleLock(t *testing.T) {
+	// 已经退出的子进程的进程号
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("run child process: %v", err)
+	}
+	exited := cmd.Process.Pid
+
+	path := filepath.Join(t.TempDir(), "todo.db.lock")
+	if err := os.WriteFile(path, []byte(fmt.Sprintf("%d\n", exited)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := acquireLock(path); err != nil {
+		t.Fatalf("stale lock of exited process %d was not taken over: %v", exited, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(string(data)); got != fmt.Sprint(os.Getpid()) {
+		t.Errorf("lock file holds pid %s, want %d", got, os.Getpid())
+	}
+
+	// 锁由仍在运行的进程（当前进程）持有
+	if err := acquireLock(path); err == nil || !strings.Contains(err.Error(), "in use") {
+		t.Errorf("acquireLock on a live lock: err = %v, want in use", err)
+	}
+}
@@ -0,0 +1,192 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+// accuracyTolerance 实际耗时与估算相差在该比例内视为估算准确
+const accuracyTolerance = 0.25
+
+// GetRemainingEffort 汇总未完成任务的剩余工作量，按分类和项目分组。
+// 时长估算会扣除已计时的时间，故事点按原值累加。
+func (s *Storage) GetRemainingEffort(filter StatsFilter) (*models.EffortSummary, error) {
+	where, args := filter.where()
+	tasks, err := s.queryTasks("SELECT "+taskColumns+" FROM tasks WHERE status = 'pending'"+where, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	tracked, err := s.trackedTimes()
+	if err != nil {
+		return nil, err
+	}
+
+	projectNames, err := s.projectNames()
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &models.EffortSummary{}
+	byCategory := make(map[string]*models.EffortBucket)
+	byProject := make(map[string]*models.EffortBucket)
+	for _, task := range tasks {
+		buckets := []*models.EffortBucket{&summary.Total, effortBucket(byCategory, string(task.Category), string(task.Category))}
+		if task.ProjectID != 0 {
+			key := fmt.Sprint(task.ProjectID)
+			buckets = append(buckets, effortBucket(byProject, key, projectNames[task.ProjectID]))
+		}
+
+		remaining := task.Estimate.Duration - tracked[task.ID]
+		if remaining < 0 {
+			remaining = 0
+		}
+		for _, bucket := range buckets {
+			if task.Estimate.IsZero() {
+				bucket.Unestimated++
+				continue
+			}
+			bucket.Tasks++
+			bucket.Remaining += remaining
+			bucket.Points += task.Estimate.Points
+		}
+	}
+
+	summary.ByCategory = sortedEffortBuckets(byCategory)
+	summary.ByProject = sortedEffortBuckets(byProject)
+	return summary, nil
+}
+
+// GetEstimateAccuracy 对比已完成任务的估算与实际耗时。
+// 实际耗时优先使用计时记录，没有计时记录时使用从创建到完成经过的时间。
+func (s *Storage) GetEstimateAccuracy(filter StatsFilter) (*models.AccuracyReport, error) {
+	where, args := filter.where()
+	query := "SELECT " + taskColumns + ` FROM tasks
+	WHERE status = 'completed' AND (estimate_seconds > 0 OR estimate_points > 0)` + where + `
+	ORDER BY completed_at DESC`
+
+	tasks, err := s.queryTasks(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	tracked, err := s.trackedTimes()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.AccuracyReport{Tasks: []models.EstimateAccuracy{}}
+	var ratioSum float64
+	var pointHours, points float64
+	for _, task := range tasks {
+		item := models.EstimateAccuracy{
+			TaskID:   task.ID,
+			Title:    task.Title,
+			Estimate: task.Estimate,
+			Actual:   tracked[task.ID],
+			Source:   "tracked",
+		}
+		if item.Actual == 0 {
+			if task.CompletedAt == nil {
+				continue
+			}
+			item.Actual = task.CompletedAt.Sub(task.CreatedAt)
+			item.Source = "elapsed"
+		}
+
+		if task.Estimate.Duration > 0 {
+			item.Ratio = float64(item.Actual) / float64(task.Estimate.Duration)
+			ratioSum += item.Ratio
+			report.DurationTasks++
+			if item.Ratio >= 1-accuracyTolerance && item.Ratio <= 1+accuracyTolerance {
+				report.WithinRange++
+			}
+		} else {
+			pointHours += item.Actual.Hours()
+			points += task.Estimate.Points
+			report.PointTasks++
+		}
+		report.Tasks = append(report.Tasks, item)
+	}
+
+	if report.DurationTasks > 0 {
+		report.MeanRatio = ratioSum / float64(report.DurationTasks)
+	}
+	if points > 0 {
+		report.HoursPerPoint = pointHours / points
+	}
+	return report, nil
+}
+
+// trackedTimes 每个任务已计时的总时长，运行中的计时器计算到现在
+func (s *Storage) trackedTimes() (map[int64]time.Duration, error) {
+	rows, err := s.db.Query("SELECT task_id, started_at, ended_at FROM time_entries")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query time entries: %w", err)
+	}
+	defer rows.Close()
+
+	now := time.Now()
+	tracked := make(map[int64]time.Duration)
+	for rows.Next() {
+		var entry models.TimeEntry
+		var endedAt sql.NullTime
+		if err := rows.Scan(&entry.TaskID, &entry.StartedAt, &endedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan time entry: %w", err)
+		}
+		if endedAt.Valid {
+			entry.EndedAt = &endedAt.Time
+		}
+		tracked[entry.TaskID] += entry.Duration(now)
+	}
+
+	return tracked, rows.Err()
+}
+
+// projectNames 项目 ID 到名称的映射
+func (s *Storage) projectNames() (map[int64]string, error) {
+	projects, err := s.ListProjects(true)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[int64]string, len(projects))
+	for _, project := range projects {
+		names[project.ID] = project.Name
+	}
+	return names, nil
+}
+
+func effortBucket(buckets map[string]*models.EffortBucket, key, label string) *models.EffortBucket {
+	bucket, ok := buckets[key]
+	if !ok {
+		if label == "" {
+			label = key
+		}
+		bucket = &models.EffortBucket{Key: key, Label: label}
+		buckets[key] = bucket
+	}
+	return bucket
+}
+
+// sortedEffortBuckets 按剩余时长、故事点降序排列
+func sortedEffortBuckets(buckets map[string]*models.EffortBucket) []models.EffortBucket {
+	list := make([]models.EffortBucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		list = append(list, *bucket)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Remaining != list[j].Remaining {
+			return list[i].Remaining > list[j].Remaining
+		}
+		if list[i].Points != list[j].Points {
+			return list[i].Points > list[j].Points
+		}
+		return list[i].Key < list[j].Key
+	})
+	return list
+}
@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+// AddFocusSession 保存一轮番茄钟记录
+func (s *Storage) AddFocusSession(session *models.FocusSession) error {
+	query := `
+	INSERT INTO focus_sessions (task_id, started_at, ended_at, planned_seconds, completed, interruptions, notes, created_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := s.exec(query,
+		session.TaskID, session.StartedAt.UTC(), session.EndedAt.UTC(),
+		int64(session.Planned/time.Second), session.Completed,
+		session.Interruptions, session.Notes, session.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add focus session: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	session.ID = id
+	return nil
+}
+
+// GetTaskFocusStats 单个任务的番茄钟统计
+func (s *Storage) GetTaskFocusStats(taskID int64) (*models.FocusStats, error) {
+	return s.focusStats(" AND f.task_id = ?", []interface{}{taskID})
+}
+
+// GetFocusStats 按统计范围汇总番茄钟
+func (s *Storage) GetFocusStats(filter StatsFilter) (*models.FocusStats, error) {
+	where, args := filter.whereOn("t")
+	return s.focusStats(where, args)
+}
+
+func (s *Storage) focusStats(where string, args []interface{}) (*models.FocusStats, error) {
+	query := `
+	SELECT f.started_at, f.ended_at, f.completed, f.interruptions
+	FROM focus_sessions f
+	JOIN tasks t ON t.id = f.task_id
+	WHERE 1=1` + where
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query focus sessions: %w", err)
+	}
+	defer rows.Close()
+
+	stats := &models.FocusStats{}
+	for rows.Next() {
+		var session models.FocusSession
+		if err := rows.Scan(&session.StartedAt, &session.EndedAt, &session.Completed, &session.Interruptions); err != nil {
+			return nil, fmt.Errorf("failed to scan focus session: %w", err)
+		}
+
+		if session.Completed {
+			stats.Completed++
+		} else {
+			stats.Abandoned++
+		}
+		stats.Interruptions += session.Interruptions
+		stats.FocusTime += session.Duration()
+	}
+
+	return stats, rows.Err()
+}
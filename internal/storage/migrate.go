@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// migration 一个 schema 迁移：SQL 语句，无法用 SQL 表达时使用在同一个事务中执行的函数
+type migration struct {
+	sql string
+	fn  func(tx *sql.Tx) error
+}
+
+// migrations 按顺序执行的 schema 迁移。
+// 第 i 个迁移执行后，数据库的 user_version 为 i+1；已发布的迁移不要修改，只能在末尾追加。
+var migrations = []migration{
+	// 1: 任务截止时间
+	{sql: `ALTER TABLE tasks ADD COLUMN due_at DATETIME;
+	CREATE INDEX IF NOT EXISTS idx_due_at ON tasks(due_at);`},
+	// 2: 延后任务
+	{sql: `ALTER TABLE tasks ADD COLUMN defer_until DATETIME;`},
+	// 3: 任务标签，逗号分隔
+	{sql: `ALTER TABLE tasks ADD COLUMN tags TEXT NOT NULL DEFAULT '';`},
+	// 4: 任务所属项目，0 表示不属于任何项目
+	{sql: `ALTER TABLE tasks ADD COLUMN project_id INTEGER NOT NULL DEFAULT 0;
+	CREATE INDEX IF NOT EXISTS idx_project_id ON tasks(project_id);`},
+	// 5: 看板工作流状态，空字符串表示由 status 推导
+	{sql: `ALTER TABLE tasks ADD COLUMN state TEXT NOT NULL DEFAULT '';`},
+	// 6: 工作量估算，时长（秒）或故事点
+	{sql: `ALTER TABLE tasks ADD COLUMN estimate_seconds INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE tasks ADD COLUMN estimate_points REAL NOT NULL DEFAULT 0;`},
+	// 7: 数据库级别的设置，例如 fields 模式的加密参数
+	{sql: `CREATE TABLE IF NOT EXISTS settings (
+		key TEXT PRIMARY KEY,
+		value TEXT NOT NULL
+	);`},
+	// 8: 时间统一转换为 UTC。之前按写入时的时区存储，带不同时区偏移的时间按字符串比较和排序时顺序错误
+	{fn: migrateTimesToUTC},
+}
+
+// timeColumns 各表中的时间列
+var timeColumns = map[string][]string{
+	"tasks":             {"created_at", "updated_at", "completed_at", "due_at", "defer_until"},
+	"reminders":         {"remind_at", "snoozed_until", "fired_at", "created_at"},
+	"projects":          {"created_at", "updated_at"},
+	"task_dependencies": {"created_at"},
+	"time_entries":      {"started_at", "ended_at", "created_at"},
+	"focus_sessions":    {"started_at", "ended_at", "created_at"},
+	"webhooks":          {"created_at"},
+	"webhook_outbox":    {"next_attempt_at", "created_at", "delivered_at"},
+}
+
+// migrateTimesToUTC 把所有时间列重写为 UTC，格式与驱动写入 time.Time 时相同。无法解析的值保持不变
+func migrateTimesToUTC(tx *sql.Tx) error {
+	for table, columns := range timeColumns {
+		for _, column := range columns {
+			if err := rewriteTimesUTC(tx, table, column); err != nil {
+				return fmt.Errorf("failed to convert %s.%s: %w", table, column, err)
+			}
+		}
+	}
+	return nil
+}
+
+// rewriteTimesUTC 把 table.column 中的时间重写为 UTC
+func rewriteTimesUTC(tx *sql.Tx, table, column string) error {
+	// 转换为 TEXT 后驱动不再解析时间，无法解析的值不会被读成零值
+	rows, err := tx.Query(fmt.Sprintf("SELECT rowid, CAST(%s AS TEXT) FROM %s WHERE %s IS NOT NULL", column, table, column))
+	if err != nil {
+		return err
+	}
+	converted := make(map[int64]time.Time)
+	for rows.Next() {
+		var rowid int64
+		var value string
+		if err := rows.Scan(&rowid, &value); err != nil {
+			rows.Close()
+			return err
+		}
+		if t, ok := parseStoredTime(value); ok {
+			converted[rowid] = t.UTC()
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	update := fmt.Sprintf("UPDATE %s SET %s = ? WHERE rowid = ?", table, column)
+	for rowid, t := range converted {
+		if _, err := tx.Exec(update, t, rowid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseStoredTime 按驱动读取时间的规则解析数据库中的时间，不带时区的值视为 UTC
+func parseStoredTime(value string) (time.Time, bool) {
+	value = strings.TrimSuffix(value, "Z")
+	for _, layout := range sqlite3.SQLiteTimestampFormats {
+		if t, err := time.ParseInLocation(layout, value, time.UTC); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// apply 在事务中执行迁移
+func (m migration) apply(tx *sql.Tx) error {
+	if m.fn != nil {
+		return m.fn(tx)
+	}
+	_, err := tx.Exec(m.sql)
+	return err
+}
+
+// SchemaVersion 当前程序支持的 schema 版本
+func SchemaVersion() int {
+	return len(migrations)
+}
+
+// schemaVersion 读取数据库的 schema 版本
+func (s *Storage) schemaVersion() (int, error) {
+	var version int
+	if err := s.db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// migrate 执行尚未应用的迁移，每个迁移在独立事务中完成。
+// schema 版本在每个迁移的写事务中重新读取：多个进程同时打开旧数据库时，
+// 后拿到写锁的进程会看到其他进程已经应用的迁移并跳过，不会重复执行 ALTER TABLE
+func (s *Storage) migrate() error {
+	// 已是最新版本时不需要获取写锁
+	if version, err := s.schemaVersion(); err != nil || version == len(migrations) {
+		return err
+	}
+
+	for {
+		tx, err := s.begin()
+		if err != nil {
+			return fmt.Errorf("failed to begin migration: %w", err)
+		}
+
+		var version int
+		if err := tx.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to read schema version: %w", err)
+		}
+		if version >= len(migrations) {
+			tx.Rollback()
+			if version > len(migrations) {
+				return fmt.Errorf("database schema version %d is newer than supported version %d", version, len(migrations))
+			}
+			return nil
+		}
+
+		if err := migrations[version].apply(tx); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to apply migration %d: %w", version+1, err)
+		}
+
+		// PRAGMA 不支持参数绑定
+		if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", version+1)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to update schema version: %w", err)
+		}
+
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit migration %d: %w", version+1, err)
+		}
+	}
+}
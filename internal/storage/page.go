@@ -0,0 +1,240 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"iter"
+	"strings"
+
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+// iterBatchSize IterTasks 每次从数据库读取的任务数
+const iterBatchSize = 500
+
+// ErrInvalidCursor 分页游标无法解析，或者不是由同一种排序生成的
+var ErrInvalidCursor = errors.New("invalid page cursor")
+
+// Page 键集分页参数：按排序取 After 之后的 Limit 个任务。
+// After 是上一页返回的游标，记录了上一页最后一个任务的排序列取值和 ID，下一页从这组取值之后继续，
+// 与该任务之后是否被修改或删除无关。已经翻过的位置之前新增的任务，或者修改后排序位置越过游标的任务，
+// 不会出现在后续的页中；其余任务不会重复或遗漏
+type Page struct {
+	// Limit 每页的任务数，0 表示不限
+	Limit int
+	// After 上一页的 TaskPage.Next，为空表示从第一页开始
+	After string
+}
+
+// TaskPage 一页任务
+type TaskPage struct {
+	Tasks []*models.Task
+	// Next 下一页的游标，没有更多任务时为空
+	Next string
+}
+
+// sortKey 排序中的一列
+type sortKey struct {
+	expr string
+	desc bool
+}
+
+// taskOrders 任务列表支持的排序方式。最后一列都是 id，保证顺序唯一，键集分页才不会重复或遗漏
+var taskOrders = map[string][]sortKey{
+	// 优先级从高到低(4->1),创建时间从新到旧
+	"priority": {{"priority", true}, {"created_at", true}, {"id", true}},
+	// 创建时间从新到旧
+	"created_at": {{"created_at", true}, {"id", true}},
+	// 更新时间从新到旧
+	"updated_at": {{"updated_at", true}, {"id", true}},
+	// 截止时间从近到远，没有截止时间的排在最后
+	"due_at": {{"due_at IS NULL", false}, {"COALESCE(due_at, '')", false}, {"priority", true}, {"id", true}},
+}
+
+// taskOrder 排序方式对应的列，默认按优先级排序
+func taskOrder(sortBy string) []sortKey {
+	if keys, ok := taskOrders[sortBy]; ok {
+		return keys
+	}
+	return taskOrders["priority"]
+}
+
+// orderClause ORDER BY 子句
+func orderClause(keys []sortKey) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = key.expr
+		if key.desc {
+			parts[i] += " DESC"
+		}
+	}
+	return " ORDER BY " + strings.Join(parts, ", ")
+}
+
+// keyColumns 排序列的取值，放在查询结果的最前面，用于生成游标。
+// 加上一元 + 后不再是列引用，驱动按存储的原始值返回（时间列不会被解析为 time.Time），与数据库中的值比较时完全一致
+func keyColumns(keys []sortKey) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = "+(" + key.expr + ")"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// orderID 排序方式的标识，写入游标，防止把一种排序的游标用于另一种排序
+func orderID(keys []sortKey) uint32 {
+	return crc32.ChecksumIEEE([]byte(orderClause(keys)))
+}
+
+// encodeCursor 由最后一个任务的排序列取值生成游标：排序标识和各列取值的 JSON 数组，再做 base64 编码
+func encodeCursor(keys []sortKey, values []interface{}) string {
+	data, _ := json.Marshal(append([]interface{}{orderID(keys)}, values...))
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor 解析游标，返回各排序列的取值
+func decodeCursor(keys []sortKey, cursor string) ([]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidCursor, cursor)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var raw []interface{}
+	if err := decoder.Decode(&raw); err != nil || decoder.Decode(new(interface{})) != io.EOF || len(raw) != len(keys)+1 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidCursor, cursor)
+	}
+	if id, err := raw[0].(json.Number).Int64(); err != nil || uint32(id) != orderID(keys) {
+		return nil, fmt.Errorf("%w: %q was created for a different sort order", ErrInvalidCursor, cursor)
+	}
+
+	values := raw[1:]
+	for i, value := range values {
+		switch value := value.(type) {
+		case json.Number:
+			if n, err := value.Int64(); err == nil {
+				values[i] = n
+			} else if f, err := value.Float64(); err == nil {
+				values[i] = f
+			} else {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidCursor, cursor)
+			}
+		case string, nil:
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrInvalidCursor, cursor)
+		}
+	}
+	return values, nil
+}
+
+// afterClause 只保留排在游标之后的行：
+// (k1 在后) OR (k1 相同 AND k2 在后) OR ...，每列的比较值取自游标。
+// 列表达式需要加括号，否则 "due_at IS NULL > x" 会被解析为 "due_at IS (NULL > x)"
+func afterClause(keys []sortKey, values []interface{}) (string, []interface{}) {
+	var terms []string
+	var args []interface{}
+	for i, key := range keys {
+		var conditions []string
+		for j, prev := range keys[:i] {
+			conditions = append(conditions, "("+prev.expr+") = ?")
+			args = append(args, values[j])
+		}
+		op := " > ?"
+		if key.desc {
+			op = " < ?"
+		}
+		conditions = append(conditions, "("+key.expr+")"+op)
+		args = append(args, values[i])
+		terms = append(terms, "("+strings.Join(conditions, " AND ")+")")
+	}
+	return " AND (" + strings.Join(terms, " OR ") + ")", args
+}
+
+// queryPage 按 keys 的顺序查询 where 条件下游标 p.After 之后的一页任务，多取一个任务判断是否还有下一页。
+// match 不为 nil 时在内存中过滤（fields 模式下标题和描述需要解密后才能匹配），SQL 不能加 LIMIT，
+// 游标之后的所有任务都会被读取并解密
+func (s *Storage) queryPage(where string, args []interface{}, keys []sortKey, p Page, match func(*models.Task) bool) (*TaskPage, error) {
+	if p.After != "" {
+		values, err := decodeCursor(keys, p.After)
+		if err != nil {
+			return nil, err
+		}
+		clause, afterArgs := afterClause(keys, values)
+		where += clause
+		args = append(args, afterArgs...)
+	}
+
+	query := "SELECT " + keyColumns(keys) + ", " + taskColumns + " FROM tasks WHERE 1=1" + where + orderClause(keys)
+	if p.Limit > 0 && match == nil {
+		query += fmt.Sprintf(" LIMIT %d", p.Limit+1)
+	}
+
+	tasks, values, err := s.queryTasksWithKeys(query, len(keys), args...)
+	if err != nil {
+		return nil, err
+	}
+	if match != nil {
+		var matchedTasks []*models.Task
+		var matchedValues [][]interface{}
+		for i, task := range tasks {
+			if match(task) {
+				matchedTasks = append(matchedTasks, task)
+				matchedValues = append(matchedValues, values[i])
+			}
+		}
+		tasks, values = matchedTasks, matchedValues
+	}
+
+	page := &TaskPage{Tasks: tasks}
+	if p.Limit > 0 && len(tasks) > p.Limit {
+		page.Tasks = tasks[:p.Limit]
+		page.Next = encodeCursor(keys, values[p.Limit-1])
+	}
+	return page, nil
+}
+
+// GetTaskPage 按 filter 的排序获取一页任务
+func (s *Storage) GetTaskPage(filter TaskFilter) (*TaskPage, error) {
+	where, args := s.taskWhere(filter)
+	return s.queryPage(where, args, taskOrder(filter.SortBy), filter.Page, nil)
+}
+
+// CountTasks 统计符合 filter 的任务数，忽略分页参数
+func (s *Storage) CountTasks(filter TaskFilter) (int, error) {
+	where, args := s.taskWhere(filter)
+	var count int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM tasks WHERE 1=1"+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count tasks: %w", err)
+	}
+	return count, nil
+}
+
+// IterTasks 按 filter 的排序逐个返回任务，用于导出等需要遍历大量任务的场景。
+// 内部按批分页读取，不会一次把所有任务加载到内存，也不会长时间占用读事务。filter.Limit 被忽略
+func (s *Storage) IterTasks(filter TaskFilter) iter.Seq2[*models.Task, error] {
+	return func(yield func(*models.Task, error) bool) {
+		filter.Limit = iterBatchSize
+		for {
+			page, err := s.GetTaskPage(filter)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, task := range page.Tasks {
+				if !yield(task, nil) {
+					return
+				}
+			}
+			if page.Next == "" {
+				return
+			}
+			filter.After = page.Next
+		}
+	}
+}
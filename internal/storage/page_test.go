@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+// TestTaskPageCursor 翻页期间修改或删除上一页的最后一个任务，下一页仍从原来的位置继续
+func TestTaskPageCursor(t *testing.T) {
+	s, err := New(filepath.Join(t.TempDir(), "todo.db"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	// 按优先级排序：P4 的两个任务在前，然后是 P3、P2、P1
+	priorities := []models.Priority{models.PriorityLow, models.PriorityMedium, models.PriorityHigh, models.PriorityUrgent, models.PriorityUrgent}
+	for i, priority := range priorities {
+		if err := s.AddTask(models.NewTask(fmt.Sprintf("任务 %d", i+1), "", models.CategoryWork, priority)); err != nil {
+			t.Fatalf("AddTask: %v", err)
+		}
+	}
+
+	first, err := s.GetTaskPage(TaskFilter{Page: Page{Limit: 2}})
+	if err != nil {
+		t.Fatalf("GetTaskPage: %v", err)
+	}
+	if got := taskIDs(first.Tasks); fmt.Sprint(got) != "[5 4]" || first.Next == "" {
+		t.Fatalf("first page = %v, next %q; want [5 4] with a cursor", got, first.Next)
+	}
+
+	// 上一页的最后一个任务被移到排序的最后，下一页不应重复或跳过任务
+	anchor := first.Tasks[1]
+	anchor.Priority = models.PriorityLow
+	if err := s.UpdateTask(anchor); err != nil {
+		t.Fatalf("UpdateTask: %v", err)
+	}
+	second, err := s.GetTaskPage(TaskFilter{Page: Page{Limit: 2, After: first.Next}})
+	if err != nil {
+		t.Fatalf("GetTaskPage: %v", err)
+	}
+	if got := taskIDs(second.Tasks); fmt.Sprint(got) != "[3 2]" {
+		t.Errorf("second page after updating the anchor = %v, want [3 2]", got)
+	}
+
+	// 删除上一页的最后一个任务后游标仍然有效
+	if err := s.DeleteTask(second.Tasks[1].ID); err != nil {
+		t.Fatalf("DeleteTask: %v", err)
+	}
+	third, err := s.GetTaskPage(TaskFilter{Page: Page{Limit: 2, After: second.Next}})
+	if err != nil {
+		t.Fatalf("GetTaskPage after deleting the anchor: %v", err)
+	}
+	if got := taskIDs(third.Tasks); fmt.Sprint(got) != "[4 1]" || third.Next != "" {
+		t.Errorf("third page = %v, next %q; want [4 1] without a cursor", got, third.Next)
+	}
+
+	for _, cursor := range []string{"xyz", first.Next + "x"} {
+		if _, err := s.GetTaskPage(TaskFilter{Page: Page{Limit: 2, After: cursor}}); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("cursor %q: err = %v, want ErrInvalidCursor", cursor, err)
+		}
+	}
+	// 其他排序方式生成的游标不能混用
+	if _, err := s.GetTaskPage(TaskFilter{SortBy: "due_at", Page: Page{Limit: 2, After: first.Next}}); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("cursor from another sort order: err = %v, want ErrInvalidCursor", err)
+	}
+}
+
+func taskIDs(tasks []*models.Task) []int64 {
+	ids := make([]int64, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
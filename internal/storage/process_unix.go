@@ -0,0 +1,20 @@
+//go:build !windows
+
+package storage
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// processAlive 进程是否仍在运行，无法确定时视为仍在运行。
+// 信号 0 只检查进程是否存在，进程属于其他用户时返回 EPERM，同样视为仍在运行
+func processAlive(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	err = process.Signal(syscall.Signal(0))
+	return err == nil || !errors.Is(err, os.ErrProcessDone)
+}
@@ -0,0 +1,33 @@
+//go:build windows
+
+package storage
+
+import (
+	"syscall"
+)
+
+const (
+	// processQueryLimitedInformation PROCESS_QUERY_LIMITED_INFORMATION，读取退出码所需的最小权限
+	processQueryLimitedInformation = 0x1000
+	// stillActive 进程仍在运行时 GetExitCodeProcess 返回的 STILL_ACTIVE
+	stillActive = 259
+	// errorInvalidParameter 进程不存在时 OpenProcess 返回的 ERROR_INVALID_PARAMETER
+	errorInvalidParameter syscall.Errno = 87
+)
+
+// processAlive 进程是否仍在运行，无法确定时视为仍在运行。
+// Windows 不支持信号 0，通过 OpenProcess 和 GetExitCodeProcess 检查：
+// 进程已退出但还有句柄未关闭时 OpenProcess 仍会成功，需要再看退出码
+func processAlive(pid int) bool {
+	handle, err := syscall.OpenProcess(processQueryLimitedInformation, false, uint32(pid))
+	if err != nil {
+		return err != errorInvalidParameter
+	}
+	defer syscall.CloseHandle(handle)
+
+	var code uint32
+	if err := syscall.GetExitCodeProcess(handle, &code); err != nil {
+		return true
+	}
+	return code == stillActive
+}
@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+const projectColumns = `id, name, description, status, archived, created_at, updated_at`
+
+// AddProject 添加项目
+func (s *Storage) AddProject(project *models.Project) error {
+	query := `
+	INSERT INTO projects (name, description, status, archived, created_at, updated_at)
+	VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := s.exec(query,
+		project.Name, project.Description, project.Status, project.Archived,
+		project.CreatedAt.UTC(), project.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add project: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	project.ID = id
+	return nil
+}
+
+// GetProject 获取单个项目，不存在时返回 nil
+func (s *Storage) GetProject(id int64) (*models.Project, error) {
+	query := "SELECT " + projectColumns + " FROM projects WHERE id = ?"
+
+	project, err := scanProject(s.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	return project, nil
+}
+
+// GetProjectByName 按名称获取项目（不区分大小写），不存在时返回 nil
+func (s *Storage) GetProjectByName(name string) (*models.Project, error) {
+	query := "SELECT " + projectColumns + " FROM projects WHERE name = ? COLLATE NOCASE"
+
+	project, err := scanProject(s.db.QueryRow(query, name))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	return project, nil
+}
+
+// ListProjects 获取项目列表，includeArchived 为 false 时不包含已归档项目
+func (s *Storage) ListProjects(includeArchived bool) ([]*models.Project, error) {
+	query := "SELECT " + projectColumns + " FROM projects"
+	if !includeArchived {
+		query += " WHERE archived = 0"
+	}
+	query += " ORDER BY archived, name"
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	defer rows.Close()
+
+	var projects []*models.Project
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project: %w", err)
+		}
+		projects = append(projects, project)
+	}
+
+	return projects, rows.Err()
+}
+
+// UpdateProject 更新项目
+func (s *Storage) UpdateProject(project *models.Project) error {
+	query := `
+	UPDATE projects
+	SET name = ?, description = ?, status = ?, archived = ?, updated_at = ?
+	WHERE id = ?
+	`
+
+	project.UpdatedAt = time.Now()
+
+	result, err := s.exec(query,
+		project.Name, project.Description, project.Status, project.Archived,
+		project.UpdatedAt.UTC(), project.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("project not found")
+	}
+
+	return nil
+}
+
+// GetProjectProgress 统计项目的任务完成情况
+func (s *Storage) GetProjectProgress(project *models.Project) (*models.ProjectProgress, error) {
+	progress := &models.ProjectProgress{Project: project}
+
+	err := s.db.QueryRow(`
+	SELECT COUNT(*), COALESCE(SUM(CASE WHEN status = 'completed' THEN 1 ELSE 0 END), 0)
+	FROM tasks WHERE project_id = ?
+	`, project.ID).Scan(&progress.Total, &progress.Completed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project progress: %w", err)
+	}
+
+	if progress.Total > 0 {
+		progress.CompletionRate = float64(progress.Completed) / float64(progress.Total) * 100
+	}
+
+	return progress, nil
+}
+
+// scanProject 从查询结果中读取一个项目
+func scanProject(row rowScanner) (*models.Project, error) {
+	var project models.Project
+	var description sql.NullString
+
+	err := row.Scan(
+		&project.ID, &project.Name, &description, &project.Status,
+		&project.Archived, &project.CreatedAt, &project.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	project.Description = description.String
+	return &project, nil
+}
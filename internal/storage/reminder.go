@@ -0,0 +1,255 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+const reminderColumns = `id, task_id, remind_at, offset_seconds, snoozed_until, fired_at, created_at`
+
+// AddReminder 添加提醒
+func (s *Storage) AddReminder(reminder *models.Reminder) error {
+	query := `
+	INSERT INTO reminders (task_id, remind_at, offset_seconds, created_at)
+	VALUES (?, ?, ?, ?)
+	`
+
+	result, err := s.exec(query,
+		reminder.TaskID, nullableTime(reminder.RemindAt),
+		int64(reminder.Offset/time.Second), reminder.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add reminder: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	reminder.ID = id
+	return nil
+}
+
+// GetReminder 获取单个提醒
+func (s *Storage) GetReminder(id int64) (*models.Reminder, error) {
+	query := "SELECT " + reminderColumns + " FROM reminders WHERE id = ?"
+
+	reminder, err := scanReminder(s.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reminder: %w", err)
+	}
+
+	return reminder, nil
+}
+
+// ListReminders 获取提醒列表，taskID 为 0 时返回所有任务的提醒
+func (s *Storage) ListReminders(taskID int64, includeFired bool) ([]*models.Reminder, error) {
+	query := "SELECT " + reminderColumns + " FROM reminders WHERE 1=1"
+	args := []interface{}{}
+
+	if taskID != 0 {
+		query += " AND task_id = ?"
+		args = append(args, taskID)
+	}
+	if !includeFired {
+		query += " AND fired_at IS NULL"
+	}
+	query += " ORDER BY id"
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reminders: %w", err)
+	}
+	defer rows.Close()
+
+	var reminders []*models.Reminder
+	for rows.Next() {
+		reminder, err := scanReminder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reminder: %w", err)
+		}
+		reminders = append(reminders, reminder)
+	}
+
+	return reminders, rows.Err()
+}
+
+// DeleteReminder 删除提醒
+func (s *Storage) DeleteReminder(id int64) error {
+	result, err := s.exec("DELETE FROM reminders WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete reminder: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("reminder not found")
+	}
+
+	return nil
+}
+
+// SnoozeReminder 推迟提醒，已触发的提醒会在新的时间再次触发
+func (s *Storage) SnoozeReminder(id int64, until time.Time) error {
+	result, err := s.exec(
+		"UPDATE reminders SET snoozed_until = ?, fired_at = NULL WHERE id = ?",
+		until.UTC(), id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to snooze reminder: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("reminder not found")
+	}
+
+	return nil
+}
+
+// GetUpcomingReminders 获取所有未触发提醒及其触发时间（按触发时间排序）。
+// 已完成任务的提醒和无法计算触发时间的提醒会被跳过。
+// 提醒和任务在一次 JOIN 查询中读出，同一任务的多个提醒共用一个任务对象
+func (s *Storage) GetUpcomingReminders() ([]*models.DueReminder, error) {
+	query := "SELECT " + qualifyColumns("r", reminderColumns) + ", " + qualifyColumns("t", taskColumns) + `
+	FROM reminders r
+	JOIN tasks t ON t.id = r.task_id
+	WHERE r.fired_at IS NULL AND t.status != 'completed'
+	ORDER BY r.id`
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reminders: %w", err)
+	}
+	defer rows.Close()
+
+	var upcoming []*models.DueReminder
+	var tasks []*models.Task
+	seen := make(map[int64]*models.Task)
+	for rows.Next() {
+		var r reminderRow
+		task, err := scanTask(prefixScanner{row: rows, prefix: r.dest()})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reminder: %w", err)
+		}
+		if shared, ok := seen[task.ID]; ok {
+			task = shared
+		} else {
+			if err := s.decryptTask(task); err != nil {
+				return nil, fmt.Errorf("failed to decrypt task %d: %w", task.ID, err)
+			}
+			seen[task.ID] = task
+			tasks = append(tasks, task)
+		}
+
+		reminder := r.toReminder()
+		triggerAt, ok := reminder.TriggerAt(task)
+		if !ok {
+			continue
+		}
+
+		upcoming = append(upcoming, &models.DueReminder{
+			Reminder:  reminder,
+			Task:      task,
+			TriggerAt: triggerAt,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if err := s.fillBlockedBy(tasks); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(upcoming, func(i, j int) bool {
+		return upcoming[i].TriggerAt.Before(upcoming[j].TriggerAt)
+	})
+
+	return upcoming, nil
+}
+
+// MarkReminderFired 将提醒标记为已触发。
+// 只有尚未触发的提醒会被更新，返回 false 表示提醒已被其他进程触发，调用方不应重复通知。
+func (s *Storage) MarkReminderFired(id int64, firedAt time.Time) (bool, error) {
+	result, err := s.exec(
+		"UPDATE reminders SET fired_at = ? WHERE id = ? AND fired_at IS NULL",
+		firedAt.UTC(), id,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark reminder fired: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// rearmDueRemindersQuery 截止时间变化后重置基于截止时间的提醒
+const rearmDueRemindersQuery = "UPDATE reminders SET fired_at = NULL, snoozed_until = NULL WHERE task_id = ? AND remind_at IS NULL"
+
+// reminderRow 提醒在查询结果中的原始列
+type reminderRow struct {
+	reminder                      models.Reminder
+	remindAt, snoozedUntil, fired sql.NullTime
+	offsetSeconds                 int64
+}
+
+// dest 与 reminderColumns 顺序一致的扫描目标
+func (r *reminderRow) dest() []interface{} {
+	return []interface{}{
+		&r.reminder.ID, &r.reminder.TaskID, &r.remindAt, &r.offsetSeconds,
+		&r.snoozedUntil, &r.fired, &r.reminder.CreatedAt,
+	}
+}
+
+// toReminder 由原始列生成提醒
+func (r *reminderRow) toReminder() *models.Reminder {
+	reminder := r.reminder
+	reminder.Offset = time.Duration(r.offsetSeconds) * time.Second
+	if r.remindAt.Valid {
+		reminder.RemindAt = &r.remindAt.Time
+	}
+	if r.snoozedUntil.Valid {
+		reminder.SnoozedUntil = &r.snoozedUntil.Time
+	}
+	if r.fired.Valid {
+		reminder.FiredAt = &r.fired.Time
+	}
+	return &reminder
+}
+
+func scanReminder(row rowScanner) (*models.Reminder, error) {
+	var r reminderRow
+	if err := row.Scan(r.dest()...); err != nil {
+		return nil, err
+	}
+	return r.toReminder(), nil
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(*b)
+}
@@ -5,44 +5,131 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
+	"github.com/WHITE13452/toDoList/internal/encryption"
+	"github.com/WHITE13452/toDoList/internal/events"
 	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/WHITE13452/toDoList/internal/workspace"
 )
 
 // Storage SQLite 存储实现
 type Storage struct {
-	db *sql.DB
+	db       *sql.DB
+	path     string
+	bus      *events.Bus
+	workflow *models.Workflow
+	// fields/cipher fields 模式加密的参数和密钥，未加密时为 nil
+	fields *encryption.Header
+	cipher *encryption.Cipher
+	// sealed 整库加密时的工作副本，未加密时为 nil
+	sealed *sealedStore
+	opts   Options
 }
 
-// New 创建新的存储实例
+// Options 打开存储的选项
+type Options struct {
+	// KeyProvider 返回加密数据库的密钥，例如从密钥代理读取或提示输入口令，只在第一次需要解密时调用。
+	// 为 nil 时无法打开加密的数据库
+	KeyProvider func(h *encryption.Header) ([]byte, error)
+}
+
+// New 使用默认选项创建存储实例，加密的数据库无法解密
 func New(dbPath string) (*Storage, error) {
-	// 如果没有指定路径，使用默认路径
+	return NewWithOptions(dbPath, Options{})
+}
+
+// NewWithOptions 创建新的存储实例
+func NewWithOptions(dbPath string, opts Options) (*Storage, error) {
+	// 如果没有指定路径，使用默认工作区的数据库
 	if dbPath == "" {
-		home, err := os.Getwd()
+		path, err := workspace.Path(workspace.Default)
+		if err != nil {
+			return nil, err
+		}
+		dbPath = path
+	}
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create database directory: %w", err)
+	}
+
+	// 整库加密的数据库先解密到工作副本，之后的读写都在工作副本上进行
+	openPath := dbPath
+	var sealed *sealedStore
+	isSealed, err := encryption.IsSealedFile(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read database: %w", err)
+	}
+	if isSealed {
+		store, err := openSealedStore(dbPath, nil, opts)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get current directory: %w", err)
+			return nil, err
 		}
-		dbPath = filepath.Join(home, ".todolist.db")
+		sealed = store
+		openPath = store.workPath
 	}
 
-	db, err := sql.Open("sqlite3", dbPath)
+	db, err := openDB(openPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
+		return nil, err
 	}
 
-	storage := &Storage{db: db}
-	if err := storage.initDatabase(); err != nil {
+	storage := &Storage{db: db, path: dbPath, workflow: models.DefaultWorkflow(), sealed: sealed, opts: opts}
+	if err := storage.init(); err != nil {
+		storage.Close()
 		return nil, err
 	}
 
 	return storage, nil
 }
 
-// initDatabase 初始化数据库表
-func (s *Storage) initDatabase() error {
-	query := `
+// init 备份、建表、迁移并读取加密参数
+func (s *Storage) init() error {
+	// 升级 schema 之前先备份，迁移失败时可以用 'todo restore' 恢复
+	if err := s.backupBeforeMigration(); err != nil {
+		return err
+	}
+	if err := s.initDatabase(); err != nil {
+		return err
+	}
+	if err := s.migrate(); err != nil {
+		return err
+	}
+	return s.loadEncryption()
+}
+
+// Path 数据库文件路径
+func (s *Storage) Path() string {
+	return s.path
+}
+
+// SetEventBus 设置事件总线，任务的增删改会发布对应的生命周期事件
+func (s *Storage) SetEventBus(bus *events.Bus) {
+	s.bus = bus
+}
+
+// SetWorkflow 设置看板工作流，用于按状态过滤和统计
+func (s *Storage) SetWorkflow(workflow *models.Workflow) {
+	s.workflow = workflow
+}
+
+// Workflow 当前使用的看板工作流
+func (s *Storage) Workflow() *models.Workflow {
+	return s.workflow
+}
+
+// publish 发布事件（未设置事件总线时忽略）
+func (s *Storage) publish(eventType events.Type, task *models.Task) {
+	if s.bus == nil {
+		return
+	}
+	s.bus.Publish(events.New(eventType, task))
+}
+
+// schema 初始化数据库使用的建表语句，之后的修改通过 migrations 完成
+const schema = `
 	CREATE TABLE IF NOT EXISTS tasks (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
 		title TEXT NOT NULL,
@@ -57,25 +144,229 @@ func (s *Storage) initDatabase() error {
 	CREATE INDEX IF NOT EXISTS idx_status ON tasks(status);
 	CREATE INDEX IF NOT EXISTS idx_category ON tasks(category);
 	CREATE INDEX IF NOT EXISTS idx_priority ON tasks(priority);
+
+	CREATE TABLE IF NOT EXISTS webhooks (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		url TEXT NOT NULL,
+		secret TEXT NOT NULL DEFAULT '',
+		events TEXT NOT NULL DEFAULT '',
+		filter TEXT NOT NULL DEFAULT '',
+		active INTEGER NOT NULL DEFAULT 1,
+		created_at DATETIME NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS webhook_outbox (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		webhook_id INTEGER NOT NULL,
+		event TEXT NOT NULL,
+		payload TEXT NOT NULL,
+		status TEXT NOT NULL DEFAULT 'pending',
+		attempts INTEGER NOT NULL DEFAULT 0,
+		next_attempt_at DATETIME NOT NULL,
+		last_error TEXT NOT NULL DEFAULT '',
+		created_at DATETIME NOT NULL,
+		delivered_at DATETIME
+	);
+	CREATE INDEX IF NOT EXISTS idx_outbox_due ON webhook_outbox(status, next_attempt_at);
+
+	CREATE TABLE IF NOT EXISTS reminders (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL,
+		remind_at DATETIME,
+		offset_seconds INTEGER NOT NULL DEFAULT 0,
+		snoozed_until DATETIME,
+		fired_at DATETIME,
+		created_at DATETIME NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_reminders_task ON reminders(task_id);
+	CREATE INDEX IF NOT EXISTS idx_reminders_fired ON reminders(fired_at);
+
+	CREATE TABLE IF NOT EXISTS projects (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL UNIQUE,
+		description TEXT,
+		status TEXT NOT NULL DEFAULT 'active',
+		archived INTEGER NOT NULL DEFAULT 0,
+		created_at DATETIME NOT NULL,
+		updated_at DATETIME NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS task_dependencies (
+		task_id INTEGER NOT NULL,
+		depends_on_id INTEGER NOT NULL,
+		created_at DATETIME NOT NULL,
+		PRIMARY KEY (task_id, depends_on_id)
+	);
+	CREATE INDEX IF NOT EXISTS idx_dependencies_depends_on ON task_dependencies(depends_on_id);
+
+	CREATE TABLE IF NOT EXISTS time_entries (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL,
+		started_at DATETIME NOT NULL,
+		ended_at DATETIME,
+		note TEXT NOT NULL DEFAULT '',
+		created_at DATETIME NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_time_entries_task ON time_entries(task_id);
+	-- 同一时间最多只有一个运行中的计时器
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_time_entries_running ON time_entries((ended_at IS NULL)) WHERE ended_at IS NULL;
+
+	CREATE TABLE IF NOT EXISTS focus_sessions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL,
+		started_at DATETIME NOT NULL,
+		ended_at DATETIME NOT NULL,
+		planned_seconds INTEGER NOT NULL,
+		completed INTEGER NOT NULL DEFAULT 0,
+		interruptions INTEGER NOT NULL DEFAULT 0,
+		notes TEXT NOT NULL DEFAULT '',
+		created_at DATETIME NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_focus_sessions_task ON focus_sessions(task_id);
 	`
 
-	if _, err := s.db.Exec(query); err != nil {
+// initDatabase 初始化数据库表
+func (s *Storage) initDatabase() error {
+	if _, err := s.exec(schema); err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
 
 	return nil
 }
 
+// taskColumns 查询任务时使用的列，顺序与 scanTask 一致
+const taskColumns = `id, title, description, status, category, priority,
+	       created_at, updated_at, completed_at, due_at, defer_until, tags, project_id, state,
+	       estimate_seconds, estimate_points`
+
+// qualifyColumns 给列表中的每一列加上表别名，用于 JOIN 查询
+func qualifyColumns(alias, columns string) string {
+	parts := strings.Split(columns, ",")
+	for i, part := range parts {
+		parts[i] = alias + "." + strings.TrimSpace(part)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// prefixScanner 先读取 prefix 对应的列，其余的列交给 Scan 的调用方，
+// 用于 JOIN 查询中复用 scanTask
+type prefixScanner struct {
+	row    rowScanner
+	prefix []interface{}
+}
+
+func (p prefixScanner) Scan(dest ...interface{}) error {
+	return p.row.Scan(append(p.prefix, dest...)...)
+}
+
+// scanTask 从查询结果中读取一个任务
+func scanTask(row rowScanner) (*models.Task, error) {
+	var task models.Task
+	var description sql.NullString
+	var completedAt, dueAt, deferUntil sql.NullTime
+	var tags string
+	var estimateSeconds int64
+
+	err := row.Scan(
+		&task.ID, &task.Title, &description, &task.Status,
+		&task.Category, &task.Priority, &task.CreatedAt,
+		&task.UpdatedAt, &completedAt, &dueAt, &deferUntil, &tags,
+		&task.ProjectID, &task.State,
+		&estimateSeconds, &task.Estimate.Points,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	task.Description = description.String
+	if completedAt.Valid {
+		task.CompletedAt = &completedAt.Time
+	}
+	if dueAt.Valid {
+		task.DueAt = &dueAt.Time
+	}
+	if deferUntil.Valid {
+		task.DeferUntil = &deferUntil.Time
+	}
+	if tags != "" {
+		task.Tags = strings.Split(tags, ",")
+	}
+	task.Estimate.Duration = time.Duration(estimateSeconds) * time.Second
+
+	return &task, nil
+}
+
+// queryTasks 执行查询并读取所有任务
+func (s *Storage) queryTasks(query string, args ...interface{}) ([]*models.Task, error) {
+	tasks, _, err := s.queryTasksWithKeys(query, 0, args...)
+	return tasks, err
+}
+
+// queryTasksWithKeys 执行查询并读取所有任务，每行前 nkeys 列是排序列的取值，与任务一起返回
+func (s *Storage) queryTasksWithKeys(query string, nkeys int, args ...interface{}) ([]*models.Task, [][]interface{}, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query tasks: %w", err)
+	}
+	defer rows.Close()
+
+	var tasks []*models.Task
+	var keys [][]interface{}
+	for rows.Next() {
+		values := make([]interface{}, nkeys)
+		prefix := make([]interface{}, nkeys)
+		for i := range values {
+			prefix[i] = &values[i]
+		}
+		task, err := scanTask(prefixScanner{row: rows, prefix: prefix})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+		if err := s.decryptTask(task); err != nil {
+			return nil, nil, fmt.Errorf("failed to decrypt task %d: %w", task.ID, err)
+		}
+		tasks = append(tasks, task)
+		keys = append(keys, values)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	rows.Close()
+
+	if err := s.fillBlockedBy(tasks); err != nil {
+		return nil, nil, err
+	}
+	return tasks, keys, nil
+}
+
+// nullableTime 将可选时间转换为数据库参数，与其他时间一样以 UTC 存储
+func nullableTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC()
+}
+
 // AddTask 添加任务
 func (s *Storage) AddTask(task *models.Task) error {
 	query := `
-	INSERT INTO tasks (title, description, status, category, priority, created_at, updated_at)
-	VALUES (?, ?, ?, ?, ?, ?, ?)
+	INSERT INTO tasks (title, description, status, category, priority,
+	                   created_at, updated_at, due_at, defer_until, tags, project_id, state,
+	                   estimate_seconds, estimate_points)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	result, err := s.db.Exec(query,
-		task.Title, task.Description, task.Status, task.Category,
-		task.Priority, task.CreatedAt, task.UpdatedAt,
+	title, description, err := s.encryptTask(task)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.exec(query,
+		title, description, task.Status, task.Category,
+		task.Priority, task.CreatedAt.UTC(), task.UpdatedAt.UTC(),
+		nullableTime(task.DueAt), nullableTime(task.DeferUntil),
+		strings.Join(task.Tags, ","), task.ProjectID, task.State,
+		int64(task.Estimate.Duration/time.Second), task.Estimate.Points,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to add task: %w", err)
@@ -87,103 +378,159 @@ func (s *Storage) AddTask(task *models.Task) error {
 	}
 
 	task.ID = id
+	s.publish(events.TaskCreated, task)
 	return nil
 }
 
 // GetTask 获取单个任务
 func (s *Storage) GetTask(id int64) (*models.Task, error) {
-	query := `
-	SELECT id, title, description, status, category, priority,
-	       created_at, updated_at, completed_at
-	FROM tasks WHERE id = ?
-	`
-
-	var task models.Task
-	var completedAt sql.NullTime
-
-	err := s.db.QueryRow(query, id).Scan(
-		&task.ID, &task.Title, &task.Description, &task.Status,
-		&task.Category, &task.Priority, &task.CreatedAt,
-		&task.UpdatedAt, &completedAt,
-	)
+	query := "SELECT " + taskColumns + " FROM tasks WHERE id = ?"
 
+	task, err := scanTask(s.db.QueryRow(query, id))
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get task: %w", err)
 	}
-
-	if completedAt.Valid {
-		task.CompletedAt = &completedAt.Time
+	if err := s.decryptTask(task); err != nil {
+		return nil, fmt.Errorf("failed to decrypt task %d: %w", task.ID, err)
 	}
 
-	return &task, nil
+	if err := s.fillBlockedBy([]*models.Task{task}); err != nil {
+		return nil, err
+	}
+	return task, nil
 }
 
-// GetAllTasks 获取所有任务
-func (s *Storage) GetAllTasks(status models.TaskStatus, category models.TaskCategory, sortBy string) ([]*models.Task, error) {
-    query := `
-    SELECT id, title, description, status, category, priority,
-           created_at, updated_at, completed_at
-    FROM tasks WHERE 1=1
-    `
-    args := []interface{}{}
+// TaskFilter 任务列表查询条件
+type TaskFilter struct {
+	Status   models.TaskStatus
+	Category models.TaskCategory
+	SortBy   string
+	// IncludeDeferred 为 true 时包含延后时间未到的任务
+	IncludeDeferred bool
+	// DueBefore/DueAfter 按截止时间过滤，设置后没有截止时间的任务不会返回
+	DueBefore *time.Time
+	DueAfter  *time.Time
+	// Tag 只返回带有该标签的任务
+	Tag string
+	// ProjectID 只返回该项目下的任务，0 表示不限
+	ProjectID int64
+	// State 只返回处于该工作流状态的任务
+	State string
+	// Actionable 只返回现在就可以做的任务：未完成且前置任务都已完成
+	Actionable bool
+	// Page 分页，按 SortBy 的顺序取 After 之后的 Limit 个任务
+	Page
+}
 
-    if status != "" {
-        query += " AND status = ?"
-        args = append(args, status)
+// GetAllTasks 获取所有任务，设置 filter.Limit/After 时只返回一页
+func (s *Storage) GetAllTasks(filter TaskFilter) ([]*models.Task, error) {
+    page, err := s.GetTaskPage(filter)
+    if err != nil {
+        return nil, err
     }
+    return page.Tasks, nil
+}
 
-    if category != "" {
-        query += " AND category = ?"
-        args = append(args, category)
-    }
+// taskWhere 返回任务列表的过滤条件（以 AND 开头）和参数
+func (s *Storage) taskWhere(filter TaskFilter) (string, []interface{}) {
+	query := ""
+	args := []interface{}{}
 
-    // 动态排序
-    switch sortBy {
-    case "priority":
-        // 优先级从高到低(4->1),创建时间从新到旧
-        query += " ORDER BY priority DESC, created_at DESC"
-    case "created_at":
-        // 创建时间从新到旧
-        query += " ORDER BY created_at DESC"
-    case "updated_at":
-        // 更新时间从新到旧
-        query += " ORDER BY updated_at DESC"
-    default:
-        // 默认排序:优先级降序,创建时间降序
-        query += " ORDER BY priority DESC, created_at DESC"
-    }
+	if filter.Status != "" {
+		query += " AND status = ?"
+		args = append(args, filter.Status)
+	}
 
-    rows, err := s.db.Query(query, args...)
-    if err != nil {
-        return nil, fmt.Errorf("failed to query tasks: %w", err)
-    }
-    defer rows.Close()
-
-    var tasks []*models.Task
-    for rows.Next() {
-        var task models.Task
-        var completedAt sql.NullTime
-
-        err := rows.Scan(
-            &task.ID, &task.Title, &task.Description, &task.Status,
-            &task.Category, &task.Priority, &task.CreatedAt,
-            &task.UpdatedAt, &completedAt,
-        )
-        if err != nil {
-            return nil, fmt.Errorf("failed to scan task: %w", err)
-        }
-
-        if completedAt.Valid {
-            task.CompletedAt = &completedAt.Time
-        }
-
-        tasks = append(tasks, &task)
-    }
+	if filter.Category != "" {
+		query += " AND category = ?"
+		args = append(args, filter.Category)
+	}
+
+	// 延后时间到了的任务自动重新出现
+	if !filter.IncludeDeferred {
+		query += " AND (defer_until IS NULL OR defer_until <= ?)"
+		args = append(args, time.Now().UTC())
+	}
+
+	if filter.ProjectID != 0 {
+		query += " AND project_id = ?"
+		args = append(args, filter.ProjectID)
+	}
+
+	if filter.Actionable {
+		query += " AND status = 'pending' AND NOT " + openPrerequisiteCondition
+	}
+
+	if filter.State != "" {
+		clause, stateArgs := s.stateClause(filter.State)
+		query += " AND " + clause
+		args = append(args, stateArgs...)
+	}
+
+	if filter.Tag != "" {
+		query += " AND (',' || tags || ',') LIKE ?"
+		args = append(args, "%,"+filter.Tag+",%")
+	}
+
+	if filter.DueBefore != nil {
+		query += " AND due_at IS NOT NULL AND due_at <= ?"
+		args = append(args, filter.DueBefore.UTC())
+	}
+
+	if filter.DueAfter != nil {
+		query += " AND due_at IS NOT NULL AND due_at >= ?"
+		args = append(args, filter.DueAfter.UTC())
+	}
+
+	return query, args
+}
+
+// stateClause 生成按工作流状态过滤的条件，与 Workflow.Resolve 的映射规则一致：
+// state 为空、未定义或与完成状态不符的任务归入初始状态或第一个完成状态。
+func (s *Storage) stateClause(state string) (string, []interface{}) {
+	target, ok := s.workflow.State(state)
+	if !ok {
+		return "state = ?", []interface{}{state}
+	}
 
-    return tasks, nil
+	status := models.StatusPending
+	if target.Done {
+		status = models.StatusCompleted
+	}
+	clause := "(state = ? AND status = ?)"
+	args := []interface{}{state, status}
+
+	if state != s.workflow.Initial && state != s.workflow.DoneState() {
+		return clause, args
+	}
+
+	// 与目标完成状态一致的状态名，其余状态名都归入兜底状态
+	placeholders := []string{}
+	args = append(args, status)
+	for _, st := range s.workflow.States {
+		if st.Done == target.Done {
+			placeholders = append(placeholders, "?")
+			args = append(args, st.Name)
+		}
+	}
+	clause = "(" + clause + " OR (status = ? AND state NOT IN (" + strings.Join(placeholders, ", ") + ")))"
+	return clause, args
+}
+
+// CountDeferredTasks 统计当前处于延后状态的未完成任务数量
+func (s *Storage) CountDeferredTasks() (int, error) {
+	var count int
+	err := s.db.QueryRow(
+		"SELECT COUNT(*) FROM tasks WHERE status != 'completed' AND defer_until > ?",
+		time.Now().UTC(),
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count deferred tasks: %w", err)
+	}
+	return count, nil
 }
 
 // UpdateTask 更新任务
@@ -191,32 +538,69 @@ func (s *Storage) UpdateTask(task *models.Task) error {
 	query := `
 	UPDATE tasks
 	SET title = ?, description = ?, status = ?, category = ?,
-	    priority = ?, updated_at = ?, completed_at = ?
+	    priority = ?, updated_at = ?, completed_at = ?, due_at = ?,
+	    defer_until = ?, tags = ?, project_id = ?, state = ?,
+	    estimate_seconds = ?, estimate_points = ?
 	WHERE id = ?
 	`
 
 	task.UpdatedAt = time.Now()
 
-	var completedAt interface{}
-	if task.CompletedAt != nil {
-		completedAt = task.CompletedAt
+	title, description, err := s.encryptTask(task)
+	if err != nil {
+		return err
 	}
 
-	result, err := s.db.Exec(query,
-		task.Title, task.Description, task.Status, task.Category,
-		task.Priority, task.UpdatedAt, completedAt, task.ID,
-	)
+	// 读取旧状态和更新在同一个写事务中完成，并发修改同一个任务时
+	// 只有一个进程会看到状态变化，completed/reopened 事件不会重复或遗漏
+	tx, err := s.begin()
 	if err != nil {
-		return fmt.Errorf("failed to update task: %w", err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	rowsAffected, err := result.RowsAffected()
+	var previousStatus models.TaskStatus
+	var previousDue sql.NullTime
+	err = tx.QueryRow("SELECT status, due_at FROM tasks WHERE id = ?", task.ID).Scan(&previousStatus, &previousDue)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("task not found")
+	}
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return fmt.Errorf("failed to get task: %w", err)
 	}
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("task not found")
+	if _, err := tx.Exec(query,
+		title, description, task.Status, task.Category,
+		task.Priority, task.UpdatedAt.UTC(), nullableTime(task.CompletedAt),
+		nullableTime(task.DueAt), nullableTime(task.DeferUntil),
+		strings.Join(task.Tags, ","), task.ProjectID, task.State,
+		int64(task.Estimate.Duration/time.Second), task.Estimate.Points, task.ID,
+	); err != nil {
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+
+	// 截止时间变化后，基于截止时间的提醒需要重新触发
+	var previousDueAt *time.Time
+	if previousDue.Valid {
+		previousDueAt = &previousDue.Time
+	}
+	if !sameTime(previousDueAt, task.DueAt) {
+		if _, err := tx.Exec(rearmDueRemindersQuery, task.ID); err != nil {
+			return fmt.Errorf("failed to reset reminders: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit task update: %w", err)
+	}
+
+	switch {
+	case previousStatus != models.StatusCompleted && task.Status == models.StatusCompleted:
+		s.publish(events.TaskCompleted, task)
+	case previousStatus == models.StatusCompleted && task.Status != models.StatusCompleted:
+		s.publish(events.TaskReopened, task)
+	default:
+		s.publish(events.TaskUpdated, task)
 	}
 
 	return nil
@@ -224,9 +608,18 @@ func (s *Storage) UpdateTask(task *models.Task) error {
 
 // DeleteTask 删除任务
 func (s *Storage) DeleteTask(id int64) error {
+	// 删除前取出任务快照，用于发布删除事件
+	task, err := s.GetTask(id)
+	if err != nil {
+		return err
+	}
+	if task == nil {
+		return fmt.Errorf("task not found")
+	}
+
 	query := "DELETE FROM tasks WHERE id = ?"
 
-	result, err := s.db.Exec(query, id)
+	result, err := s.exec(query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete task: %w", err)
 	}
@@ -240,64 +633,98 @@ func (s *Storage) DeleteTask(id int64) error {
 		return fmt.Errorf("task not found")
 	}
 
+	if _, err := s.exec("DELETE FROM reminders WHERE task_id = ?", id); err != nil {
+		return fmt.Errorf("failed to delete task reminders: %w", err)
+	}
+
+	if _, err := s.exec("DELETE FROM task_dependencies WHERE task_id = ? OR depends_on_id = ?", id, id); err != nil {
+		return fmt.Errorf("failed to delete task dependencies: %w", err)
+	}
+
+	if _, err := s.exec("DELETE FROM time_entries WHERE task_id = ?", id); err != nil {
+		return fmt.Errorf("failed to delete task time entries: %w", err)
+	}
+
+	if _, err := s.exec("DELETE FROM focus_sessions WHERE task_id = ?", id); err != nil {
+		return fmt.Errorf("failed to delete task focus sessions: %w", err)
+	}
+
+	s.publish(events.TaskDeleted, task)
 	return nil
 }
 
-// SearchTasks 搜索任务
-func (s *Storage) SearchTasks(keyword string) ([]*models.Task, error) {
-	query := `
-	SELECT id, title, description, status, category, priority,
-	       created_at, updated_at, completed_at
-	FROM tasks
-	WHERE title LIKE ? OR description LIKE ?
-	ORDER BY priority DESC, created_at DESC
-	`
+// SearchTasks 搜索任务，projectID 不为 0 时只搜索该项目下的任务
+func (s *Storage) SearchTasks(keyword string, projectID int64) ([]*models.Task, error) {
+	page, err := s.SearchTaskPage(keyword, projectID, Page{})
+	if err != nil {
+		return nil, err
+	}
+	return page.Tasks, nil
+}
+
+// SearchTaskPage 搜索任务并分页，按优先级排序。
+// fields 模式下标题和描述是密文，无法在 SQL 中匹配：游标之后的所有任务（第一页时是整张表）都会被读取、
+// 解密后在内存中匹配，任务很多时每一页都比未加密时慢得多
+func (s *Storage) SearchTaskPage(keyword string, projectID int64, p Page) (*TaskPage, error) {
+	where := ""
+	var args []interface{}
+	var match func(*models.Task) bool
+	if s.fields == nil {
+		pattern := "%" + keyword + "%"
+		where += " AND (title LIKE ? OR description LIKE ? OR tags LIKE ?)"
+		args = append(args, pattern, pattern, pattern)
+	} else {
+		match = matchDecrypted(keyword)
+	}
+	if projectID != 0 {
+		where += " AND project_id = ?"
+		args = append(args, projectID)
+	}
 
-	pattern := "%" + keyword + "%"
-	rows, err := s.db.Query(query, pattern, pattern)
+	page, err := s.queryPage(where, args, taskOrder("priority"), p, match)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search tasks: %w", err)
 	}
-	defer rows.Close()
+	return page, nil
+}
 
-	var tasks []*models.Task
-	for rows.Next() {
-		var task models.Task
-		var completedAt sql.NullTime
-
-		err := rows.Scan(
-			&task.ID, &task.Title, &task.Description, &task.Status,
-			&task.Category, &task.Priority, &task.CreatedAt,
-			&task.UpdatedAt, &completedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan task: %w", err)
-		}
+// StatsFilter 统计范围
+type StatsFilter struct {
+	// ProjectID 只统计该项目下的任务，0 表示全部
+	ProjectID int64
+}
 
-		if completedAt.Valid {
-			task.CompletedAt = &completedAt.Time
-		}
+// where 返回统计查询的过滤条件（以 AND 开头）和参数
+func (f StatsFilter) where() (string, []interface{}) {
+	return f.whereOn("")
+}
 
-		tasks = append(tasks, &task)
+// whereOn 同 where，列名使用 alias 限定，用于联表查询
+func (f StatsFilter) whereOn(alias string) (string, []interface{}) {
+	if alias != "" {
+		alias += "."
 	}
-
-	return tasks, nil
+	if f.ProjectID != 0 {
+		return " AND " + alias + "project_id = ?", []interface{}{f.ProjectID}
+	}
+	return "", nil
 }
 
 // GetStatistics 获取统计信息
-func (s *Storage) GetStatistics() (*models.Statistics, error) {
+func (s *Storage) GetStatistics(filter StatsFilter) (*models.Statistics, error) {
 	stats := &models.Statistics{
 		ByCategory: make(map[models.TaskCategory]int),
 		ByPriority: make(map[models.Priority]int),
 	}
+	where, args := filter.where()
 
 	// 总数和完成数
-	err := s.db.QueryRow("SELECT COUNT(*) FROM tasks").Scan(&stats.Total)
+	err := s.db.QueryRow("SELECT COUNT(*) FROM tasks WHERE 1=1"+where, args...).Scan(&stats.Total)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get total count: %w", err)
 	}
 
-	err = s.db.QueryRow("SELECT COUNT(*) FROM tasks WHERE status = 'completed'").Scan(&stats.Completed)
+	err = s.db.QueryRow("SELECT COUNT(*) FROM tasks WHERE status = 'completed'"+where, args...).Scan(&stats.Completed)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get completed count: %w", err)
 	}
@@ -309,7 +736,7 @@ func (s *Storage) GetStatistics() (*models.Statistics, error) {
 	}
 
 	// 按分类统计
-	rows, err := s.db.Query("SELECT category, COUNT(*) FROM tasks GROUP BY category")
+	rows, err := s.db.Query("SELECT category, COUNT(*) FROM tasks WHERE 1=1"+where+" GROUP BY category", args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get category stats: %w", err)
 	}
@@ -325,7 +752,7 @@ func (s *Storage) GetStatistics() (*models.Statistics, error) {
 	}
 
 	// 按优先级统计（仅待办）
-	rows, err = s.db.Query("SELECT priority, COUNT(*) FROM tasks WHERE status = 'pending' GROUP BY priority")
+	rows, err = s.db.Query("SELECT priority, COUNT(*) FROM tasks WHERE status = 'pending'"+where+" GROUP BY priority", args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get priority stats: %w", err)
 	}
@@ -340,10 +767,70 @@ func (s *Storage) GetStatistics() (*models.Statistics, error) {
 		stats.ByPriority[priority] = count
 	}
 
+	// 按工作流状态统计
+	rows, err = s.db.Query("SELECT state, status, COUNT(*) FROM tasks WHERE 1=1"+where+" GROUP BY state, status", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get state stats: %w", err)
+	}
+	defer rows.Close()
+
+	byState := make(map[string]int)
+	for rows.Next() {
+		var state string
+		var status models.TaskStatus
+		var count int
+		if err := rows.Scan(&state, &status, &count); err != nil {
+			return nil, err
+		}
+		byState[s.workflow.Resolve(state, status)] += count
+	}
+	for _, state := range s.workflow.States {
+		stats.ByState = append(stats.ByState, models.StateCount{
+			State: state.Name,
+			Label: state.DisplayName(),
+			Count: byState[state.Name],
+		})
+	}
+
+	// 计时汇总
+	timeSummary, err := s.GetTimeSummary(filter)
+	if err != nil {
+		return nil, err
+	}
+	if timeSummary.Total > 0 {
+		stats.Time = timeSummary
+	}
+
+	// 番茄钟
+	focusStats, err := s.GetFocusStats(filter)
+	if err != nil {
+		return nil, err
+	}
+	if focusStats.Completed+focusStats.Abandoned > 0 {
+		stats.Focus = focusStats
+	}
+
+	// 未完成任务的剩余工作量
+	remaining, err := s.GetRemainingEffort(filter)
+	if err != nil {
+		return nil, err
+	}
+	if remaining.Total.Tasks > 0 {
+		stats.Remaining = remaining
+	}
+
 	return stats, nil
 }
 
-// Close 关闭数据库连接
+// Close 关闭数据库连接。整库加密时重新加密工作副本并写回数据库文件
 func (s *Storage) Close() error {
-	return s.db.Close()
+	if err := s.db.Close(); err != nil {
+		return err
+	}
+	if s.sealed == nil {
+		return nil
+	}
+	err := s.sealed.close(s.path)
+	s.sealed = nil
+	return err
 }
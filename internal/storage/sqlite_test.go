@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+var (
+	newYork  = time.FixedZone("EST", -5*3600)
+	shanghai = time.FixedZone("CST", 8*3600)
+)
+
+// addDueTasks 添加截止时间分别为 dues 的任务，返回任务 ID
+func addDueTasks(t *testing.T, s *Storage, dues ...time.Time) []int64 {
+	t.Helper()
+	var ids []int64
+	for i, due := range dues {
+		task := models.NewTask(fmt.Sprintf("任务 %d", i+1), "", models.CategoryWork, models.PriorityMedium)
+		task.DueAt = &due
+		if err := s.AddTask(task); err != nil {
+			t.Fatalf("AddTask: %v", err)
+		}
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+// checkDueOrder 检查按截止时间排序和过滤的结果。
+// 任务 1 截止于 2027-01-01 03:00 UTC，任务 2 截止于 01:00 UTC，任务 3 截止于 02:00 UTC
+func checkDueOrder(t *testing.T, s *Storage) {
+	t.Helper()
+	tasks, err := s.GetAllTasks(TaskFilter{SortBy: "due_at"})
+	if err != nil {
+		t.Fatalf("GetAllTasks: %v", err)
+	}
+	if got := fmt.Sprint(taskIDs(tasks)); got != "[2 3 1]" {
+		t.Errorf("sorted by due_at = %s, want [2 3 1]", got)
+	}
+
+	boundary := time.Date(2027, 1, 1, 10, 0, 0, 0, shanghai)
+	before, err := s.GetAllTasks(TaskFilter{SortBy: "due_at", DueBefore: &boundary})
+	if err != nil {
+		t.Fatalf("GetAllTasks: %v", err)
+	}
+	if got := fmt.Sprint(taskIDs(before)); got != "[2 3]" {
+		t.Errorf("due before %s = %s, want [2 3]", boundary, got)
+	}
+	after, err := s.GetAllTasks(TaskFilter{SortBy: "due_at", DueAfter: &boundary})
+	if err != nil {
+		t.Fatalf("GetAllTasks: %v", err)
+	}
+	if got := fmt.Sprint(taskIDs(after)); got != "[3 1]" {
+		t.Errorf("due after %s = %s, want [3 1]", boundary, got)
+	}
+}
+
+// TestDueTimesWithMixedZones 截止时间带不同时区时，排序和过滤按实际时刻比较
+func TestDueTimesWithMixedZones(t *testing.T) {
+	s, err := New(filepath.Join(t.TempDir(), "todo.db"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	addDueTasks(t, s,
+		time.Date(2026, 12, 31, 22, 0, 0, 0, newYork),
+		time.Date(2027, 1, 1, 9, 0, 0, 0, shanghai),
+		time.Date(2027, 1, 1, 2, 0, 0, 0, time.UTC),
+	)
+	checkDueOrder(t, s)
+}
+
+// TestMigrateTimesToUTC 升级前按各自时区存储的时间在迁移后统一为 UTC
+func TestMigrateTimesToUTC(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "todo.db")
+	s, err := New(path)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ids := addDueTasks(t, s, time.Time{}, time.Time{}, time.Time{})
+
+	// 模拟旧版本写入的数据：时间带写入时的时区偏移
+	old := []string{"2026-12-31 22:00:00-05:00", "2027-01-01 09:00:00+08:00", "2027-01-01 02:00:00+00:00"}
+	for i, due := range old {
+		if _, err := s.db.Exec("UPDATE tasks SET due_at = ? WHERE id = ?", due, ids[i]); err != nil {
+			t.Fatalf("update due_at: %v", err)
+		}
+	}
+	if _, err := s.db.Exec(fmt.Sprintf("PRAGMA user_version = %d", SchemaVersion()-1)); err != nil {
+		t.Fatalf("set user_version: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	s, err = New(path)
+	if err != nil {
+		t.Fatalf("New after downgrade: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	checkDueOrder(t, s)
+	task, err := s.GetTask(ids[0])
+	if err != nil {
+		t.Fatalf("GetTask: %v", err)
+	}
+	if want := time.Date(2026, 12, 31, 22, 0, 0, 0, newYork); task.DueAt == nil || !task.DueAt.Equal(want) {
+		t.Errorf("due_at after migration = %v, want %v", task.DueAt, want)
+	}
+}
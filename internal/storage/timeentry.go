@@ -0,0 +1,239 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+const timeEntryColumns = `id, task_id, started_at, ended_at, note, created_at`
+
+// StartTimer 为任务开始计时。已有运行中的计时器时返回 models.ErrTimerRunning。
+func (s *Storage) StartTimer(taskID int64, at time.Time) (*models.TimeEntry, error) {
+	running, err := s.GetRunningTimer()
+	if err != nil {
+		return nil, err
+	}
+	if running != nil {
+		return nil, fmt.Errorf("%w (task %d)", models.ErrTimerRunning, running.TaskID)
+	}
+
+	entry := models.NewTimeEntry(taskID, at)
+	if err := s.addTimeEntry(entry); err != nil {
+		// 唯一索引兜底：并发启动计时器时只有一个能成功
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return nil, models.ErrTimerRunning
+		}
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// StopTimer 停止运行中的计时器，没有运行中的计时器时返回 nil
+func (s *Storage) StopTimer(at time.Time) (*models.TimeEntry, error) {
+	entry, err := s.GetRunningTimer()
+	if err != nil || entry == nil {
+		return nil, err
+	}
+
+	if at.Before(entry.StartedAt) {
+		at = entry.StartedAt
+	}
+
+	_, err = s.exec("UPDATE time_entries SET ended_at = ? WHERE id = ? AND ended_at IS NULL", at.UTC(), entry.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stop timer: %w", err)
+	}
+
+	entry.EndedAt = &at
+	return entry, nil
+}
+
+// LogTime 手动补录一段已结束的时间
+func (s *Storage) LogTime(entry *models.TimeEntry) error {
+	if entry.EndedAt == nil {
+		return fmt.Errorf("logged time entry must have an end time")
+	}
+	return s.addTimeEntry(entry)
+}
+
+// GetRunningTimer 获取运行中的计时器，没有时返回 nil
+func (s *Storage) GetRunningTimer() (*models.TimeEntry, error) {
+	query := "SELECT " + timeEntryColumns + " FROM time_entries WHERE ended_at IS NULL LIMIT 1"
+
+	entry, err := scanTimeEntry(s.db.QueryRow(query))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get running timer: %w", err)
+	}
+
+	return entry, nil
+}
+
+// ListTimeEntries 获取任务的计时记录，按开始时间排序
+func (s *Storage) ListTimeEntries(taskID int64) ([]*models.TimeEntry, error) {
+	query := "SELECT " + timeEntryColumns + " FROM time_entries WHERE task_id = ? ORDER BY started_at, id"
+
+	rows, err := s.db.Query(query, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query time entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*models.TimeEntry
+	for rows.Next() {
+		entry, err := scanTimeEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan time entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// GetTrackedTime 任务累计计时，包括运行中的计时器
+func (s *Storage) GetTrackedTime(taskID int64) (time.Duration, error) {
+	entries, err := s.ListTimeEntries(taskID)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	var total time.Duration
+	for _, entry := range entries {
+		total += entry.Duration(now)
+	}
+	return total, nil
+}
+
+// GetTimeSummary 按分类、项目和日期汇总计时，日期按计时开始的本地日期归属
+func (s *Storage) GetTimeSummary(filter StatsFilter) (*models.TimeSummary, error) {
+	where, args := filter.whereOn("t")
+	query := `
+	SELECT e.started_at, e.ended_at, t.category, t.project_id, COALESCE(p.name, '')
+	FROM time_entries e
+	JOIN tasks t ON t.id = e.task_id
+	LEFT JOIN projects p ON p.id = t.project_id
+	WHERE 1=1` + where
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query time summary: %w", err)
+	}
+	defer rows.Close()
+
+	summary := &models.TimeSummary{}
+	byCategory := make(map[string]time.Duration)
+	byProject := make(map[string]time.Duration)
+	projectNames := make(map[string]string)
+	byDay := make(map[string]time.Duration)
+	now := time.Now()
+
+	for rows.Next() {
+		var entry models.TimeEntry
+		var endedAt sql.NullTime
+		var category string
+		var projectID int64
+		var projectName string
+		if err := rows.Scan(&entry.StartedAt, &endedAt, &category, &projectID, &projectName); err != nil {
+			return nil, fmt.Errorf("failed to scan time summary: %w", err)
+		}
+		if endedAt.Valid {
+			entry.EndedAt = &endedAt.Time
+		}
+
+		d := entry.Duration(now)
+		summary.Total += d
+		byCategory[category] += d
+		if projectID != 0 {
+			key := fmt.Sprint(projectID)
+			byProject[key] += d
+			projectNames[key] = projectName
+		}
+		byDay[entry.StartedAt.Local().Format("2006-01-02")] += d
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	summary.TotalHours = hours(summary.Total)
+	summary.ByCategory = timeBuckets(byCategory, nil)
+	summary.ByProject = timeBuckets(byProject, projectNames)
+	summary.ByDay = timeBuckets(byDay, nil)
+	// 日期按时间顺序排列
+	sort.Slice(summary.ByDay, func(i, j int) bool {
+		return summary.ByDay[i].Key < summary.ByDay[j].Key
+	})
+
+	return summary, nil
+}
+
+func (s *Storage) addTimeEntry(entry *models.TimeEntry) error {
+	query := `
+	INSERT INTO time_entries (task_id, started_at, ended_at, note, created_at)
+	VALUES (?, ?, ?, ?, ?)
+	`
+
+	result, err := s.exec(query,
+		entry.TaskID, entry.StartedAt.UTC(), nullableTime(entry.EndedAt), entry.Note, entry.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add time entry: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	entry.ID = id
+	return nil
+}
+
+// timeBuckets 将汇总结果转换为按时长降序的列表，labels 为空时使用 key 作为显示名称
+func timeBuckets(durations map[string]time.Duration, labels map[string]string) []models.TimeBucket {
+	buckets := make([]models.TimeBucket, 0, len(durations))
+	for key, d := range durations {
+		label := key
+		if labels[key] != "" {
+			label = labels[key]
+		}
+		buckets = append(buckets, models.TimeBucket{Key: key, Label: label, Duration: d, Hours: hours(d)})
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		if buckets[i].Duration != buckets[j].Duration {
+			return buckets[i].Duration > buckets[j].Duration
+		}
+		return buckets[i].Key < buckets[j].Key
+	})
+	return buckets
+}
+
+// hours 时长换算为小时，保留两位小数
+func hours(d time.Duration) float64 {
+	return float64(d.Round(36*time.Second)) / float64(time.Hour)
+}
+
+func scanTimeEntry(row rowScanner) (*models.TimeEntry, error) {
+	var entry models.TimeEntry
+	var endedAt sql.NullTime
+
+	err := row.Scan(&entry.ID, &entry.TaskID, &entry.StartedAt, &endedAt, &entry.Note, &entry.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if endedAt.Valid {
+		entry.EndedAt = &endedAt.Time
+	}
+
+	return &entry, nil
+}
@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+// TrendsFilter 趋势统计范围
+type TrendsFilter struct {
+	StatsFilter
+	// From、To 统计的时间范围，To 为零值时表示到现在
+	From        time.Time
+	To          time.Time
+	Granularity models.Granularity
+}
+
+// GetProductivityTrends 根据任务的创建和完成时间计算趋势：
+// 每个周期新建和完成的任务数、平均完成耗时、连续完成天数和未完成任务的存在时长。
+func (s *Storage) GetProductivityTrends(filter TrendsFilter) (*models.ProductivityTrends, error) {
+	if filter.Granularity == "" {
+		filter.Granularity = models.GranularityDay
+	}
+	if !filter.Granularity.IsValid() {
+		return nil, fmt.Errorf("invalid granularity %q", filter.Granularity)
+	}
+	now := time.Now()
+	if filter.To.IsZero() {
+		filter.To = now
+	}
+	if !filter.From.Before(filter.To) {
+		return nil, fmt.Errorf("invalid range: %s - %s", filter.From.Format(time.RFC3339), filter.To.Format(time.RFC3339))
+	}
+
+	where, args := filter.where()
+	tasks, err := s.queryTasks("SELECT "+taskColumns+" FROM tasks WHERE 1=1"+where, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	trends := &models.ProductivityTrends{
+		From:        filter.From,
+		To:          filter.To,
+		Granularity: filter.Granularity,
+		Aging:       models.NewAgingBuckets(),
+	}
+
+	// 周期
+	index := make(map[time.Time]int)
+	g := filter.Granularity
+	for start := g.PeriodStart(filter.From); start.Before(filter.To); start = g.Next(start) {
+		index[start] = len(trends.Periods)
+		trends.Periods = append(trends.Periods, models.TrendPeriod{Start: start, Label: g.Label(start)})
+	}
+	inRange := func(t time.Time) bool {
+		return !t.Before(filter.From) && t.Before(filter.To)
+	}
+
+	byCategory := make(map[string][]time.Duration)
+	byPriority := make(map[string][]time.Duration)
+	completionDays := make(map[time.Time]bool)
+	for _, task := range tasks {
+		if inRange(task.CreatedAt) {
+			trends.Created++
+			trends.Periods[index[g.PeriodStart(task.CreatedAt)]].Created++
+		}
+
+		if task.Status == models.StatusCompleted && task.CompletedAt != nil {
+			completedAt := *task.CompletedAt
+			completionDays[models.GranularityDay.PeriodStart(completedAt)] = true
+			if inRange(completedAt) {
+				trends.Completed++
+				trends.Periods[index[g.PeriodStart(completedAt)]].Completed++
+
+				lead := completedAt.Sub(task.CreatedAt)
+				byCategory[string(task.Category)] = append(byCategory[string(task.Category)], lead)
+				priority := fmt.Sprint(int(task.Priority))
+				byPriority[priority] = append(byPriority[priority], lead)
+			}
+			continue
+		}
+
+		trends.OpenTasks++
+		models.AddAge(trends.Aging, int(now.Sub(task.CreatedAt).Hours()/24))
+	}
+
+	trends.LeadTimeByCategory = leadTimes(byCategory)
+	trends.LeadTimeByPriority = leadTimes(byPriority)
+	// 优先级从高到低
+	sort.Slice(trends.LeadTimeByPriority, func(i, j int) bool {
+		return trends.LeadTimeByPriority[i].Key > trends.LeadTimeByPriority[j].Key
+	})
+	trends.Streak = completionStreak(completionDays, now)
+
+	return trends, nil
+}
+
+// leadTimes 计算每组的平均完成耗时，按组名排序
+func leadTimes(groups map[string][]time.Duration) []models.LeadTime {
+	result := make([]models.LeadTime, 0, len(groups))
+	for key, durations := range groups {
+		var total time.Duration
+		for _, d := range durations {
+			total += d
+		}
+		average := total / time.Duration(len(durations))
+		result = append(result, models.LeadTime{
+			Key:     key,
+			Count:   len(durations),
+			Average: average,
+			Days:    float64(average.Round(864*time.Second)) / float64(24*time.Hour),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result
+}
+
+// completionStreak 计算连续有任务完成的天数。
+// 今天还没有完成任务时，截止到昨天的连续记录仍算作当前连续天数。
+func completionStreak(days map[time.Time]bool, now time.Time) models.Streak {
+	var streak models.Streak
+
+	sorted := make([]time.Time, 0, len(days))
+	for day := range days {
+		sorted = append(sorted, day)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	run := 0
+	for i, day := range sorted {
+		// 按日历日比较，避免夏令时切换导致的 23/25 小时
+		if i > 0 && sorted[i-1].AddDate(0, 0, 1).Equal(day) {
+			run++
+		} else {
+			run = 1
+		}
+		if run >= streak.Longest {
+			streak.Longest = run
+			end := day
+			streak.LongestEnd = &end
+		}
+	}
+
+	day := models.GranularityDay.PeriodStart(now)
+	if !days[day] {
+		day = day.AddDate(0, 0, -1)
+	}
+	for days[day] {
+		streak.Current++
+		day = day.AddDate(0, 0, -1)
+	}
+
+	return streak
+}
@@ -0,0 +1,292 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+// AddWebhook 添加 Webhook 订阅
+func (s *Storage) AddWebhook(hook *models.Webhook) error {
+	query := `
+	INSERT INTO webhooks (url, secret, events, filter, active, created_at)
+	VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := s.exec(query,
+		hook.URL, hook.Secret, strings.Join(hook.Events, ","),
+		hook.Filter, hook.Active, hook.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add webhook: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	hook.ID = id
+	return nil
+}
+
+// GetWebhook 获取单个 Webhook 订阅
+func (s *Storage) GetWebhook(id int64) (*models.Webhook, error) {
+	query := `
+	SELECT id, url, secret, events, filter, active, created_at
+	FROM webhooks WHERE id = ?
+	`
+
+	hook, err := scanWebhook(s.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	return hook, nil
+}
+
+// ListWebhooks 获取所有 Webhook 订阅
+func (s *Storage) ListWebhooks() ([]*models.Webhook, error) {
+	query := `
+	SELECT id, url, secret, events, filter, active, created_at
+	FROM webhooks ORDER BY id
+	`
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var hooks []*models.Webhook
+	for rows.Next() {
+		hook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		hooks = append(hooks, hook)
+	}
+
+	return hooks, rows.Err()
+}
+
+// DeleteWebhook 删除 Webhook 订阅及其投递记录
+func (s *Storage) DeleteWebhook(id int64) error {
+	result, err := s.exec("DELETE FROM webhooks WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("webhook not found")
+	}
+
+	if _, err := s.exec("DELETE FROM webhook_outbox WHERE webhook_id = ?", id); err != nil {
+		return fmt.Errorf("failed to delete webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// SetWebhookActive 启用或停用 Webhook 订阅
+func (s *Storage) SetWebhookActive(id int64, active bool) error {
+	result, err := s.exec("UPDATE webhooks SET active = ? WHERE id = ?", active, id)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("webhook not found")
+	}
+
+	return nil
+}
+
+// EnqueueDelivery 写入发件箱，等待投递
+func (s *Storage) EnqueueDelivery(delivery *models.WebhookDelivery) error {
+	query := `
+	INSERT INTO webhook_outbox (webhook_id, event, payload, status, attempts, next_attempt_at, created_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	// 任务快照包含标题和描述，fields 模式下同样加密保存
+	payload, err := s.encryptField(delivery.Payload)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.exec(query,
+		delivery.WebhookID, delivery.Event, payload, delivery.Status,
+		delivery.Attempts, delivery.NextAttemptAt.UTC(), delivery.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue delivery: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	delivery.ID = id
+	return nil
+}
+
+// GetDelivery 获取单条投递记录
+func (s *Storage) GetDelivery(id int64) (*models.WebhookDelivery, error) {
+	query := `
+	SELECT id, webhook_id, event, payload, status, attempts, next_attempt_at,
+	       last_error, created_at, delivered_at
+	FROM webhook_outbox WHERE id = ?
+	`
+
+	delivery, err := scanDelivery(s.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get delivery: %w", err)
+	}
+	if delivery.Payload, err = s.decryptField(delivery.Payload); err != nil {
+		return nil, fmt.Errorf("failed to decrypt delivery %d: %w", delivery.ID, err)
+	}
+
+	return delivery, nil
+}
+
+// GetDueDeliveries 获取到期待投递的记录
+func (s *Storage) GetDueDeliveries(now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	query := `
+	SELECT id, webhook_id, event, payload, status, attempts, next_attempt_at,
+	       last_error, created_at, delivered_at
+	FROM webhook_outbox
+	WHERE status = ? AND next_attempt_at <= ?
+	ORDER BY next_attempt_at, id
+	LIMIT ?
+	`
+
+	return s.queryDeliveries(query, models.DeliveryPending, now.UTC(), limit)
+}
+
+// ListDeliveries 获取最近的投递记录，status 为空时返回全部状态
+func (s *Storage) ListDeliveries(status models.DeliveryStatus, limit int) ([]*models.WebhookDelivery, error) {
+	query := `
+	SELECT id, webhook_id, event, payload, status, attempts, next_attempt_at,
+	       last_error, created_at, delivered_at
+	FROM webhook_outbox
+	WHERE (? = '' OR status = ?)
+	ORDER BY id DESC
+	LIMIT ?
+	`
+
+	return s.queryDeliveries(query, status, status, limit)
+}
+
+func (s *Storage) queryDeliveries(query string, args ...interface{}) ([]*models.WebhookDelivery, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*models.WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan delivery: %w", err)
+		}
+		if delivery.Payload, err = s.decryptField(delivery.Payload); err != nil {
+			return nil, fmt.Errorf("failed to decrypt delivery %d: %w", delivery.ID, err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// UpdateDelivery 更新投递状态、重试次数和下次重试时间
+func (s *Storage) UpdateDelivery(delivery *models.WebhookDelivery) error {
+	query := `
+	UPDATE webhook_outbox
+	SET status = ?, attempts = ?, next_attempt_at = ?, last_error = ?, delivered_at = ?
+	WHERE id = ?
+	`
+
+	result, err := s.exec(query,
+		delivery.Status, delivery.Attempts, delivery.NextAttemptAt.UTC(),
+		delivery.LastError, nullableTime(delivery.DeliveredAt), delivery.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update delivery: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("delivery not found")
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanWebhook(row rowScanner) (*models.Webhook, error) {
+	var hook models.Webhook
+	var events string
+
+	err := row.Scan(
+		&hook.ID, &hook.URL, &hook.Secret, &events,
+		&hook.Filter, &hook.Active, &hook.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if events != "" {
+		hook.Events = strings.Split(events, ",")
+	}
+
+	return &hook, nil
+}
+
+func scanDelivery(row rowScanner) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	var deliveredAt sql.NullTime
+
+	err := row.Scan(
+		&delivery.ID, &delivery.WebhookID, &delivery.Event, &delivery.Payload,
+		&delivery.Status, &delivery.Attempts, &delivery.NextAttemptAt,
+		&delivery.LastError, &delivery.CreatedAt, &deliveredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if deliveredAt.Valid {
+		delivery.DeliveredAt = &deliveredAt.Time
+	}
+
+	return &delivery, nil
+}
@@ -0,0 +1,123 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/WHITE13452/toDoList/internal/storage"
+	"github.com/sashabaranov/go-openai"
+)
+
+// dependencyToolDefinitions 任务依赖相关的工具定义
+func dependencyToolDefinitions() []openai.Tool {
+	return []openai.Tool{
+		{
+			Type: openai.ToolTypeFunction,
+			Function: &openai.FunctionDefinition{
+				Name:        "get_actionable_tasks",
+				Description: "获取现在就可以开始做的任务：未完成、未延后，且所有前置任务都已完成。按优先级排序。",
+				Parameters: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"project": {
+							"type": "string",
+							"description": "只返回该项目（名称）下的任务"
+						}
+					}
+				}`),
+			},
+		},
+		{
+			Type: openai.ToolTypeFunction,
+			Function: &openai.FunctionDefinition{
+				Name:        "add_dependency",
+				Description: "设置任务依赖：task_id 需要等 depends_on_id 完成后才能完成。会拒绝形成环的依赖。",
+				Parameters: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"task_id": {
+							"type": "integer",
+							"description": "需要等待的任务 ID"
+						},
+						"depends_on_id": {
+							"type": "integer",
+							"description": "前置任务 ID"
+						}
+					},
+					"required": ["task_id", "depends_on_id"]
+				}`),
+			},
+		},
+	}
+}
+
+func (t *TodoTools) getActionableTasks(arguments string) (string, error) {
+	var args struct {
+		Project string `json:"project"`
+	}
+
+	if arguments != "" && arguments != "{}" {
+		if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+			return "", fmt.Errorf("failed to parse arguments: %w", err)
+		}
+	}
+
+	projectID, err := t.resolveProject(args.Project)
+	if err != nil {
+		return "", err
+	}
+
+	tasks, err := t.storage.GetAllTasks(storage.TaskFilter{
+		ProjectID:  projectID,
+		Actionable: true,
+		SortBy:     "priority",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	result := map[string]interface{}{
+		"success": true,
+		"count":   len(tasks),
+		"tasks":   tasks,
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (t *TodoTools) addDependency(arguments string) (string, error) {
+	var args struct {
+		TaskID      int64 `json:"task_id"`
+		DependsOnID int64 `json:"depends_on_id"`
+	}
+
+	if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+		return "", fmt.Errorf("failed to parse arguments: %w", err)
+	}
+
+	if err := t.storage.AddDependency(args.TaskID, args.DependsOnID); err != nil {
+		result := map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		}
+		data, _ := json.Marshal(result)
+		return string(data), nil
+	}
+
+	result := map[string]interface{}{
+		"success": true,
+		"message": fmt.Sprintf("任务 %d 现在依赖任务 %d", args.TaskID, args.DependsOnID),
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,98 @@
+package tools
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/WHITE13452/toDoList/internal/storage"
+)
+
+const (
+	// defaultPageSize/maxPageSize 列表类工具每次返回的任务数，避免任务很多时占满模型的上下文
+	defaultPageSize = 50
+	maxPageSize     = 200
+	// summaryDescriptionLen 摘要中描述保留的字符数，完整内容用 get_task_detail 获取
+	summaryDescriptionLen = 80
+)
+
+// pageSchema 列表类工具共用的分页参数定义，拼接到 properties 中
+const pageSchema = `"limit": {
+							"type": "integer",
+							"description": "每页返回的任务数，默认 50，最多 200"
+						},
+						"cursor": {
+							"type": "string",
+							"description": "上一次结果中的 next_cursor，用于获取下一页；其余参数需要与上一次相同"
+						}`
+
+// pageArgs 列表类工具的分页参数
+type pageArgs struct {
+	Limit  int    `json:"limit"`
+	Cursor string `json:"cursor"`
+}
+
+// page 转换为存储层的分页参数，游标原样传递，由存储层校验
+func (a pageArgs) page() storage.Page {
+	limit := a.Limit
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	limit = min(limit, maxPageSize)
+
+	return storage.Page{Limit: limit, After: a.Cursor}
+}
+
+// taskSummary 列表结果中的任务摘要，只包含判断任务所需的字段
+type taskSummary struct {
+	ID          int64               `json:"id"`
+	Title       string              `json:"title"`
+	Description string              `json:"description,omitempty"`
+	Status      models.TaskStatus   `json:"status"`
+	State       string              `json:"state,omitempty"`
+	Category    models.TaskCategory `json:"category"`
+	Priority    models.Priority     `json:"priority"`
+	DueAt       *time.Time          `json:"due_at,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	ProjectID   int64               `json:"project_id,omitempty"`
+	BlockedBy   []int64             `json:"blocked_by,omitempty"`
+}
+
+// summarizeTasks 生成任务摘要，过长的描述被截断
+func summarizeTasks(tasks []*models.Task) []taskSummary {
+	summaries := make([]taskSummary, len(tasks))
+	for i, task := range tasks {
+		description := task.Description
+		if utf8.RuneCountInString(description) > summaryDescriptionLen {
+			description = string([]rune(description)[:summaryDescriptionLen]) + "…"
+		}
+		summaries[i] = taskSummary{
+			ID:          task.ID,
+			Title:       task.Title,
+			Description: description,
+			Status:      task.Status,
+			State:       task.State,
+			Category:    task.Category,
+			Priority:    task.Priority,
+			DueAt:       task.DueAt,
+			Tags:        task.Tags,
+			ProjectID:   task.ProjectID,
+			BlockedBy:   task.BlockedBy,
+		}
+	}
+	return summaries
+}
+
+// pageResult 一页任务的工具结果：任务摘要和下一页的游标
+func pageResult(page *storage.TaskPage) map[string]interface{} {
+	result := map[string]interface{}{
+		"success": true,
+		"count":   len(page.Tasks),
+		"tasks":   summarizeTasks(page.Tasks),
+	}
+	if page.Next != "" {
+		result["next_cursor"] = page.Next
+		result["note"] = "还有更多任务，需要时传入 cursor 获取下一页"
+	}
+	return result
+}
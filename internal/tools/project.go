@@ -0,0 +1,150 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/sashabaranov/go-openai"
+)
+
+// projectToolDefinitions 项目相关的工具定义
+func projectToolDefinitions() []openai.Tool {
+	return []openai.Tool{
+		{
+			Type: openai.ToolTypeFunction,
+			Function: &openai.FunctionDefinition{
+				Name:        "list_projects",
+				Description: "获取项目列表及每个项目的任务进度（总数、完成数、完成率）。",
+				Parameters: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"include_archived": {
+							"type": "boolean",
+							"description": "是否包含已归档的项目，默认不包含"
+						}
+					}
+				}`),
+			},
+		},
+		{
+			Type: openai.ToolTypeFunction,
+			Function: &openai.FunctionDefinition{
+				Name:        "create_project",
+				Description: "创建新项目。项目名称不能重复。",
+				Parameters: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"name": {
+							"type": "string",
+							"description": "项目名称（必填）"
+						},
+						"description": {
+							"type": "string",
+							"description": "项目描述（可选）"
+						}
+					},
+					"required": ["name"]
+				}`),
+			},
+		},
+	}
+}
+
+// resolveProject 将项目名称转换为 ID，名称为空时返回 0
+func (t *TodoTools) resolveProject(name string) (int64, error) {
+	if name == "" {
+		return 0, nil
+	}
+
+	project, err := t.storage.GetProjectByName(name)
+	if err != nil {
+		return 0, err
+	}
+	if project == nil {
+		return 0, fmt.Errorf("项目 %q 不存在，可以先调用 create_project 创建", name)
+	}
+	return project.ID, nil
+}
+
+func (t *TodoTools) listProjects(arguments string) (string, error) {
+	var args struct {
+		IncludeArchived bool `json:"include_archived"`
+	}
+
+	if arguments != "" && arguments != "{}" {
+		if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+			return "", fmt.Errorf("failed to parse arguments: %w", err)
+		}
+	}
+
+	projects, err := t.storage.ListProjects(args.IncludeArchived)
+	if err != nil {
+		return "", err
+	}
+
+	progress := make([]*models.ProjectProgress, 0, len(projects))
+	for _, project := range projects {
+		p, err := t.storage.GetProjectProgress(project)
+		if err != nil {
+			return "", err
+		}
+		progress = append(progress, p)
+	}
+
+	result := map[string]interface{}{
+		"success":  true,
+		"count":    len(progress),
+		"projects": progress,
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (t *TodoTools) createProject(arguments string) (string, error) {
+	var args struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+
+	if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+		return "", fmt.Errorf("failed to parse arguments: %w", err)
+	}
+
+	existing, err := t.storage.GetProjectByName(args.Name)
+	if err != nil {
+		return "", err
+	}
+	if existing != nil {
+		result := map[string]interface{}{
+			"success": false,
+			"error":   fmt.Sprintf("项目 %q 已存在", args.Name),
+			"project": existing,
+		}
+		data, _ := json.Marshal(result)
+		return string(data), nil
+	}
+
+	project := models.NewProject(args.Name, args.Description)
+	if err := t.storage.AddProject(project); err != nil {
+		return "", err
+	}
+
+	result := map[string]interface{}{
+		"success": true,
+		"message": fmt.Sprintf("项目已创建，ID: %d", project.ID),
+		"project": project,
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,214 @@
+package tools
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/dateparse"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/sashabaranov/go-openai"
+)
+
+// timerToolDefinitions 计时相关的工具定义
+func timerToolDefinitions() []openai.Tool {
+	return []openai.Tool{
+		{
+			Type: openai.ToolTypeFunction,
+			Function: &openai.FunctionDefinition{
+				Name:        "start_timer",
+				Description: "开始为任务计时。同一时间只能有一个计时器运行，switch 为 true 时会先停止当前计时器。",
+				Parameters: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"task_id": {
+							"type": "integer",
+							"description": "任务 ID"
+						},
+						"switch": {
+							"type": "boolean",
+							"description": "已有计时器运行时是否先停止它，默认 false"
+						}
+					},
+					"required": ["task_id"]
+				}`),
+			},
+		},
+		{
+			Type: openai.ToolTypeFunction,
+			Function: &openai.FunctionDefinition{
+				Name:        "stop_timer",
+				Description: "停止正在运行的计时器，返回本次时长和任务累计时长。",
+				Parameters: json.RawMessage(`{
+					"type": "object",
+					"properties": {}
+				}`),
+			},
+		},
+		{
+			Type: openai.ToolTypeFunction,
+			Function: &openai.FunctionDefinition{
+				Name:        "log_time",
+				Description: "手动补录任务耗时，例如 '给任务 5 记 1 小时 30 分钟'。",
+				Parameters: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"task_id": {
+							"type": "integer",
+							"description": "任务 ID"
+						},
+						"duration": {
+							"type": "string",
+							"description": "时长，例如 1h30m、45m、2h"
+						},
+						"note": {
+							"type": "string",
+							"description": "备注（可选）"
+						}
+					},
+					"required": ["task_id", "duration"]
+				}`),
+			},
+		},
+	}
+}
+
+func (t *TodoTools) startTimer(arguments string) (string, error) {
+	var args struct {
+		TaskID int64 `json:"task_id"`
+		Switch bool  `json:"switch"`
+	}
+
+	if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+		return "", fmt.Errorf("failed to parse arguments: %w", err)
+	}
+
+	task, err := t.storage.GetTask(args.TaskID)
+	if err != nil {
+		return "", err
+	}
+	if task == nil {
+		return "", fmt.Errorf("任务 %d 不存在", args.TaskID)
+	}
+
+	now := time.Now()
+	result := map[string]interface{}{"success": true}
+	if args.Switch {
+		stopped, err := t.storage.StopTimer(now)
+		if err != nil {
+			return "", err
+		}
+		if stopped != nil {
+			result["stopped"] = stopped
+		}
+	}
+
+	entry, err := t.storage.StartTimer(args.TaskID, now)
+	if errors.Is(err, models.ErrTimerRunning) {
+		running, _ := t.storage.GetRunningTimer()
+		result := map[string]interface{}{
+			"success": false,
+			"error":   "已有计时器在运行，可以先调用 stop_timer，或设置 switch 为 true",
+			"running": running,
+		}
+		data, _ := json.Marshal(result)
+		return string(data), nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	result["message"] = fmt.Sprintf("开始为任务 %d 计时", args.TaskID)
+	result["entry"] = entry
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (t *TodoTools) stopTimer() (string, error) {
+	now := time.Now()
+	entry, err := t.storage.StopTimer(now)
+	if err != nil {
+		return "", err
+	}
+	if entry == nil {
+		result := map[string]interface{}{
+			"success": false,
+			"error":   "没有正在运行的计时器",
+		}
+		data, _ := json.Marshal(result)
+		return string(data), nil
+	}
+
+	total, err := t.storage.GetTrackedTime(entry.TaskID)
+	if err != nil {
+		return "", err
+	}
+
+	result := map[string]interface{}{
+		"success":  true,
+		"task_id":  entry.TaskID,
+		"duration": models.FormatDuration(entry.Duration(now)),
+		"total":    models.FormatDuration(total),
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (t *TodoTools) logTime(arguments string) (string, error) {
+	var args struct {
+		TaskID   int64  `json:"task_id"`
+		Duration string `json:"duration"`
+		Note     string `json:"note"`
+	}
+
+	if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+		return "", fmt.Errorf("failed to parse arguments: %w", err)
+	}
+
+	d, err := dateparse.ParseDuration(args.Duration)
+	if err != nil || d <= 0 {
+		return "", fmt.Errorf("无法解析时长 %q，示例：1h30m、45m", args.Duration)
+	}
+
+	task, err := t.storage.GetTask(args.TaskID)
+	if err != nil {
+		return "", err
+	}
+	if task == nil {
+		return "", fmt.Errorf("任务 %d 不存在", args.TaskID)
+	}
+
+	entry := models.NewLoggedTimeEntry(args.TaskID, d, time.Now(), args.Note)
+	if err := t.storage.LogTime(entry); err != nil {
+		return "", err
+	}
+
+	total, err := t.storage.GetTrackedTime(args.TaskID)
+	if err != nil {
+		return "", err
+	}
+
+	result := map[string]interface{}{
+		"success": true,
+		"message": fmt.Sprintf("已为任务 %d 记录 %s", args.TaskID, models.FormatDuration(d)),
+		"total":   models.FormatDuration(total),
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
@@ -3,8 +3,11 @@ package tools
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/WHITE13452/toDoList/internal/dateparse"
 	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/WHITE13452/toDoList/internal/quickadd"
 	"github.com/WHITE13452/toDoList/internal/storage"
 	"github.com/sashabaranov/go-openai"
 )
@@ -21,12 +24,12 @@ func New(storage *storage.Storage) *TodoTools {
 
 // GetToolDefinitions 获取工具定义（OpenAI Function Calling 格式）
 func (t *TodoTools) GetToolDefinitions() []openai.Tool {
-	return []openai.Tool{
+	tools := []openai.Tool{
 		{
 			Type: openai.ToolTypeFunction,
 			Function: &openai.FunctionDefinition{
 				Name:        "get_all_tasks",
-				Description: "获取所有待办事项列表。可以根据状态（pending/completed）或分类（work/study/life/other）进行过滤。",
+				Description: "分页获取待办事项列表，返回任务摘要、符合条件的任务总数 total 和下一页的 next_cursor。可以根据状态（pending/completed）或分类（work/study/life/other）进行过滤。",
 				Parameters: json.RawMessage(`{
 					"type": "object",
 					"properties": {
@@ -39,7 +42,20 @@ func (t *TodoTools) GetToolDefinitions() []openai.Tool {
 							"type": "string",
 							"enum": ["work", "study", "life", "other"],
 							"description": "任务分类过滤：work(工作)、study(学习)、life(生活)、other(其他)"
-						}
+						},
+						"include_deferred": {
+							"type": "boolean",
+							"description": "是否包含延后中的任务，默认不包含"
+						},
+						"project": {
+							"type": "string",
+							"description": "只返回该项目（名称）下的任务"
+						},
+						"state": {
+							"type": "string",
+							"description": "只返回处于该工作流状态的任务，例如 in_progress、blocked"
+						},
+						` + pageSchema + `
 					}
 				}`),
 			},
@@ -69,6 +85,23 @@ func (t *TodoTools) GetToolDefinitions() []openai.Tool {
 							"type": "integer",
 							"enum": [1, 2, 3, 4],
 							"description": "优先级：1(低)、2(中)、3(高)、4(紧急)，默认为 2"
+						},
+						"due": {
+							"type": "string",
+							"description": "截止时间（可选），支持自然语言，例如 tomorrow 5pm、next friday、明天下午三点、2026-11-01"
+						},
+						"tags": {
+							"type": "array",
+							"items": {"type": "string"},
+							"description": "标签（可选）"
+						},
+						"project": {
+							"type": "string",
+							"description": "所属项目名称（可选），项目需已存在"
+						},
+						"estimate": {
+							"type": "string",
+							"description": "工作量估算（可选），时长如 2h、1h30m，或故事点如 3pt"
 						}
 					},
 					"required": ["title"]
@@ -118,14 +151,19 @@ func (t *TodoTools) GetToolDefinitions() []openai.Tool {
 			Type: openai.ToolTypeFunction,
 			Function: &openai.FunctionDefinition{
 				Name:        "search_tasks",
-				Description: "在待办事项中搜索包含指定关键词的任务（标题或描述）。",
+				Description: "在待办事项中搜索包含指定关键词的任务（标题或描述），分页返回任务摘要和下一页的 next_cursor。",
 				Parameters: json.RawMessage(`{
 					"type": "object",
 					"properties": {
 						"keyword": {
 							"type": "string",
 							"description": "搜索关键词"
-						}
+						},
+						"project": {
+							"type": "string",
+							"description": "只搜索该项目（名称）下的任务"
+						},
+						` + pageSchema + `
 					},
 					"required": ["keyword"]
 				}`),
@@ -136,7 +174,15 @@ func (t *TodoTools) GetToolDefinitions() []openai.Tool {
 			Function: &openai.FunctionDefinition{
 				Name:        "get_statistics",
 				Description: "获取待办事项的统计信息，包括总数、完成数、待办数、完成率、分类统计和优先级分布。",
-				Parameters:  json.RawMessage(`{"type": "object", "properties": {}}`),
+				Parameters: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"project": {
+							"type": "string",
+							"description": "只统计该项目（名称）下的任务"
+						}
+					}
+				}`),
 			},
 		},
 		{
@@ -178,6 +224,7 @@ func (t *TodoTools) GetToolDefinitions() []openai.Tool {
 			Type: openai.ToolTypeFunction,
 			Function: &openai.FunctionDefinition{
 				Name:        "batch_delete_tasks",
+
 				Description: "批量删除多个任务。",
 				Parameters: json.RawMessage(`{
 					"type": "object",
@@ -192,7 +239,59 @@ func (t *TodoTools) GetToolDefinitions() []openai.Tool {
 				}`),
 			},
 		},
+		{
+			Type: openai.ToolTypeFunction,
+			Function: &openai.FunctionDefinition{
+				Name:        "snooze_task",
+				Description: "延后任务，延后期间任务不会出现在任务列表中，到期后自动重新出现。指定 days/hours 或 until 之一；都不指定则取消延后。",
+				Parameters: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"task_id": {
+							"type": "integer",
+							"description": "要延后的任务 ID"
+						},
+						"days": {
+							"type": "integer",
+							"description": "延后的天数"
+						},
+						"hours": {
+							"type": "integer",
+							"description": "延后的小时数"
+						},
+						"until": {
+							"type": "string",
+							"description": "延后到指定时间，支持自然语言，例如 next monday、下周一、2026-11-01 09:00"
+						}
+					},
+					"required": ["task_id"]
+				}`),
+			},
+		},
+		{
+			Type: openai.ToolTypeFunction,
+			Function: &openai.FunctionDefinition{
+				Name:        "quick_add",
+				Description: "用快速添加语法添加任务，程序会确定性地解析语法，无需自行拆分字段。语法：!1-!4 优先级，#work/#study/#life/#other 分类，+tag 标签，due:fri 截止时间，snooze:3d 延后。例如 \"Prepare demo !3 #work +release due:fri\"。",
+				Parameters: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"text": {
+							"type": "string",
+							"description": "包含标题和快速添加语法的文本"
+						}
+					},
+					"required": ["text"]
+				}`),
+			},
+		},
 	}
+
+	tools = append(tools, projectToolDefinitions()...)
+	tools = append(tools, workflowToolDefinitions()...)
+	tools = append(tools, dependencyToolDefinitions()...)
+	tools = append(tools, timerToolDefinitions()...)
+	return append(tools, trendsToolDefinitions()...)
 }
 
 // ExecuteTool 执行工具调用
@@ -209,13 +308,37 @@ func (t *TodoTools) ExecuteTool(name, arguments string) (string, error) {
 	case "search_tasks":
 		return t.searchTasks(arguments)
 	case "get_statistics":
-		return t.getStatistics()
+		return t.getStatistics(arguments)
 	case "get_task_detail":
 		return t.getTaskDetail(arguments)
 	case "batch_complete_tasks":
 		return t.batchCompleteTasks(arguments)
 	case "batch_delete_tasks":
 		return t.batchDeleteTasks(arguments)
+	case "snooze_task":
+		return t.snoozeTask(arguments)
+	case "quick_add":
+		return t.quickAdd(arguments)
+	case "list_projects":
+		return t.listProjects(arguments)
+	case "create_project":
+		return t.createProject(arguments)
+	case "move_task":
+		return t.moveTask(arguments)
+	case "get_workflow":
+		return t.getWorkflow()
+	case "get_actionable_tasks":
+		return t.getActionableTasks(arguments)
+	case "add_dependency":
+		return t.addDependency(arguments)
+	case "start_timer":
+		return t.startTimer(arguments)
+	case "stop_timer":
+		return t.stopTimer()
+	case "log_time":
+		return t.logTime(arguments)
+	case "get_productivity_trends":
+		return t.getProductivityTrends(arguments)
 	default:
 		return "", fmt.Errorf("unknown tool: %s", name)
 	}
@@ -223,8 +346,12 @@ func (t *TodoTools) ExecuteTool(name, arguments string) (string, error) {
 
 func (t *TodoTools) getAllTasks(arguments string) (string, error) {
 	var args struct {
-		Status   models.TaskStatus   `json:"status"`
-		Category models.TaskCategory `json:"category"`
+		Status          models.TaskStatus   `json:"status"`
+		Category        models.TaskCategory `json:"category"`
+		IncludeDeferred bool                `json:"include_deferred"`
+		Project         string              `json:"project"`
+		State           string              `json:"state"`
+		pageArgs
 	}
 
 	if arguments != "" && arguments != "{}" {
@@ -233,17 +360,33 @@ func (t *TodoTools) getAllTasks(arguments string) (string, error) {
 		}
 	}
 
-	tasks, err := t.storage.GetAllTasks(args.Status, args.Category, "")
+	projectID, err := t.resolveProject(args.Project)
 	if err != nil {
 		return "", err
 	}
 
-	result := map[string]interface{}{
-		"success": true,
-		"count":   len(tasks),
-		"tasks":   tasks,
+	page := args.page()
+
+	filter := storage.TaskFilter{
+		Status:          args.Status,
+		Category:        args.Category,
+		IncludeDeferred: args.IncludeDeferred,
+		ProjectID:       projectID,
+		State:           args.State,
+		Page:            page,
+	}
+	tasks, err := t.storage.GetTaskPage(filter)
+	if err != nil {
+		return "", err
+	}
+	total, err := t.storage.CountTasks(filter)
+	if err != nil {
+		return "", err
 	}
 
+	result := pageResult(tasks)
+	result["total"] = total
+
 	data, err := json.Marshal(result)
 	if err != nil {
 		return "", err
@@ -258,6 +401,10 @@ func (t *TodoTools) addTask(arguments string) (string, error) {
 		Description string               `json:"description"`
 		Category    models.TaskCategory  `json:"category"`
 		Priority    models.Priority      `json:"priority"`
+		Due         string               `json:"due"`
+		Tags        []string             `json:"tags"`
+		Project     string               `json:"project"`
+		Estimate    string               `json:"estimate"`
 	}
 
 	if err := json.Unmarshal([]byte(arguments), &args); err != nil {
@@ -272,7 +419,32 @@ func (t *TodoTools) addTask(arguments string) (string, error) {
 		args.Priority = models.PriorityMedium
 	}
 
+	projectID, err := t.resolveProject(args.Project)
+	if err != nil {
+		return "", err
+	}
+
 	task := models.NewTask(args.Title, args.Description, args.Category, args.Priority)
+	task.ProjectID = projectID
+	for _, tag := range args.Tags {
+		task.AddTag(tag)
+	}
+	if args.Due != "" {
+		due, err := dateparse.ParseWithOptions(args.Due, time.Now(), dateparse.Options{
+			DefaultClock: 23*time.Hour + 59*time.Minute,
+		})
+		if err != nil {
+			return "", err
+		}
+		task.DueAt = &due
+	}
+	if args.Estimate != "" {
+		estimate, err := models.ParseEstimate(args.Estimate)
+		if err != nil {
+			return "", err
+		}
+		task.Estimate = estimate
+	}
 	if err := t.storage.AddTask(task); err != nil {
 		return "", err
 	}
@@ -314,6 +486,17 @@ func (t *TodoTools) updateTaskStatus(arguments string) (string, error) {
 		return string(data), nil
 	}
 
+	if args.Status == models.StatusCompleted && task.IsBlocked() {
+		result := map[string]interface{}{
+			"success":    false,
+			"error":      fmt.Sprintf("任务 %d 还有未完成的前置任务，需要先完成它们", args.TaskID),
+			"blocked_by": task.BlockedBy,
+		}
+		data, _ := json.Marshal(result)
+		return string(data), nil
+	}
+
+	// 与 todo complete 一致，直接完成或重新打开，不检查工作流的转换规则
 	if args.Status == models.StatusCompleted {
 		task.MarkCompleted()
 	} else {
@@ -372,24 +555,29 @@ func (t *TodoTools) deleteTask(arguments string) (string, error) {
 func (t *TodoTools) searchTasks(arguments string) (string, error) {
 	var args struct {
 		Keyword string `json:"keyword"`
+		Project string `json:"project"`
+		pageArgs
 	}
 
 	if err := json.Unmarshal([]byte(arguments), &args); err != nil {
 		return "", fmt.Errorf("failed to parse arguments: %w", err)
 	}
 
-	tasks, err := t.storage.SearchTasks(args.Keyword)
+	projectID, err := t.resolveProject(args.Project)
 	if err != nil {
 		return "", err
 	}
 
-	result := map[string]interface{}{
-		"success": true,
-		"count":   len(tasks),
-		"keyword": args.Keyword,
-		"tasks":   tasks,
+	page := args.page()
+
+	tasks, err := t.storage.SearchTaskPage(args.Keyword, projectID, page)
+	if err != nil {
+		return "", err
 	}
 
+	result := pageResult(tasks)
+	result["keyword"] = args.Keyword
+
 	data, err := json.Marshal(result)
 	if err != nil {
 		return "", err
@@ -398,8 +586,23 @@ func (t *TodoTools) searchTasks(arguments string) (string, error) {
 	return string(data), nil
 }
 
-func (t *TodoTools) getStatistics() (string, error) {
-	stats, err := t.storage.GetStatistics()
+func (t *TodoTools) getStatistics(arguments string) (string, error) {
+	var args struct {
+		Project string `json:"project"`
+	}
+
+	if arguments != "" && arguments != "{}" {
+		if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+			return "", fmt.Errorf("failed to parse arguments: %w", err)
+		}
+	}
+
+	projectID, err := t.resolveProject(args.Project)
+	if err != nil {
+		return "", err
+	}
+
+	stats, err := t.storage.GetStatistics(storage.StatsFilter{ProjectID: projectID})
 	if err != nil {
 		return "", err
 	}
@@ -472,6 +675,10 @@ func (t *TodoTools) batchCompleteTasks(arguments string) (string, error) {
 			continue
 		}
 
+		if task.IsBlocked() {
+			failedIDs = append(failedIDs, id)
+			continue
+		}
 		task.MarkCompleted()
 		if err := t.storage.UpdateTask(task); err != nil {
 			failedIDs = append(failedIDs, id)
@@ -530,3 +737,107 @@ func (t *TodoTools) batchDeleteTasks(arguments string) (string, error) {
 
 	return string(data), nil
 }
+
+func (t *TodoTools) snoozeTask(arguments string) (string, error) {
+	var args struct {
+		TaskID int64  `json:"task_id"`
+		Days   int    `json:"days"`
+		Hours  int    `json:"hours"`
+		Until  string `json:"until"`
+	}
+
+	if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+		return "", fmt.Errorf("failed to parse arguments: %w", err)
+	}
+
+	task, err := t.storage.GetTask(args.TaskID)
+	if err != nil {
+		return "", err
+	}
+	if task == nil {
+		result := map[string]interface{}{
+			"success": false,
+			"error":   fmt.Sprintf("任务 %d 不存在", args.TaskID),
+		}
+		data, _ := json.Marshal(result)
+		return string(data), nil
+	}
+
+	var until *time.Time
+	switch {
+	case args.Until != "":
+		parsed, err := dateparse.Parse(args.Until, time.Now())
+		if err != nil {
+			return "", err
+		}
+		until = &parsed
+	case args.Days > 0 || args.Hours > 0:
+		parsed := time.Now().AddDate(0, 0, args.Days).Add(time.Duration(args.Hours) * time.Hour)
+		until = &parsed
+	}
+
+	task.Defer(until)
+	if err := t.storage.UpdateTask(task); err != nil {
+		return "", err
+	}
+
+	message := fmt.Sprintf("任务 %d 已取消延后", args.TaskID)
+	if until != nil {
+		message = fmt.Sprintf("任务 %d 已延后至 %s", args.TaskID, until.Format("2006-01-02 15:04"))
+	}
+
+	result := map[string]interface{}{
+		"success": true,
+		"message": message,
+		"task":    task,
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (t *TodoTools) quickAdd(arguments string) (string, error) {
+	var args struct {
+		Text string `json:"text"`
+	}
+
+	if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+		return "", fmt.Errorf("failed to parse arguments: %w", err)
+	}
+
+	parsed, err := quickadd.Parse(args.Text, time.Now())
+	if err != nil {
+		result := map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		}
+		data, _ := json.Marshal(result)
+		return string(data), nil
+	}
+
+	task := parsed.Task(models.CategoryOther, models.PriorityMedium)
+	if task.ProjectID, err = t.resolveProject(parsed.Project); err != nil {
+		return "", err
+	}
+	if err := t.storage.AddTask(task); err != nil {
+		return "", err
+	}
+
+	result := map[string]interface{}{
+		"success": true,
+		"message": fmt.Sprintf("任务已添加，ID: %d", task.ID),
+		"parsed":  parsed,
+		"task":    task,
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
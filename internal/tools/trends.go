@@ -0,0 +1,93 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/dateparse"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/WHITE13452/toDoList/internal/storage"
+	"github.com/sashabaranov/go-openai"
+)
+
+// trendsToolDefinitions 趋势分析相关的工具定义
+func trendsToolDefinitions() []openai.Tool {
+	return []openai.Tool{
+		{
+			Type: openai.ToolTypeFunction,
+			Function: &openai.FunctionDefinition{
+				Name:        "get_productivity_trends",
+				Description: "获取一段时间内的生产力趋势：每天或每周新建和完成的任务数、按分类和优先级的平均完成耗时、连续完成天数、未完成任务的存在时长分布。用户问最近效率、拖延情况、完成速度时使用。",
+				Parameters: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"range": {
+							"type": "string",
+							"description": "时间范围，例如 7d、30d、12w，默认 30d"
+						},
+						"by": {
+							"type": "string",
+							"enum": ["day", "week"],
+							"description": "统计粒度，默认 day"
+						},
+						"project": {
+							"type": "string",
+							"description": "只统计该项目（名称）下的任务"
+						}
+					}
+				}`),
+			},
+		},
+	}
+}
+
+func (t *TodoTools) getProductivityTrends(arguments string) (string, error) {
+	var args struct {
+		Range   string `json:"range"`
+		By      string `json:"by"`
+		Project string `json:"project"`
+	}
+
+	if arguments != "" && arguments != "{}" {
+		if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+			return "", fmt.Errorf("failed to parse arguments: %w", err)
+		}
+	}
+
+	if args.Range == "" {
+		args.Range = "30d"
+	}
+	span, err := dateparse.ParseDuration(args.Range)
+	if err != nil {
+		return "", fmt.Errorf("无法解析时间范围 %q，示例：7d、30d、12w", args.Range)
+	}
+
+	projectID, err := t.resolveProject(args.Project)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	trends, err := t.storage.GetProductivityTrends(storage.TrendsFilter{
+		StatsFilter: storage.StatsFilter{ProjectID: projectID},
+		From:        models.RangeStart(now, span),
+		To:          now,
+		Granularity: models.Granularity(args.By),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	result := map[string]interface{}{
+		"success": true,
+		"trends":  trends,
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
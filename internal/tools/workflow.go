@@ -0,0 +1,119 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// workflowToolDefinitions 看板工作流相关的工具定义
+func workflowToolDefinitions() []openai.Tool {
+	return []openai.Tool{
+		{
+			Type: openai.ToolTypeFunction,
+			Function: &openai.FunctionDefinition{
+				Name:        "move_task",
+				Description: "将任务移动到看板的另一个工作流状态（例如 todo、in_progress、blocked、review、done），只允许工作流定义的转换。不确定有哪些状态时先调用 get_workflow。",
+				Parameters: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"task_id": {
+							"type": "integer",
+							"description": "任务 ID"
+						},
+						"state": {
+							"type": "string",
+							"description": "目标状态名称"
+						}
+					},
+					"required": ["task_id", "state"]
+				}`),
+			},
+		},
+		{
+			Type: openai.ToolTypeFunction,
+			Function: &openai.FunctionDefinition{
+				Name:        "get_workflow",
+				Description: "获取看板工作流的状态列表和允许的状态转换。",
+				Parameters:  json.RawMessage(`{"type": "object", "properties": {}}`),
+			},
+		},
+	}
+}
+
+func (t *TodoTools) moveTask(arguments string) (string, error) {
+	var args struct {
+		TaskID int64  `json:"task_id"`
+		State  string `json:"state"`
+	}
+
+	if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+		return "", fmt.Errorf("failed to parse arguments: %w", err)
+	}
+
+	task, err := t.storage.GetTask(args.TaskID)
+	if err != nil {
+		return "", err
+	}
+	if task == nil {
+		result := map[string]interface{}{
+			"success": false,
+			"error":   fmt.Sprintf("任务 %d 不存在", args.TaskID),
+		}
+		data, _ := json.Marshal(result)
+		return string(data), nil
+	}
+
+	workflow := t.storage.Workflow()
+	from := workflow.StateOf(task)
+	if target, ok := workflow.State(args.State); ok && target.Done && task.IsBlocked() {
+		result := map[string]interface{}{
+			"success":    false,
+			"error":      fmt.Sprintf("任务 %d 还有未完成的前置任务，需要先完成它们", args.TaskID),
+			"blocked_by": task.BlockedBy,
+		}
+		data, _ := json.Marshal(result)
+		return string(data), nil
+	}
+	if err := workflow.Transition(task, args.State); err != nil {
+		result := map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+			"allowed": workflow.Allowed(from),
+		}
+		data, _ := json.Marshal(result)
+		return string(data), nil
+	}
+
+	if err := t.storage.UpdateTask(task); err != nil {
+		return "", err
+	}
+
+	result := map[string]interface{}{
+		"success": true,
+		"message": fmt.Sprintf("任务 %d 已从 %s 移动到 %s", args.TaskID, from, args.State),
+		"task":    task,
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (t *TodoTools) getWorkflow() (string, error) {
+	result := map[string]interface{}{
+		"success":  true,
+		"workflow": t.storage.Workflow(),
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// handleChatDone 在对话输入框按下 Enter 时把消息发给 Agent，Esc 返回任务列表
+func (a *App) handleChatDone(key tcell.Key) {
+	if key != tcell.KeyEnter {
+		a.app.SetFocus(a.table)
+		return
+	}
+
+	message := strings.TrimSpace(a.chatInput.GetText())
+	if message == "" || a.chatting {
+		return
+	}
+
+	if a.agent == nil {
+		if a.newAgent == nil {
+			a.setError(i18n.T("tui.chat_unavailable"))
+			return
+		}
+		agentInstance, err := a.newAgent()
+		if err != nil {
+			a.setError(i18n.T("tui.agent_failed", err))
+			return
+		}
+		a.agent = agentInstance
+	}
+
+	a.chatInput.SetText("")
+	a.appendChat("[green]" + i18n.T("tui.you_said") + "[-] " + tview.Escape(message))
+	a.chatting = true
+	a.setStatus(i18n.T("tui.thinking"))
+
+	// Agent 可能调用工具修改任务，回复后重新读取任务列表
+	go func() {
+		reply, err := a.agent.Chat(context.Background(), message)
+		a.app.QueueUpdateDraw(func() {
+			a.chatting = false
+			if err != nil {
+				a.appendChat("[red]" + tview.Escape(i18n.T("tui.chat_error", err)) + "[-]")
+				a.setError(i18n.T("tui.chat_failed"))
+				return
+			}
+			a.appendChat("[blue]AI:[-] " + tview.Escape(reply))
+			if err := a.reload(); err != nil {
+				a.setError(i18n.T("tui.refresh_failed", err))
+				return
+			}
+			a.setStatus(i18n.T("tui.hint"))
+		})
+	}()
+}
+
+// appendChat 在对话面板末尾追加一条消息
+func (a *App) appendChat(text string) {
+	if a.chatView.GetText(false) != "" {
+		fmt.Fprintln(a.chatView)
+	}
+	fmt.Fprint(a.chatView, text)
+}
@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+// Filter 过滤栏的条件。输入按空格分词：
+//
+//	#work       分类
+//	+release    标签
+//	@launch     项目名称
+//	!3          最低优先级
+//	is:open     只显示未完成（默认）；is:done 只显示已完成；is:all 显示全部
+//	其他文字    标题、描述或标签中包含该关键词
+type Filter struct {
+	Keywords []string
+	Category models.TaskCategory
+	Tag      string
+	Project  string
+	// MinPriority 为 0 时不限
+	MinPriority models.Priority
+	// Status 为空时显示全部
+	Status models.TaskStatus
+}
+
+// ParseFilter 解析过滤栏输入
+func ParseFilter(input string) Filter {
+	filter := Filter{Status: models.StatusPending}
+	for _, token := range strings.Fields(input) {
+		switch {
+		case strings.HasPrefix(token, "#") && len(token) > 1:
+			filter.Category = models.TaskCategory(strings.ToLower(token[1:]))
+		case strings.HasPrefix(token, "+") && len(token) > 1:
+			filter.Tag = token[1:]
+		case strings.HasPrefix(token, "@") && len(token) > 1:
+			filter.Project = token[1:]
+		case strings.HasPrefix(token, "!") && len(token) > 1:
+			if p, err := strconv.Atoi(token[1:]); err == nil {
+				filter.MinPriority = models.Priority(p)
+			} else {
+				filter.Keywords = append(filter.Keywords, strings.ToLower(token))
+			}
+		case token == "is:open":
+			filter.Status = models.StatusPending
+		case token == "is:done":
+			filter.Status = models.StatusCompleted
+		case token == "is:all":
+			filter.Status = ""
+		default:
+			filter.Keywords = append(filter.Keywords, strings.ToLower(token))
+		}
+	}
+	return filter
+}
+
+// Match 任务是否满足条件，projectName 用于按项目名称匹配
+func (f Filter) Match(task *models.Task, projectName func(int64) string) bool {
+	if f.Status != "" && task.Status != f.Status {
+		return false
+	}
+	if f.Category != "" && task.Category != f.Category {
+		return false
+	}
+	if f.Tag != "" && !task.HasTag(f.Tag) {
+		return false
+	}
+	if f.MinPriority != 0 && task.Priority < f.MinPriority {
+		return false
+	}
+	if f.Project != "" && (task.ProjectID == 0 || !strings.EqualFold(projectName(task.ProjectID), f.Project)) {
+		return false
+	}
+
+	text := strings.ToLower(task.Title + "\n" + task.Description + "\n" + strings.Join(task.Tags, " "))
+	for _, keyword := range f.Keywords {
+		if !strings.Contains(text, keyword) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,151 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/dateparse"
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/rivo/tview"
+)
+
+// dueDefaultClock 截止时间只写日期时默认为当天 23:59，与 'todo add --due' 一致
+const dueDefaultClock = 23*time.Hour + 59*time.Minute
+
+var categories = []models.TaskCategory{
+	models.CategoryWork, models.CategoryStudy, models.CategoryLife, models.CategoryOther,
+}
+
+// showForm 显示添加或编辑任务的表单，task 为 nil 时添加新任务
+func (a *App) showForm(task *models.Task) {
+	editing := task != nil
+	if !editing {
+		task = models.NewTask("", "", models.CategoryOther, models.PriorityMedium)
+	}
+
+	categoryIndex := 0
+	categoryOptions := make([]string, len(categories))
+	for i, category := range categories {
+		categoryOptions[i] = i18n.T("category." + string(category))
+		if category == task.Category {
+			categoryIndex = i
+		}
+	}
+
+	due := ""
+	if task.DueAt != nil {
+		due = task.DueAt.Format("2006-01-02 15:04")
+	}
+	priorities := make([]string, models.PriorityUrgent)
+	for i := range priorities {
+		priorities[i] = fmt.Sprintf("%d %s", i+1, i18n.T(fmt.Sprintf("priority.%d", i+1)))
+	}
+
+	estimate := ""
+	if !task.Estimate.IsZero() {
+		estimate = task.Estimate.String()
+	}
+
+	form := tview.NewForm().
+		AddInputField(i18n.T("field.title"), task.Title, 50, nil, nil).
+		AddTextArea(i18n.T("field.description"), task.Description, 50, 3, 0, nil).
+		AddDropDown(i18n.T("field.category"), categoryOptions, categoryIndex, nil).
+		AddDropDown(i18n.T("field.priority"), priorities, int(task.Priority)-1, nil).
+		AddInputField(i18n.T("field.due_short"), due, 30, nil, nil).
+		AddInputField(i18n.T("field.tags"), strings.Join(task.Tags, ","), 30, nil, nil).
+		AddInputField(i18n.T("field.estimate"), estimate, 20, nil, nil)
+
+	form.AddButton(i18n.T("tui.save"), func() {
+		if err := a.applyForm(form, task); err != nil {
+			a.setError(err.Error())
+			return
+		}
+
+		var err error
+		if editing {
+			err = a.store.UpdateTask(task)
+		} else {
+			err = a.store.AddTask(task)
+		}
+		if err != nil {
+			a.setError(i18n.T("tui.save_failed", err))
+			return
+		}
+
+		a.closeModal()
+		if err := a.reload(); err != nil {
+			a.setError(i18n.T("tui.refresh_failed", err))
+			return
+		}
+		a.selectTask(task.ID)
+		if editing {
+			a.setStatus(i18n.T("tui.updated", task.ID))
+		} else {
+			a.setStatus(i18n.T("tui.added", task.ID))
+		}
+	})
+	form.AddButton(i18n.T("tui.cancel"), a.closeModal)
+	form.SetCancelFunc(a.closeModal)
+
+	title := i18n.T("tui.add_title")
+	if editing {
+		title = i18n.T("tui.edit_title", task.ID)
+	}
+	form.SetBorder(true).SetTitle(title)
+	a.showModal(form, 70, 21)
+}
+
+// applyForm 校验表单并写入 task，出错时不修改 task
+func (a *App) applyForm(form *tview.Form, task *models.Task) error {
+	title := strings.TrimSpace(form.GetFormItemByLabel(i18n.T("field.title")).(*tview.InputField).GetText())
+	if title == "" {
+		return errors.New(i18n.T("tui.title_required"))
+	}
+
+	var dueAt *time.Time
+	if due := strings.TrimSpace(form.GetFormItemByLabel(i18n.T("field.due_short")).(*tview.InputField).GetText()); due != "" {
+		t, err := dateparse.ParseWithOptions(due, time.Now(), dateparse.Options{DefaultClock: dueDefaultClock})
+		if err != nil {
+			return errors.New(i18n.T("time.invalid", due))
+		}
+		dueAt = &t
+	}
+
+	var estimate models.Estimate
+	if value := strings.TrimSpace(form.GetFormItemByLabel(i18n.T("field.estimate")).(*tview.InputField).GetText()); value != "" {
+		e, err := models.ParseEstimate(value)
+		if err != nil {
+			return errors.New(i18n.T("time.invalid_estimate", value))
+		}
+		estimate = e
+	}
+
+	categoryIndex, _ := form.GetFormItemByLabel(i18n.T("field.category")).(*tview.DropDown).GetCurrentOption()
+	priorityIndex, _ := form.GetFormItemByLabel(i18n.T("field.priority")).(*tview.DropDown).GetCurrentOption()
+
+	task.Title = title
+	task.Description = form.GetFormItemByLabel(i18n.T("field.description")).(*tview.TextArea).GetText()
+	task.Category = categories[categoryIndex]
+	task.Priority = models.Priority(priorityIndex + 1)
+	task.DueAt = dueAt
+	task.Estimate = estimate
+	task.Tags = nil
+	for _, tag := range strings.Split(form.GetFormItemByLabel(i18n.T("field.tags")).(*tview.InputField).GetText(), ",") {
+		task.AddTag(strings.TrimSpace(tag))
+	}
+	task.UpdatedAt = time.Now()
+	return nil
+}
+
+// selectTask 选中指定任务，任务不在当前列表中时不做任何事
+func (a *App) selectTask(id int64) {
+	for i, task := range a.visible {
+		if task.ID == id {
+			a.table.Select(i+1, 0)
+			return
+		}
+	}
+}
@@ -0,0 +1,403 @@
+// Package tui 实现全屏终端界面：任务列表、过滤栏、详情面板和 AI 对话面板。
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/agent"
+	"github.com/WHITE13452/toDoList/internal/cli"
+	"github.com/WHITE13452/toDoList/internal/i18n"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/WHITE13452/toDoList/internal/storage"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// App 全屏终端界面
+type App struct {
+	store *storage.Storage
+	// newAgent 第一次对话时创建 Agent
+	newAgent func() (*agent.Agent, error)
+	agent    *agent.Agent
+
+	app         *tview.Application
+	pages       *tview.Pages
+	table       *tview.Table
+	detail      *tview.TextView
+	filterInput *tview.InputField
+	chatView    *tview.TextView
+	chatInput   *tview.InputField
+	status      *tview.TextView
+
+	filter  Filter
+	tasks   []*models.Task
+	visible []*models.Task
+	// deleted 待删除的任务，退出时才真正删除，之前可以撤销
+	deleted []*models.Task
+	// chatting 为 true 时 Agent 正在回复
+	chatting bool
+}
+
+// New 创建终端界面，newAgent 为 nil 时不能使用对话面板
+func New(store *storage.Storage, newAgent func() (*agent.Agent, error)) *App {
+	return &App{
+		store:    store,
+		newAgent: newAgent,
+		filter:   ParseFilter(""),
+	}
+}
+
+// Run 运行终端界面直到退出，退出时提交待删除的任务
+func (a *App) Run() error {
+	a.build()
+	if err := a.reload(); err != nil {
+		return err
+	}
+
+	runErr := a.app.Run()
+	return errors.Join(runErr, a.commitDeletes())
+}
+
+// commitDeletes 删除待删除的任务。某个任务删除失败时继续删除其余任务，返回所有失败的原因
+func (a *App) commitDeletes() error {
+	var errs []error
+	for _, task := range a.deleted {
+		if err := a.store.DeleteTask(task.ID); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete task %d: %w", task.ID, err))
+		}
+	}
+	a.deleted = nil
+	return errors.Join(errs...)
+}
+
+// build 创建界面组件和布局
+func (a *App) build() {
+	a.app = tview.NewApplication()
+
+	a.table = tview.NewTable().SetSelectable(true, false).SetFixed(1, 0)
+	a.table.SetBorder(true).SetTitle(i18n.T("tui.tasks"))
+	a.table.SetSelectionChangedFunc(func(row, column int) {
+		a.showDetail()
+	})
+	a.table.SetInputCapture(a.handleTableKey)
+
+	a.detail = tview.NewTextView().SetDynamicColors(true).SetWrap(true)
+	a.detail.SetBorder(true).SetTitle(i18n.T("tui.detail"))
+
+	a.filterInput = tview.NewInputField().
+		SetLabel(i18n.T("tui.filter")).
+		SetPlaceholder(i18n.T("tui.filter_placeholder")).
+		SetFieldBackgroundColor(tcell.ColorDefault)
+	a.filterInput.SetChangedFunc(func(text string) {
+		a.filter = ParseFilter(text)
+		a.refresh()
+	})
+	a.filterInput.SetDoneFunc(func(key tcell.Key) {
+		a.app.SetFocus(a.table)
+	})
+
+	a.chatView = tview.NewTextView().SetDynamicColors(true).SetWrap(true).SetScrollable(true)
+	a.chatView.SetBorder(true).SetTitle(i18n.T("tui.chat"))
+	a.chatView.SetChangedFunc(func() {
+		a.chatView.ScrollToEnd()
+	})
+	a.chatInput = tview.NewInputField().
+		SetLabel(i18n.T("tui.you")).
+		SetFieldBackgroundColor(tcell.ColorDefault)
+	a.chatInput.SetDoneFunc(a.handleChatDone)
+
+	a.status = tview.NewTextView().SetDynamicColors(true)
+
+	main := tview.NewFlex().
+		AddItem(a.table, 0, 3, true).
+		AddItem(a.detail, 0, 2, false)
+	chat := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(a.chatView, 0, 1, false).
+		AddItem(a.chatInput, 1, 0, false)
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(a.filterInput, 1, 0, false).
+		AddItem(main, 0, 3, true).
+		AddItem(chat, 0, 1, false).
+		AddItem(a.status, 1, 0, false)
+
+	a.pages = tview.NewPages().AddPage("main", layout, true, true)
+	a.app.SetRoot(a.pages, true).SetFocus(a.table)
+	a.app.SetInputCapture(a.handleGlobalKey)
+	a.setStatus(i18n.T("tui.hint"))
+}
+
+// handleGlobalKey 全局快捷键：Tab 切换焦点
+func (a *App) handleGlobalKey(event *tcell.EventKey) *tcell.EventKey {
+	if a.pages.HasPage("modal") {
+		return event
+	}
+	if event.Key() != tcell.KeyTab {
+		return event
+	}
+	switch a.app.GetFocus() {
+	case a.table:
+		a.app.SetFocus(a.filterInput)
+	case a.filterInput:
+		a.app.SetFocus(a.chatInput)
+	default:
+		a.app.SetFocus(a.table)
+	}
+	return nil
+}
+
+// handleTableKey 任务列表中的快捷键
+func (a *App) handleTableKey(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() {
+	case tcell.KeyEscape:
+		return nil
+	case tcell.KeyRune:
+	default:
+		return event
+	}
+
+	switch event.Rune() {
+	case 'j':
+		return tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)
+	case 'k':
+		return tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone)
+	case ' ', 'x':
+		a.toggle()
+	case 'a':
+		a.showForm(nil)
+	case 'e':
+		if task := a.selected(); task != nil {
+			a.showForm(task)
+		}
+	case 'd':
+		a.delete()
+	case 'u':
+		a.undo()
+	case '/':
+		a.app.SetFocus(a.filterInput)
+	case 'c':
+		a.app.SetFocus(a.chatInput)
+	case 'r':
+		if err := a.reload(); err != nil {
+			a.setError(i18n.T("tui.refresh_failed", err))
+		} else {
+			a.setStatus(i18n.T("tui.refreshed"))
+		}
+	case '?':
+		a.showHelp()
+	case 'q':
+		a.app.Stop()
+	default:
+		return event
+	}
+	return nil
+}
+
+// reload 从数据库重新读取任务
+func (a *App) reload() error {
+	tasks, err := a.store.GetAllTasks(storage.TaskFilter{})
+	if err != nil {
+		return err
+	}
+	a.tasks = tasks
+	a.refresh()
+	return nil
+}
+
+// refresh 按过滤条件重新绘制任务列表，尽量保持选中的任务不变
+func (a *App) refresh() {
+	var selectedID int64
+	if task := a.selected(); task != nil {
+		selectedID = task.ID
+	}
+
+	deleted := make(map[int64]bool, len(a.deleted))
+	for _, task := range a.deleted {
+		deleted[task.ID] = true
+	}
+
+	a.visible = a.visible[:0]
+	for _, task := range a.tasks {
+		if !deleted[task.ID] && a.filter.Match(task, cli.ProjectName) {
+			a.visible = append(a.visible, task)
+		}
+	}
+
+	a.table.Clear()
+	for col, header := range []string{"", i18n.T("field.id"), i18n.T("field.title"), i18n.T("field.category"), i18n.T("field.priority"), i18n.T("field.due_short")} {
+		a.table.SetCell(0, col, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false))
+	}
+
+	now := time.Now()
+	selectedRow := 1
+	for i, task := range a.visible {
+		row := i + 1
+		if task.ID == selectedID {
+			selectedRow = row
+		}
+
+		color := tcell.ColorDefault
+		switch {
+		case task.Status == models.StatusCompleted:
+			color = tcell.ColorGray
+		case task.IsOverdue(now), task.IsBlocked():
+			color = tcell.ColorRed
+		}
+
+		due := ""
+		if task.DueAt != nil {
+			due = task.DueAt.Format("01-02 15:04")
+		}
+		cells := []string{
+			cli.StatusIcon(task),
+			fmt.Sprint(task.ID),
+			task.Title,
+			cli.CategoryText(task.Category),
+			strings.Repeat("!", int(task.Priority)),
+			due,
+		}
+		for col, text := range cells {
+			cell := tview.NewTableCell(tview.Escape(text)).SetTextColor(color)
+			if col == 2 {
+				cell.SetExpansion(1).SetMaxWidth(50)
+			}
+			a.table.SetCell(row, col, cell)
+		}
+	}
+
+	a.table.SetTitle(i18n.T("tui.tasks_count", len(a.visible)))
+	if len(a.visible) > 0 {
+		a.table.Select(selectedRow, 0)
+	}
+	a.showDetail()
+}
+
+// selected 当前选中的任务
+func (a *App) selected() *models.Task {
+	if a.table == nil {
+		return nil
+	}
+	row, _ := a.table.GetSelection()
+	if row < 1 || row > len(a.visible) {
+		return nil
+	}
+	return a.visible[row-1]
+}
+
+// showDetail 在详情面板显示选中任务，内容与 'todo show' 一致
+func (a *App) showDetail() {
+	task := a.selected()
+	if task == nil {
+		a.detail.SetText("[gray]" + i18n.T("tui.no_task") + "[-]")
+		return
+	}
+
+	var b strings.Builder
+	for _, field := range cli.TaskDetails(task) {
+		if field.Alert {
+			fmt.Fprintf(&b, "[red]%s: %s[-]\n", field.Label, tview.Escape(field.Value))
+		} else {
+			fmt.Fprintf(&b, "[yellow]%s:[-] %s\n", field.Label, tview.Escape(field.Value))
+		}
+	}
+	if task.Description != "" {
+		fmt.Fprintf(&b, "\n[yellow]%s:[-]\n%s\n", i18n.T("field.description"), tview.Escape(task.Description))
+	}
+	a.detail.SetText(b.String()).ScrollToBeginning()
+}
+
+// toggle 切换选中任务的完成状态，通过工作流转换
+func (a *App) toggle() {
+	task := a.selected()
+	if task == nil {
+		return
+	}
+
+	// 与 todo complete 一致，直接完成或重新打开，不检查工作流的转换规则
+	if task.Status == models.StatusCompleted {
+		task.MarkPending()
+	} else if task.IsBlocked() {
+		a.setError(i18n.T("tui.blocked", task.ID, cli.FormatTaskIDs(task.BlockedBy)))
+		return
+	} else {
+		task.MarkCompleted()
+	}
+	if err := a.store.UpdateTask(task); err != nil {
+		a.setError(i18n.T("tui.update_failed", err))
+		return
+	}
+
+	// 完成任务可能解除其他任务的阻塞，重新读取
+	if err := a.reload(); err != nil {
+		a.setError(i18n.T("tui.refresh_failed", err))
+		return
+	}
+	if task.Status == models.StatusCompleted {
+		a.setStatus(i18n.T("tui.completed", task.ID))
+	} else {
+		a.setStatus(i18n.T("tui.reopened", task.ID))
+	}
+}
+
+// delete 删除选中的任务。任务只是先隐藏，退出时才真正删除
+func (a *App) delete() {
+	task := a.selected()
+	if task == nil {
+		return
+	}
+	a.deleted = append(a.deleted, task)
+	a.refresh()
+	a.setStatus(i18n.T("tui.deleted", task.ID))
+}
+
+// undo 撤销最近一次删除
+func (a *App) undo() {
+	if len(a.deleted) == 0 {
+		a.setStatus(i18n.T("tui.nothing_to_undo"))
+		return
+	}
+	task := a.deleted[len(a.deleted)-1]
+	a.deleted = a.deleted[:len(a.deleted)-1]
+	a.refresh()
+	a.setStatus(i18n.T("tui.restored", task.ID))
+}
+
+// showHelp 显示快捷键帮助
+func (a *App) showHelp() {
+	text := tview.NewTextView().SetDynamicColors(true).SetText(i18n.T("tui.help"))
+	text.SetBorder(true).SetTitle(i18n.T("tui.help_title"))
+	text.SetDoneFunc(func(key tcell.Key) {
+		a.closeModal()
+	})
+	a.showModal(text, 70, 20)
+}
+
+// showModal 在列表上方居中显示 p
+func (a *App) showModal(p tview.Primitive, width, height int) {
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(p, height, 0, true).
+			AddItem(nil, 0, 1, false), width, 0, true).
+		AddItem(nil, 0, 1, false)
+	a.pages.AddPage("modal", modal, true, true)
+	a.app.SetFocus(p)
+}
+
+func (a *App) closeModal() {
+	a.pages.RemovePage("modal")
+	a.app.SetFocus(a.table)
+}
+
+func (a *App) setStatus(message string) {
+	a.status.SetText(" " + tview.Escape(message))
+}
+
+func (a *App) setError(message string) {
+	a.status.SetText(" [red]" + tview.Escape(message) + "[-]")
+}
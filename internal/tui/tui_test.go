@@ -0,0 +1,43 @@
+package tui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/WHITE13452/toDoList/internal/storage"
+)
+
+// TestCommitDeletesContinuesAfterFailure 某个任务删除失败时，其余待删除的任务仍然删除，所有失败都会报告
+func TestCommitDeletesContinuesAfterFailure(t *testing.T) {
+	store, err := storage.New(filepath.Join(t.TempDir(), "todo.db"))
+	if err != nil {
+		t.Fatalf("storage.New: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	var tasks []*models.Task
+	for _, title := range []string{"a", "b", "c"} {
+		task := models.NewTask(title, "", models.CategoryWork, models.PriorityMedium)
+		if err := store.AddTask(task); err != nil {
+			t.Fatalf("AddTask: %v", err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	app := New(store, nil)
+	app.deleted = []*models.Task{tasks[0], {ID: 98}, tasks[2], {ID: 99}}
+	err = app.commitDeletes()
+	if err == nil || !strings.Contains(err.Error(), "task 98") || !strings.Contains(err.Error(), "task 99") {
+		t.Errorf("commitDeletes: err = %v, want failures for tasks 98 and 99", err)
+	}
+
+	remaining, err := store.GetAllTasks(storage.TaskFilter{})
+	if err != nil {
+		t.Fatalf("GetAllTasks: %v", err)
+	}
+	if len(remaining) != 1 || remaining[0].ID != tasks[1].ID {
+		t.Errorf("remaining tasks = %v, want only task %d", remaining, tasks[1].ID)
+	}
+}
@@ -0,0 +1,135 @@
+package webhook
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+// 按长度降序排列，保证 ">=" 先于 ">" 匹配
+var operators = []string{">=", "<=", "!=", "==", ">", "<", "="}
+
+// condition 单个过滤条件，例如 priority>=4
+type condition struct {
+	field string
+	op    string
+	value string
+}
+
+// Filter 任务过滤表达式，多个条件用逗号或 && 连接，全部满足才匹配。
+//
+// 支持的字段: priority, category, status。
+// 示例: "priority>=4", "category=work,priority>=3"
+type Filter struct {
+	conditions []condition
+}
+
+// ParseFilter 解析过滤表达式，空字符串匹配所有任务
+func ParseFilter(expr string) (*Filter, error) {
+	filter := &Filter{}
+	expr = strings.ReplaceAll(expr, "&&", ",")
+
+	for _, part := range strings.Split(expr, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		cond, err := parseCondition(part)
+		if err != nil {
+			return nil, err
+		}
+		filter.conditions = append(filter.conditions, cond)
+	}
+
+	return filter, nil
+}
+
+func parseCondition(part string) (condition, error) {
+	for _, op := range operators {
+		idx := strings.Index(part, op)
+		if idx <= 0 {
+			continue
+		}
+
+		cond := condition{
+			field: strings.ToLower(strings.TrimSpace(part[:idx])),
+			op:    op,
+			value: strings.TrimSpace(part[idx+len(op):]),
+		}
+		if cond.op == "==" {
+			cond.op = "="
+		}
+
+		switch cond.field {
+		case "priority":
+			if _, err := strconv.Atoi(cond.value); err != nil {
+				return condition{}, fmt.Errorf("invalid priority in filter %q", part)
+			}
+		case "category", "status":
+			if cond.op != "=" && cond.op != "!=" {
+				return condition{}, fmt.Errorf("operator %s not supported for %s", cond.op, cond.field)
+			}
+		default:
+			return condition{}, fmt.Errorf("unknown filter field %q", cond.field)
+		}
+
+		return cond, nil
+	}
+
+	return condition{}, fmt.Errorf("invalid filter condition %q", part)
+}
+
+// Match 判断任务是否满足过滤条件
+func (f *Filter) Match(task *models.Task) bool {
+	if task == nil {
+		return len(f.conditions) == 0
+	}
+
+	for _, cond := range f.conditions {
+		if !cond.match(task) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c condition) match(task *models.Task) bool {
+	switch c.field {
+	case "priority":
+		want, _ := strconv.Atoi(c.value)
+		return compareInt(int(task.Priority), c.op, want)
+	case "category":
+		return compareString(string(task.Category), c.op, c.value)
+	case "status":
+		return compareString(string(task.Status), c.op, c.value)
+	}
+	return false
+}
+
+func compareInt(got int, op string, want int) bool {
+	switch op {
+	case "=":
+		return got == want
+	case "!=":
+		return got != want
+	case ">":
+		return got > want
+	case ">=":
+		return got >= want
+	case "<":
+		return got < want
+	case "<=":
+		return got <= want
+	}
+	return false
+}
+
+func compareString(got, op, want string) bool {
+	if op == "!=" {
+		return !strings.EqualFold(got, want)
+	}
+	return strings.EqualFold(got, want)
+}
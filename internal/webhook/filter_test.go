@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+func TestFilterMatch(t *testing.T) {
+	urgentWork := &models.Task{Priority: models.PriorityUrgent, Category: models.CategoryWork, Status: models.StatusPending}
+	lowLife := &models.Task{Priority: models.PriorityLow, Category: models.CategoryLife, Status: models.StatusCompleted}
+
+	tests := []struct {
+		expr string
+		task *models.Task
+		want bool
+	}{
+		{"", urgentWork, true},
+		{"", nil, true},
+		{"priority>=4", nil, false},
+		{"priority>=4", urgentWork, true},
+		{"priority>=4", lowLife, false},
+		{"priority>3", urgentWork, true},
+		{"priority<2", lowLife, true},
+		{"priority<=1", urgentWork, false},
+		{"priority==1", lowLife, true},
+		{"priority!=1", lowLife, false},
+		{"category=work", urgentWork, true},
+		{"category=WORK", urgentWork, true},
+		{"category!=work", lowLife, true},
+		{"status=completed", lowLife, true},
+		{"category=work,priority>=3", urgentWork, true},
+		{"category=work && priority>=3", lowLife, false},
+		{" category = life , status = completed ", lowLife, true},
+	}
+
+	for _, tt := range tests {
+		filter, err := ParseFilter(tt.expr)
+		if err != nil {
+			t.Errorf("ParseFilter(%q): %v", tt.expr, err)
+			continue
+		}
+		if got := filter.Match(tt.task); got != tt.want {
+			t.Errorf("ParseFilter(%q).Match(%+v) = %v, want %v", tt.expr, tt.task, got, tt.want)
+		}
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	for _, expr := range []string{
+		"priority>=high",
+		"category>work",
+		"owner=me",
+		"priority",
+		"=4",
+	} {
+		if _, err := ParseFilter(expr); err == nil {
+			t.Errorf("ParseFilter(%q) succeeded, want error", expr)
+		}
+	}
+}
@@ -0,0 +1,305 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/events"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/WHITE13452/toDoList/internal/storage"
+)
+
+const (
+	// SignatureHeader HMAC-SHA256 签名请求头，值为 "sha256=<hex>"
+	SignatureHeader = "X-Todo-Signature"
+	// EventHeader 事件类型请求头
+	EventHeader = "X-Todo-Event"
+	// DeliveryHeader 投递 ID 请求头，接收方可据此去重
+	DeliveryHeader = "X-Todo-Delivery"
+
+	// TestEvent 用于 `todo webhook test` 的测试事件
+	TestEvent = "webhook.test"
+)
+
+const (
+	// FlushBudget 命令产生了新事件时，退出前投递发件箱的最长时间
+	FlushBudget = 10 * time.Second
+	// RetryBudget 命令没有产生新事件时，退出前重试到期投递的最长时间
+	RetryBudget = 2 * time.Second
+)
+
+// Payload Webhook 请求体
+type Payload struct {
+	Event      string       `json:"event"`
+	OccurredAt time.Time    `json:"occurred_at"`
+	Task       *models.Task `json:"task,omitempty"`
+}
+
+// Dispatcher 将任务事件写入发件箱并负责投递和重试
+type Dispatcher struct {
+	storage     *storage.Storage
+	client      *http.Client
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+
+	enqueued int
+}
+
+// NewDispatcher 创建 Webhook 投递器
+func NewDispatcher(storage *storage.Storage) *Dispatcher {
+	return &Dispatcher{
+		storage:     storage,
+		client:      &http.Client{Timeout: 5 * time.Second},
+		MaxAttempts: 8,
+		BaseBackoff: 30 * time.Second,
+		MaxBackoff:  time.Hour,
+	}
+}
+
+// GenerateSecret 生成随机签名密钥
+func GenerateSecret() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate secret: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// Sign 计算请求体的 HMAC-SHA256 签名
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Attach 订阅事件总线
+func (d *Dispatcher) Attach(bus *events.Bus) {
+	bus.SubscribeAll(d.Handle)
+}
+
+// Handle 为匹配事件的订阅写入发件箱，实际投递由 Flush 完成
+func (d *Dispatcher) Handle(event events.Event) {
+	if err := d.enqueue(event); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ webhook: %v\n", err)
+	}
+}
+
+func (d *Dispatcher) enqueue(event events.Event) error {
+	hooks, err := d.storage.ListWebhooks()
+	if err != nil {
+		return err
+	}
+
+	for _, hook := range hooks {
+		if !hook.Active || !subscribed(hook, string(event.Type)) {
+			continue
+		}
+
+		filter, err := ParseFilter(hook.Filter)
+		if err != nil {
+			return fmt.Errorf("webhook %d: %w", hook.ID, err)
+		}
+		if !filter.Match(event.Task) {
+			continue
+		}
+
+		body, err := json.Marshal(Payload{
+			Event:      string(event.Type),
+			OccurredAt: event.OccurredAt,
+			Task:       event.Task,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to encode payload: %w", err)
+		}
+
+		now := time.Now()
+		delivery := &models.WebhookDelivery{
+			WebhookID:     hook.ID,
+			Event:         string(event.Type),
+			Payload:       string(body),
+			Status:        models.DeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		}
+		if err := d.storage.EnqueueDelivery(delivery); err != nil {
+			return err
+		}
+		d.enqueued++
+	}
+
+	return nil
+}
+
+func subscribed(hook *models.Webhook, event string) bool {
+	if len(hook.Events) == 0 {
+		return true
+	}
+	for _, e := range hook.Events {
+		if e == event || e == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// HasEnqueued 当前进程是否写入过新的投递
+func (d *Dispatcher) HasEnqueued() bool {
+	return d.enqueued > 0
+}
+
+// Flush 投递所有到期的发件箱记录，返回成功投递数量
+func (d *Dispatcher) Flush(ctx context.Context) (int, error) {
+	deliveries, err := d.storage.GetDueDeliveries(time.Now(), 100)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			break
+		}
+		ok, err := d.Deliver(ctx, delivery)
+		if err != nil {
+			return delivered, err
+		}
+		if ok {
+			delivered++
+		}
+	}
+
+	return delivered, nil
+}
+
+// Deliver 尝试投递一条记录并更新发件箱。投递失败时按指数退避安排重试，
+// 超过最大重试次数后标记为 failed。订阅已停用时不发送，直接标记为 failed，
+// 重新启用后可以用 Replay 重新投递。
+func (d *Dispatcher) Deliver(ctx context.Context, delivery *models.WebhookDelivery) (bool, error) {
+	hook, err := d.storage.GetWebhook(delivery.WebhookID)
+	if err != nil {
+		return false, err
+	}
+
+	// 停用的订阅不发送，也不计入重试次数
+	if hook != nil && !hook.Active {
+		delivery.Status = models.DeliveryFailed
+		delivery.LastError = "webhook is disabled"
+		return false, d.storage.UpdateDelivery(delivery)
+	}
+
+	delivery.Attempts++
+	if hook == nil {
+		delivery.Status = models.DeliveryFailed
+		delivery.LastError = "webhook not found"
+		return false, d.storage.UpdateDelivery(delivery)
+	}
+
+	sendErr := d.send(ctx, hook, delivery.ID, delivery.Event, []byte(delivery.Payload))
+	now := time.Now()
+
+	switch {
+	case sendErr == nil:
+		delivery.Status = models.DeliveryDelivered
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= d.MaxAttempts:
+		delivery.Status = models.DeliveryFailed
+		delivery.LastError = sendErr.Error()
+	default:
+		delivery.LastError = sendErr.Error()
+		delivery.NextAttemptAt = now.Add(d.backoff(delivery.Attempts))
+	}
+
+	if err := d.storage.UpdateDelivery(delivery); err != nil {
+		return false, err
+	}
+
+	return sendErr == nil, nil
+}
+
+// backoff 计算第 attempt 次失败后的等待时间
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	wait := d.BaseBackoff
+	for i := 1; i < attempt; i++ {
+		wait *= 2
+		if wait >= d.MaxBackoff {
+			return d.MaxBackoff
+		}
+	}
+	return wait
+}
+
+// Replay 将投递记录重新置为待投递并立即投递
+func (d *Dispatcher) Replay(ctx context.Context, id int64) (*models.WebhookDelivery, error) {
+	delivery, err := d.storage.GetDelivery(id)
+	if err != nil {
+		return nil, err
+	}
+	if delivery == nil {
+		return nil, fmt.Errorf("delivery %d not found", id)
+	}
+
+	delivery.Status = models.DeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+	delivery.DeliveredAt = nil
+
+	if _, err := d.Deliver(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// Test 向订阅发送一条测试事件（不写入发件箱）
+func (d *Dispatcher) Test(ctx context.Context, hook *models.Webhook) error {
+	body, err := json.Marshal(Payload{
+		Event:      TestEvent,
+		OccurredAt: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return d.send(ctx, hook, 0, TestEvent, body)
+}
+
+func (d *Dispatcher) send(ctx context.Context, hook *models.Webhook, deliveryID int64, event string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "todo-webhook/1.0")
+	req.Header.Set(EventHeader, event)
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(deliveryID, 10))
+	if hook.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(hook.Secret, body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,292 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/WHITE13452/toDoList/internal/events"
+	"github.com/WHITE13452/toDoList/internal/models"
+	"github.com/WHITE13452/toDoList/internal/storage"
+)
+
+// receiver 记录收到的 Webhook 请求的测试服务器
+type receiver struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	status   int
+	requests []*received
+}
+
+type received struct {
+	header http.Header
+	body   []byte
+}
+
+func newReceiver(t *testing.T, status int) *receiver {
+	r := &receiver{status: status}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		r.requests = append(r.requests, &received{header: req.Header.Clone(), body: body})
+		status := r.status
+		r.mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *receiver) received() []*received {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*received(nil), r.requests...)
+}
+
+func newTestDispatcher(t *testing.T) (*Dispatcher, *storage.Storage) {
+	store, err := storage.New(filepath.Join(t.TempDir(), "todo.db"))
+	if err != nil {
+		t.Fatalf("storage.New: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return NewDispatcher(store), store
+}
+
+func addWebhook(t *testing.T, store *storage.Storage, hook *models.Webhook) {
+	hook.Active = true
+	if err := store.AddWebhook(hook); err != nil {
+		t.Fatalf("AddWebhook: %v", err)
+	}
+}
+
+func TestFlushSignsPayload(t *testing.T) {
+	d, store := newTestDispatcher(t)
+	recv := newReceiver(t, http.StatusOK)
+	addWebhook(t, store, &models.Webhook{URL: recv.URL, Secret: "s3cret"})
+
+	task := &models.Task{ID: 7, Title: "写周报", Priority: models.PriorityHigh}
+	d.Handle(events.New(events.TaskCompleted, task))
+
+	delivered, err := d.Flush(context.Background())
+	if err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if delivered != 1 {
+		t.Fatalf("delivered = %d, want 1", delivered)
+	}
+
+	requests := recv.received()
+	if len(requests) != 1 {
+		t.Fatalf("got %d requests, want 1", len(requests))
+	}
+	req := requests[0]
+	if got, want := req.header.Get(SignatureHeader), Sign("s3cret", req.body); got != want {
+		t.Errorf("signature = %q, want %q", got, want)
+	}
+	if got := req.header.Get(EventHeader); got != string(events.TaskCompleted) {
+		t.Errorf("event header = %q, want %q", got, events.TaskCompleted)
+	}
+	if req.header.Get(DeliveryHeader) == "" {
+		t.Error("missing delivery header")
+	}
+
+	var payload Payload
+	if err := json.Unmarshal(req.body, &payload); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	if payload.Event != string(events.TaskCompleted) || payload.Task == nil || payload.Task.ID != 7 {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+
+	// 投递成功后不再重复投递
+	if delivered, _ := d.Flush(context.Background()); delivered != 0 {
+		t.Errorf("second flush delivered %d, want 0", delivered)
+	}
+}
+
+func TestFlushWithoutSecretHasNoSignature(t *testing.T) {
+	d, store := newTestDispatcher(t)
+	recv := newReceiver(t, http.StatusOK)
+	addWebhook(t, store, &models.Webhook{URL: recv.URL})
+
+	d.Handle(events.New(events.TaskCreated, &models.Task{ID: 1, Title: "a"}))
+	if _, err := d.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	requests := recv.received()
+	if len(requests) != 1 {
+		t.Fatalf("got %d requests, want 1", len(requests))
+	}
+	if sig := requests[0].header.Get(SignatureHeader); sig != "" {
+		t.Errorf("unexpected signature %q", sig)
+	}
+}
+
+// TestFlushSkipsDisabledWebhook 订阅停用后发件箱中已有的记录不再发送，重新启用后可以重新投递
+func TestFlushSkipsDisabledWebhook(t *testing.T) {
+	d, store := newTestDispatcher(t)
+	recv := newReceiver(t, http.StatusOK)
+	hook := &models.Webhook{URL: recv.URL}
+	addWebhook(t, store, hook)
+
+	d.Handle(events.New(events.TaskCreated, &models.Task{ID: 1, Title: "a"}))
+	if err := store.SetWebhookActive(hook.ID, false); err != nil {
+		t.Fatalf("SetWebhookActive: %v", err)
+	}
+
+	delivered, err := d.Flush(context.Background())
+	if err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if delivered != 0 || len(recv.received()) != 0 {
+		t.Fatalf("disabled webhook: delivered %d, receiver got %d requests; want none", delivered, len(recv.received()))
+	}
+
+	failed, err := store.ListDeliveries(models.DeliveryFailed, 10)
+	if err != nil || len(failed) != 1 {
+		t.Fatalf("failed deliveries = %d, %v; want 1", len(failed), err)
+	}
+	if failed[0].Attempts != 0 || failed[0].LastError == "" {
+		t.Errorf("parked delivery: attempts %d, last error %q; want 0 attempts and an error", failed[0].Attempts, failed[0].LastError)
+	}
+
+	// 停用期间的新事件不写入发件箱
+	d.Handle(events.New(events.TaskCreated, &models.Task{ID: 2, Title: "b"}))
+	if due, _ := store.GetDueDeliveries(time.Now(), 10); len(due) != 0 {
+		t.Errorf("%d deliveries queued for a disabled webhook", len(due))
+	}
+
+	if err := store.SetWebhookActive(hook.ID, true); err != nil {
+		t.Fatalf("SetWebhookActive: %v", err)
+	}
+	replayed, err := d.Replay(context.Background(), failed[0].ID)
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	if replayed.Status != models.DeliveryDelivered || len(recv.received()) != 1 {
+		t.Errorf("replay after enabling: status %s, receiver got %d requests", replayed.Status, len(recv.received()))
+	}
+}
+
+func TestDeliverBackoff(t *testing.T) {
+	d, store := newTestDispatcher(t)
+	d.MaxAttempts = 3
+	recv := newReceiver(t, http.StatusInternalServerError)
+	addWebhook(t, store, &models.Webhook{URL: recv.URL})
+
+	d.Handle(events.New(events.TaskCreated, &models.Task{ID: 1, Title: "a"}))
+	deliveries, err := store.GetDueDeliveries(time.Now(), 10)
+	if err != nil || len(deliveries) != 1 {
+		t.Fatalf("GetDueDeliveries = %d, %v; want 1 delivery", len(deliveries), err)
+	}
+	delivery := deliveries[0]
+
+	for attempt := 1; attempt <= d.MaxAttempts; attempt++ {
+		before := time.Now()
+		ok, err := d.Deliver(context.Background(), delivery)
+		if err != nil {
+			t.Fatalf("attempt %d: Deliver: %v", attempt, err)
+		}
+		if ok {
+			t.Fatalf("attempt %d: delivery succeeded against failing receiver", attempt)
+		}
+
+		stored, err := store.GetDelivery(delivery.ID)
+		if err != nil {
+			t.Fatalf("GetDelivery: %v", err)
+		}
+		if stored.Attempts != attempt {
+			t.Errorf("attempt %d: attempts = %d", attempt, stored.Attempts)
+		}
+		if stored.LastError == "" {
+			t.Errorf("attempt %d: last error not recorded", attempt)
+		}
+
+		if attempt == d.MaxAttempts {
+			if stored.Status != models.DeliveryFailed {
+				t.Errorf("status after %d attempts = %s, want failed", attempt, stored.Status)
+			}
+			break
+		}
+		if stored.Status != models.DeliveryPending {
+			t.Errorf("attempt %d: status = %s, want pending", attempt, stored.Status)
+		}
+		wait := d.backoff(attempt)
+		if stored.NextAttemptAt.Before(before.Add(wait)) || stored.NextAttemptAt.After(time.Now().Add(wait)) {
+			t.Errorf("attempt %d: next attempt at %s, want about %s from now", attempt, stored.NextAttemptAt, wait)
+		}
+
+		// 退避期间的投递不会被 Flush 取出
+		if due, _ := store.GetDueDeliveries(time.Now(), 10); len(due) != 0 {
+			t.Errorf("attempt %d: %d deliveries due during backoff", attempt, len(due))
+		}
+	}
+
+	if got := len(recv.received()); got != d.MaxAttempts {
+		t.Errorf("receiver got %d requests, want %d", got, d.MaxAttempts)
+	}
+}
+
+func TestBackoffDoublesUpToMax(t *testing.T) {
+	d := &Dispatcher{BaseBackoff: 30 * time.Second, MaxBackoff: 5 * time.Minute}
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{4, 4 * time.Minute},
+		{5, 5 * time.Minute},
+		{10, 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := d.backoff(tt.attempt); got != tt.want {
+			t.Errorf("backoff(%d) = %s, want %s", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestHandleAppliesFilterAndEvents(t *testing.T) {
+	d, store := newTestDispatcher(t)
+	recv := newReceiver(t, http.StatusOK)
+	addWebhook(t, store, &models.Webhook{
+		URL:    recv.URL,
+		Events: []string{string(events.TaskCompleted)},
+		Filter: "priority>=4,category=work",
+	})
+
+	d.Handle(events.New(events.TaskCompleted, &models.Task{ID: 1, Priority: models.PriorityUrgent, Category: models.CategoryWork}))
+	// 不满足过滤条件
+	d.Handle(events.New(events.TaskCompleted, &models.Task{ID: 2, Priority: models.PriorityLow, Category: models.CategoryWork}))
+	d.Handle(events.New(events.TaskCompleted, &models.Task{ID: 3, Priority: models.PriorityUrgent, Category: models.CategoryLife}))
+	// 未订阅的事件
+	d.Handle(events.New(events.TaskCreated, &models.Task{ID: 4, Priority: models.PriorityUrgent, Category: models.CategoryWork}))
+
+	if !d.HasEnqueued() {
+		t.Fatal("HasEnqueued = false after matching event")
+	}
+	if _, err := d.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	requests := recv.received()
+	if len(requests) != 1 {
+		t.Fatalf("got %d requests, want 1", len(requests))
+	}
+	var payload Payload
+	if err := json.Unmarshal(requests[0].body, &payload); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	if payload.Task == nil || payload.Task.ID != 1 {
+		t.Errorf("delivered task %+v, want task 1", payload.Task)
+	}
+}
@@ -0,0 +1,60 @@
+// Package workflow 加载看板工作流配置。
+//
+// 配置文件为 JSON，例如：
+//
+//	{
+//	  "initial": "todo",
+//	  "states": [
+//	    {"name": "todo", "label": "待办"},
+//	    {"name": "doing", "label": "进行中"},
+//	    {"name": "done", "label": "完成", "done": true}
+//	  ],
+//	  "transitions": {
+//	    "todo": ["doing"],
+//	    "doing": ["todo", "done"],
+//	    "done": ["todo"]
+//	  }
+//	}
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/WHITE13452/toDoList/internal/models"
+)
+
+// DefaultPath 返回工作流配置文件的默认路径，可通过 TODO_WORKFLOW 环境变量覆盖
+func DefaultPath() (string, error) {
+	if path := os.Getenv("TODO_WORKFLOW"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get config directory: %w", err)
+	}
+	return filepath.Join(dir, "todo", "workflow.json"), nil
+}
+
+// Load 读取工作流配置，文件不存在时返回默认工作流
+func Load(path string) (*models.Workflow, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return models.DefaultWorkflow(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workflow config: %w", err)
+	}
+
+	var workflow models.Workflow
+	if err := json.Unmarshal(data, &workflow); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow config %s: %w", path, err)
+	}
+	if err := workflow.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid workflow config %s: %w", path, err)
+	}
+
+	return &workflow, nil
+}
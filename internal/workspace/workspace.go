@@ -0,0 +1,162 @@
+// Package workspace 管理工作区。每个工作区有独立的数据库，存放在 XDG 数据目录下：
+//
+//	$XDG_DATA_HOME/todo/todolist.db          默认工作区
+//	$XDG_DATA_HOME/todo/workspaces/<名称>.db  其他工作区
+//
+// 未设置 XDG_DATA_HOME 时使用 ~/.local/share。运行时文件（密钥代理的 socket、整库加密数据库的工作副本）
+// 存放在 XDG_RUNTIME_DIR 下，解密后的明文只会写入位于内存中的目录。
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Default 默认工作区的名称
+const Default = "default"
+
+// LegacyFile 旧版本在当前目录创建的数据库文件名
+const LegacyFile = ".todolist.db"
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// Workspace 一个工作区
+type Workspace struct {
+	Name string
+	Path string
+}
+
+// DataDir 数据目录，可通过 XDG_DATA_HOME 环境变量修改
+func DataDir() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); filepath.IsAbs(dir) {
+		return filepath.Join(dir, "todo"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".local", "share", "todo"), nil
+}
+
+// RuntimeDir 运行时目录，存放密钥代理的 socket。
+// 优先使用 XDG_RUNTIME_DIR（通常位于内存中，注销后清空），否则使用系统临时目录下的私有目录
+func RuntimeDir() (string, error) {
+	dir := filepath.Join(os.TempDir(), fmt.Sprintf("todo-%d", os.Getuid()))
+	if runtime := os.Getenv("XDG_RUNTIME_DIR"); filepath.IsAbs(runtime) {
+		dir = filepath.Join(runtime, "todo")
+	}
+	return privateDir(dir)
+}
+
+// shmDir Linux 上位于内存中的共享内存文件系统
+const shmDir = "/dev/shm"
+
+// PlaintextDir 存放解密后的明文数据库（整库加密数据库的工作副本、加密备份的临时副本）的目录。
+// 目录必须位于内存中，异常退出后重启也不会在磁盘上留下明文：优先使用 XDG_RUNTIME_DIR，
+// 其次是 /dev/shm 下的私有目录。都不可用时返回错误，不会退回到磁盘上的临时目录
+func PlaintextDir() (string, error) {
+	if runtime := os.Getenv("XDG_RUNTIME_DIR"); filepath.IsAbs(runtime) {
+		return privateDir(filepath.Join(runtime, "todo"))
+	}
+	if info, err := os.Stat(shmDir); err == nil && info.IsDir() {
+		return privateDir(filepath.Join(shmDir, fmt.Sprintf("todo-%d", os.Getuid())))
+	}
+	return "", fmt.Errorf("no in-memory directory for decrypted data: set XDG_RUNTIME_DIR to a tmpfs directory, or use fields encryption")
+}
+
+// privateDir 创建只有当前用户可以访问的目录
+func privateDir(dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create runtime directory: %w", err)
+	}
+	// 目录可能由其他用户预先创建，确认只有当前用户可以访问
+	info, err := os.Stat(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to check runtime directory: %w", err)
+	}
+	if info.Mode().Perm()&0o077 != 0 {
+		if err := os.Chmod(dir, 0o700); err != nil {
+			return "", fmt.Errorf("runtime directory %s is accessible by other users: %w", dir, err)
+		}
+	}
+	return dir, nil
+}
+
+// ValidateName 检查工作区名称：字母或数字开头，只包含字母、数字、"_"、"." 和 "-"
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid workspace name %q: use letters, digits, '_', '.' and '-'", name)
+	}
+	return nil
+}
+
+// Path 工作区数据库的路径，name 为空表示默认工作区
+func Path(name string) (string, error) {
+	if name == "" {
+		name = Default
+	}
+	if err := ValidateName(name); err != nil {
+		return "", err
+	}
+	dir, err := DataDir()
+	if err != nil {
+		return "", err
+	}
+	if name == Default {
+		return filepath.Join(dir, "todolist.db"), nil
+	}
+	return filepath.Join(dir, "workspaces", name+".db"), nil
+}
+
+// Exists 工作区是否已创建，默认工作区总是存在
+func Exists(name string) (bool, error) {
+	if name == "" || name == Default {
+		return true, nil
+	}
+	path, err := Path(name)
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check workspace %q: %w", name, err)
+	}
+	return true, nil
+}
+
+// List 列出默认工作区和所有已创建的工作区，默认工作区在最前
+func List() ([]Workspace, error) {
+	path, err := Path(Default)
+	if err != nil {
+		return nil, err
+	}
+	workspaces := []Workspace{{Name: Default, Path: path}}
+
+	dir := filepath.Join(filepath.Dir(path), "workspaces")
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return workspaces, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".db")
+		if ok && !entry.IsDir() && ValidateName(name) == nil && name != Default {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		workspaces = append(workspaces, Workspace{Name: name, Path: filepath.Join(dir, name+".db")})
+	}
+	return workspaces, nil
+}